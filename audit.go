@@ -0,0 +1,46 @@
+package kid
+
+import (
+	"encoding/hex"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WithAudit makes a Generator write a record of every value it issues to w,
+// for environments that must show what entropy went into each identifier:
+// one line per ID, ID64 or ID128, holding its encoded form, its timestamp
+// (RFC 3339, milliseconds, UTC), its sequence and its random bytes in hex,
+// separated by spaces:
+//
+//	06bqer9xnm79tfnl 2024-05-01T12:00:00.123Z 291 a1b2
+//
+// ULIDs are recorded as the ID128 they are built from. Writes are serialized
+// by the Generator, so w need not be goroutine-safe, but each write happens
+// as the value is generated, on the caller's goroutine: buffer w if it is
+// slow. New panics if w returns an error; Generate returns it. Without
+// WithAudit, generation pays a single nil check.
+func WithAudit(w io.Writer) Option[Generator] {
+	return func(g *Generator) {
+		g.audit = w
+	}
+}
+
+// record writes the audit record of a value issued as enc, with timestamp
+// milli, relative to g's epoch, sequence seq and random bytes random.
+func (g *Generator) record(enc string, milli, seq int64, random []byte) error {
+	t := time.UnixMilli(g.epoch/nanoPerMilli + milli).UTC()
+	b := make([]byte, 0, 64)
+	b = append(b, enc...)
+	b = append(b, ' ')
+	b = t.AppendFormat(b, "2006-01-02T15:04:05.000Z07:00")
+	b = append(b, ' ')
+	b = strconv.AppendInt(b, seq, 10)
+	b = append(b, ' ')
+	b = hex.AppendEncode(b, random)
+	b = append(b, '\n')
+	g.amu.Lock()
+	defer g.amu.Unlock()
+	_, err := g.audit.Write(b)
+	return err
+}
@@ -0,0 +1,67 @@
+package kid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithAudit(t *testing.T) {
+	// 74,496ns into the millisecond: sequence 291
+	c := &fakeClock{t: time.Date(2024, 5, 1, 12, 0, 0, 123074496, time.UTC)}
+	src := []byte{0xa1, 0xb2, 1, 2, 3, 4, 5, 6, 7, 8, 0xff, 0xff}
+	var buf bytes.Buffer
+	g := NewGenerator(WithClock(c.now), WithEpoch(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+		WithRandom(bytes.NewReader(src)), WithUnbufferedRandom(), WithAudit(&buf))
+
+	id, id128, id64 := g.New(), g.New128(), g.New64()
+	want := fmt.Sprintf("%s 2024-05-01T12:00:00.123Z 291 a1b2\n", id) +
+		fmt.Sprintf("%s 2024-05-01T12:00:00.123Z 292 0102030405060708\n", id128) +
+		fmt.Sprintf("%s 2024-05-01T12:00:00.123Z 293 03ff\n", id64)
+	if buf.String() != want {
+		t.Errorf("audit records:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if id[8] != 0xa1 || id[9] != 0xb2 || id.Sequence() != 291 || !g.Time(id).Equal(c.t.Truncate(time.Millisecond)) {
+		t.Errorf("New() = %v (% x), not as recorded", id, id[:])
+	}
+
+	// NewWithTime and Generate are recorded too
+	buf.Reset()
+	g = NewGenerator(WithNoRandom(), WithAudit(&buf))
+	dated := g.NewWithTime(c.t)
+	fresh, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], dated.String()+" 2024-05-01T12:00:00.123Z 291 0000") ||
+		!strings.HasPrefix(lines[1], fresh.String()+" ") {
+		t.Errorf("audit records = %q, want %v then %v", buf.String(), dated, fresh)
+	}
+}
+
+type failingWriter struct{}
+
+var errAuditFull = errors.New("audit log full")
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errAuditFull }
+
+func TestWithAuditError(t *testing.T) {
+	g := NewGenerator(WithAudit(failingWriter{}))
+	if _, err := g.Generate(); !errors.Is(err, errAuditFull) {
+		t.Errorf("Generate() error = %v, want %v", err, errAuditFull)
+	}
+	for name, f := range map[string]func(){"New": func() { g.New() }, "New128": func() { g.New128() }, "New64": func() { g.New64() }} {
+		func() {
+			defer func() {
+				if r := recover(); fmtPanic(r) != errAuditFull.Error() {
+					t.Errorf("%s() panicked with %v, want %v", name, r, errAuditFull)
+				}
+			}()
+			f()
+		}()
+	}
+}
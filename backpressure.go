@@ -0,0 +1,64 @@
+package kid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBackpressure is returned by NewWithDeadline when issuing an ID would put
+// the Generator's timestamps further ahead of its clock than the configured
+// maximum lead (WithMaxLead), and the clock did not catch up by the deadline.
+var ErrBackpressure = errors.New("kid: generator too far ahead of the clock")
+
+// defaultMaxLead is the maximum lead of NewWithDeadline without WithMaxLead.
+const defaultMaxLead = 100 * time.Millisecond
+
+// WithMaxLead sets how far NewWithDeadline lets the Generator's timestamps
+// run ahead of its clock, 100ms by default; d is rounded down to whole
+// milliseconds. New and Generate are not limited.
+func WithMaxLead(d time.Duration) Option[Generator] {
+	return func(g *Generator) {
+		g.maxLead = d
+	}
+}
+
+// NewWithDeadline generates a new unique ID like Generate, but refuses to
+// borrow more than the maximum lead (WithMaxLead) of future milliseconds.
+//
+// Each millisecond holds 4096 sequence values; a sustained rate above that
+// is absorbed by borrowing the following milliseconds, so New's embedded
+// timestamps drift ever further ahead of real time. NewWithDeadline instead
+// waits for the clock to catch up, and if it has not by d, as read from the
+// Generator's clock, returns ErrBackpressure, letting a producer slow down or
+// shed load. A d in the past never waits.
+//
+// The limit is checked before the timestamp+sequence is claimed, so
+// concurrent callers may overshoot it by a few sequence values.
+func (g *Generator) NewWithDeadline(d time.Time) (ID, error) {
+	maxLead := g.maxLead
+	if maxLead == 0 {
+		maxLead = defaultMaxLead
+	}
+	limit := int64(maxLead / time.Millisecond)
+	for {
+		now := g.now()
+		// the lead of the next sequence slot
+		lead := (g.latest()+1)>>12 - (now.UnixNano()-g.epoch)/nanoPerMilli
+		if lead <= limit {
+			break
+		}
+		wait := time.Duration(lead-limit) * time.Millisecond
+		if remain := d.Sub(now); remain < wait {
+			if remain <= 0 {
+				return Nil, fmt.Errorf("%w: %v ahead", ErrBackpressure, time.Duration(lead)*time.Millisecond)
+			}
+			wait = remain
+		}
+		time.Sleep(wait)
+		if g.obs != nil {
+			g.obs.Waited(wait)
+		}
+	}
+	return g.Generate()
+}
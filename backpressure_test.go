@@ -0,0 +1,50 @@
+package kid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewWithDeadline(t *testing.T) {
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	g := NewGenerator(WithClock(c.now), WithMaxLead(2*time.Millisecond))
+	// the clock stands still: a past deadline fails as soon as the lead is used
+	var n int
+	for ; ; n++ {
+		id, err := g.NewWithDeadline(c.t)
+		if errors.Is(err, ErrBackpressure) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lead := id.Time().Sub(c.t); lead > 2*time.Millisecond {
+			t.Fatalf("ID %d leads the clock by %v", n, lead)
+		}
+	}
+	if n != 3*4096 {
+		t.Errorf("issued %d IDs before ErrBackpressure, want %d: the current millisecond and 2 borrowed", n, 3*4096)
+	}
+	// New is not limited
+	g.New()
+	c.t = c.t.Add(10 * time.Millisecond)
+	if _, err := g.NewWithDeadline(c.t); err != nil {
+		t.Errorf("NewWithDeadline() after the clock caught up: %v", err)
+	}
+}
+
+func TestNewWithDeadlineWaits(t *testing.T) {
+	g := NewGenerator(WithMaxLead(time.Millisecond))
+	// borrow 5ms ahead of the clock
+	now := time.Now()
+	for g.New().Time().Sub(now) < 5*time.Millisecond {
+	}
+	id, err := g.NewWithDeadline(time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("NewWithDeadline() = %v", err)
+	}
+	if lead := time.Until(id.Time()); lead > time.Millisecond {
+		t.Errorf("timestamp leads the clock by %v after waiting", lead)
+	}
+}
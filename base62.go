@@ -0,0 +1,82 @@
+package kid
+
+import "math/bits"
+
+const (
+	// base62 is the Base62 alphabet, in ascending ASCII order so fixed-width
+	// encodings sort like the IDs.
+	base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// encodedLen62 is the fixed width of a Base62 ID: 62^14 > 2^80 > 62^13.
+	encodedLen62 = 14
+)
+
+// base62Dec is the Base62 decoding map: each character's value, or maxByte.
+var base62Dec = func() (d [256]byte) {
+	for i := range d {
+		d[i] = maxByte
+	}
+	for i := range len(base62) {
+		d[base62[i]] = byte(i)
+	}
+	return d
+}()
+
+// halves80 returns id as an 80-bit integer: the top 16 bits and the low 64.
+func halves80(id ID) (hi, lo uint64) {
+	hi = uint64(id[0])<<8 | uint64(id[1])
+	for _, b := range id[2:] {
+		lo = lo<<8 | uint64(b)
+	}
+	return hi, lo
+}
+
+// join80 is the inverse of halves80.
+func join80(hi, lo uint64) (id ID) {
+	id[0], id[1] = byte(hi>>8), byte(hi)
+	for i := rawLen - 1; i >= 2; i-- {
+		id[i] = byte(lo)
+		lo >>= 8
+	}
+	return id
+}
+
+// Base62 returns id encoded in Base62, 14 strictly alphanumeric characters,
+// zero-padded to a fixed width so encoded IDs sort like binary IDs, for
+// systems that accept only letters and digits.
+func (id ID) Base62() string {
+	hi, lo := halves80(id)
+	var text [encodedLen62]byte
+	for i := encodedLen62 - 1; i >= 0; i-- {
+		var r uint64
+		hi, r = hi/62, hi%62
+		lo, r = bits.Div64(r, lo, 62)
+		text[i] = base62[r]
+	}
+	return string(text[:])
+}
+
+// FromBase62 decodes s, an ID encoded by ID.Base62. s must be 14 Base62
+// characters with a value below 2^80; on error it returns the nil ID and an
+// *InvalidLengthError or *InvalidCharError, wrapping ErrInvalidID, the
+// latter reporting a value of 2^80 or more at its first character.
+func FromBase62(s string) (ID, error) {
+	if len(s) != encodedLen62 {
+		return Nil, &InvalidLengthError{Got: len(s), Want: encodedLen62}
+	}
+	var hi, lo uint64
+	for i := range len(s) {
+		d := base62Dec[s[i]]
+		if d == maxByte {
+			return Nil, &InvalidCharError{Pos: i, Char: s[i]}
+		}
+		carry, l := bits.Mul64(lo, 62)
+		l, c := bits.Add64(l, uint64(d), 0)
+		hi = hi*62 + carry + c
+		if hi >= 1<<16 {
+			return Nil, &InvalidCharError{Pos: 0, Char: s[0]}
+		}
+		lo = l
+	}
+	return join80(hi, lo), nil
+}
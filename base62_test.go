@@ -0,0 +1,41 @@
+package kid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBase62(t *testing.T) {
+	for _, tt := range []struct {
+		id  ID
+		str string
+	}{
+		{Nil, "00000000000000"},
+		{tests[6].id, "02Jjb06reNjU83"},
+		{Max, "62iEp5bu9VZbsV"},
+	} {
+		if got := tt.id.Base62(); got != tt.str {
+			t.Errorf("%v.Base62() = %s, want %s", tt.id, got, tt.str)
+		}
+		if got, err := FromBase62(tt.str); err != nil || got != tt.id {
+			t.Errorf("FromBase62(%s) = %v, %v, want %v", tt.str, got, err, tt.id)
+		}
+	}
+	for _, s := range []string{"", "0000000000000", "000000000000000", "62iEp5bu9VZbsW", "zzzzzzzzzzzzzz", "0000000000000-"} {
+		if got, err := FromBase62(s); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("FromBase62(%q) = %v, %v, want ErrInvalidID", s, got, err)
+		}
+	}
+
+	ids := randomIDs(1000)
+	Sort(ids)
+	for i, id := range ids {
+		s := id.Base62()
+		if got, err := FromBase62(s); err != nil || got != id {
+			t.Fatalf("FromBase62(%s) = %v, %v, want %v", s, got, err, id)
+		}
+		if i > 0 && ids[i-1].Base62() > s {
+			t.Fatalf("Base62() order: %s > %s for sorted IDs", ids[i-1].Base62(), s)
+		}
+	}
+}
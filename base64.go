@@ -0,0 +1,49 @@
+package kid
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// base64Len is the length of an ID in unpadded Base64.
+const base64Len = 14
+
+// base64Chars is the URL-safe Base64 alphabet.
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+// base64URL is strict so that each ID has exactly one encoding: the unused
+// low bits of the last character must be zero.
+var base64URL = base64.RawURLEncoding.Strict()
+
+// Base64 returns id in RFC 4648 URL-safe Base64 without padding, 14
+// characters, the shortest text form, for tokens and URLs. The Base64
+// alphabet is not in ASCII order, so unlike String, encoded IDs do not sort
+// like binary IDs.
+func (id ID) Base64() string {
+	var text [base64Len]byte
+	base64URL.Encode(text[:], id[:])
+	return string(text[:])
+}
+
+// FromBase64 decodes s, an ID encoded by ID.Base64. On error it returns the
+// nil ID and an *InvalidLengthError or *InvalidCharError, wrapping
+// ErrInvalidID; a last character with unused bits set is invalid.
+func FromBase64(s string) (ID, error) {
+	var id ID
+	if len(s) != base64Len {
+		return Nil, &InvalidLengthError{Got: len(s), Want: base64Len}
+	}
+	if _, err := base64URL.Decode(id[:], []byte(s)); err != nil {
+		var at base64.CorruptInputError
+		if !errors.As(err, &at) || int(at) >= len(s) {
+			return Nil, ErrInvalidID
+		}
+		pos := int(at)
+		if strings.IndexByte(base64Chars, s[pos]) >= 0 {
+			pos = len(s) - 1 // the unused bits are set
+		}
+		return Nil, &InvalidCharError{Pos: pos, Char: s[pos]}
+	}
+	return id, nil
+}
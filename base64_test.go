@@ -0,0 +1,40 @@
+package kid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBase64(t *testing.T) {
+	for _, tt := range []struct {
+		id  ID
+		str string
+	}{
+		{Nil, "AAAAAAAAAAAAAA"},
+		{tests[6].id, "AZV24T2tDp06sw"},
+		{Max, "_____________w"},
+	} {
+		if got := tt.id.Base64(); got != tt.str {
+			t.Errorf("%v.Base64() = %s, want %s", tt.id, got, tt.str)
+		}
+		if got, err := FromBase64(tt.str); err != nil || got != tt.id {
+			t.Errorf("FromBase64(%s) = %v, %v, want %v", tt.str, got, err, tt.id)
+		}
+	}
+	for _, s := range []string{
+		"",
+		"AZV24T2tDp06s",
+		"AZV24T2tDp06sw==",
+		"AZV24T2tDp06sx", // non-zero unused bits
+		"AZV24T2tDp06s+", // standard, not URL-safe, alphabet
+	} {
+		if got, err := FromBase64(s); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("FromBase64(%q) = %v, %v, want ErrInvalidID", s, got, err)
+		}
+	}
+	for _, id := range randomIDs(1000) {
+		if got, err := FromBase64(id.Base64()); err != nil || got != id {
+			t.Fatalf("FromBase64(%s) = %v, %v, want %v", id.Base64(), got, err, id)
+		}
+	}
+}
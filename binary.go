@@ -0,0 +1,18 @@
+package kid
+
+// Binary is an ID stored in databases in its 10-byte binary form, for
+// BINARY(10), BLOB or bytea columns, rather than as the 16-character string
+// ID.Value produces; binary keys take less space in tables and indexes and
+// sort identically. Convert at the database boundary:
+//
+//	db.Exec("INSERT INTO orders (id) VALUES ($1)", kid.Binary(id))
+//
+//	var b kid.Binary
+//	err := row.Scan(&b)
+//	id := kid.ID(b)
+type Binary ID
+
+// String returns b encoded as ID.String does.
+func (b Binary) String() string {
+	return ID(b).String()
+}
@@ -0,0 +1,9 @@
+package kid
+
+import "testing"
+
+func TestBinaryString(t *testing.T) {
+	if s := Binary(tests[6].id).String(); s != "06bqer9xnm79tfnl" {
+		t.Errorf("String() = %q, want 06bqer9xnm79tfnl", s)
+	}
+}
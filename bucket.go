@@ -0,0 +1,43 @@
+package kid
+
+import "time"
+
+// Truncate returns the first ID of the d-long time bucket containing id's
+// timestamp: the lowest possible ID, as from FirstForTime, whose timestamp
+// is id's rounded down to a multiple of d since the epoch. IDs sharing a
+// bucket share its Truncate value, so it serves as a partition or GROUP BY
+// key, e.g. per minute, hour or (UTC) day.
+//
+// Buckets are aligned to the Unix epoch, or to the custom epoch of the
+// Generator that produced id (see WithEpoch). d is rounded down to whole
+// milliseconds; below a millisecond, Truncate returns the first ID of id's
+// millisecond.
+func (id ID) Truncate(d time.Duration) ID {
+	return forTimestamp(truncMilli(id.Timestamp(), d), 0x00)
+}
+
+// Bucket returns the first ID of the d-long time bucket containing t, the
+// bucket being aligned to the Unix epoch as for ID.Truncate: for IDs
+// generated at t, id.Truncate(d) == Bucket(t, d). Consecutive buckets bound
+// time-range queries:
+//
+//	WHERE id >= Bucket(t, time.Hour) AND id < Bucket(t.Add(time.Hour), time.Hour)
+//
+// Times before the Unix epoch clamp to the lowest timestamp, and times beyond
+// the 6-byte range to the highest.
+func Bucket(t time.Time, d time.Duration) ID {
+	return forTimestamp(truncMilli(t.UnixMilli(), d), 0x00)
+}
+
+// truncMilli rounds milli down to a multiple of d in milliseconds.
+func truncMilli(milli int64, d time.Duration) int64 {
+	m := d.Milliseconds()
+	if m <= 1 {
+		return milli
+	}
+	r := milli % m
+	if r < 0 {
+		r += m
+	}
+	return milli - r
+}
@@ -0,0 +1,55 @@
+package kid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTruncate(t *testing.T) {
+	at := time.Date(2025, 3, 8, 17, 50, 27, 757*int(time.Millisecond), time.UTC)
+	id := NewWithTime(at)
+	tests := []struct {
+		d    time.Duration
+		want time.Time
+	}{
+		{0, at},
+		{time.Microsecond, at},
+		{time.Millisecond, at},
+		{time.Second, time.Date(2025, 3, 8, 17, 50, 27, 0, time.UTC)},
+		{time.Minute, time.Date(2025, 3, 8, 17, 50, 0, 0, time.UTC)},
+		{15 * time.Minute, time.Date(2025, 3, 8, 17, 45, 0, 0, time.UTC)},
+		{time.Hour, time.Date(2025, 3, 8, 17, 0, 0, 0, time.UTC)},
+		{24 * time.Hour, time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		want := FirstForTime(tt.want)
+		if got := id.Truncate(tt.d); got != want {
+			t.Errorf("Truncate(%v) = %v (%v), want %v", tt.d, got, got.Time(), want)
+		}
+		if got := Bucket(at, tt.d); got != want {
+			t.Errorf("Bucket(%v) = %v (%v), want %v", tt.d, got, got.Time(), want)
+		}
+		if got := Bucket(at.Add(time.Nanosecond), tt.d); got != want {
+			t.Errorf("Bucket(%v) of a nanosecond later = %v, want %v", tt.d, got, want)
+		}
+		if id.Compare(want) < 0 {
+			t.Errorf("%v sorts before its bucket %v", id, want)
+		}
+	}
+}
+
+func TestBucketBounds(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithClock(func() time.Time { return start.Add(59*time.Minute + 59*time.Second) }))
+	id := g.New()
+	lo, hi := Bucket(start, time.Hour), Bucket(start.Add(time.Hour), time.Hour)
+	if id.Compare(lo) < 0 || id.Compare(hi) >= 0 {
+		t.Errorf("%v not within [%v, %v)", id, lo, hi)
+	}
+	if got := Bucket(time.Unix(-90, 0), time.Minute); got != Nil {
+		t.Errorf("Bucket() before the epoch = %v, want Nil", got)
+	}
+	if got := Bucket(time.UnixMilli(1<<50), time.Hour); got != FirstForTime(time.UnixMilli(1<<48-1)) {
+		t.Errorf("Bucket() beyond the timestamp range = %v, want the clamped maximum", got)
+	}
+}
@@ -0,0 +1,142 @@
+package kid
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// BulkFormat selects the framing of an ID stream consumed by a BulkParser.
+type BulkFormat int
+
+const (
+	// BulkLines is newline-delimited text, one 16-character encoded ID per
+	// line. Surrounding whitespace, including the \r of a CRLF line ending,
+	// and blank lines are ignored.
+	BulkLines BulkFormat = iota
+	// BulkBinary is a stream of 10-byte binary IDs with no delimiters; each
+	// record is exactly rawLen bytes long.
+	BulkBinary
+)
+
+// bulkBufSize is the size of a BulkParser's read buffer, a multiple of
+// rawLen so binary records never straddle a refill.
+const bulkBufSize = 64 * 1024 / rawLen * rawLen
+
+// BulkParser decodes a stream of IDs from any io.Reader, calling Fn for each
+// ID in stream order. It implements io.ReaderFrom, so it can be the target of
+// io.Copy.
+//
+// A BulkParser reuses its read buffer across calls to ReadFrom; it is not
+// goroutine-safe. The zero value parses BulkLines and only validates the
+// stream.
+type BulkParser struct {
+	// Format is the framing of the stream.
+	Format BulkFormat
+	// Fn, if not nil, is called for each decoded ID. A non-nil error stops
+	// parsing and is returned by ReadFrom unchanged.
+	Fn func(ID) error
+
+	buf []byte
+}
+
+// ParseError records the position of an invalid entry in a stream read by a
+// BulkParser: its line, or in BulkBinary its record.
+type ParseError struct {
+	Line   int   // 1-based line number (BulkLines), or zero
+	Record int   // 1-based record number (BulkBinary), or zero
+	Err    error // ErrInvalidID, or io.ErrUnexpectedEOF for a short record
+}
+
+func (e *ParseError) Error() string {
+	if e.Record != 0 {
+		return fmt.Sprintf("kid: record %d: %s", e.Record, e.Err)
+	}
+	return fmt.Sprintf("kid: line %d: %s", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error, so errors.Is(err, ErrInvalidID)
+// reports whether parsing stopped on an invalid ID.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ReadFrom implements io.ReaderFrom, consuming r until EOF or the first
+// error. It returns the number of bytes read; an invalid entry stops parsing
+// with a *ParseError.
+func (p *BulkParser) ReadFrom(r io.Reader) (n int64, err error) {
+	if p.buf == nil {
+		p.buf = make([]byte, bulkBufSize)
+	}
+	if p.Format == BulkBinary {
+		return p.readBinary(r)
+	}
+	return p.readLines(r)
+}
+
+func (p *BulkParser) readLines(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	sc := bufio.NewScanner(cr)
+	sc.Buffer(p.buf, bufio.MaxScanTokenSize)
+	var id ID
+	for line := 1; sc.Scan(); line++ {
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if err := id.UnmarshalText(text); err != nil {
+			return cr.n, &ParseError{Line: line, Err: err}
+		}
+		if p.Fn != nil {
+			if err := p.Fn(id); err != nil {
+				return cr.n, err
+			}
+		}
+	}
+	return cr.n, sc.Err()
+}
+
+func (p *BulkParser) readBinary(r io.Reader) (n int64, err error) {
+	var (
+		held   int // bytes of an incomplete record carried over at p.buf[:held]
+		record int
+		id     ID
+	)
+	for {
+		k, rerr := r.Read(p.buf[held:])
+		n += int64(k)
+		held += k
+		i := 0
+		for ; held-i >= rawLen; i += rawLen {
+			record++
+			copy(id[:], p.buf[i:i+rawLen])
+			if p.Fn != nil {
+				if err := p.Fn(id); err != nil {
+					return n, err
+				}
+			}
+		}
+		held = copy(p.buf, p.buf[i:held])
+		switch {
+		case rerr == io.EOF && held != 0:
+			return n, &ParseError{Record: record + 1, Err: io.ErrUnexpectedEOF}
+		case rerr == io.EOF:
+			return n, nil
+		case rerr != nil:
+			return n, rerr
+		}
+	}
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += int64(n)
+	return n, err
+}
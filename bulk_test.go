@@ -0,0 +1,123 @@
+package kid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestBulkParserLines(t *testing.T) {
+	input := "06bqer9xnm79tfnl\r\n\n  06bqer9xnm7bn100  \nzzzzzzzzzzzzzzzz"
+	var got []ID
+	p := BulkParser{Fn: func(id ID) error {
+		got = append(got, id)
+		return nil
+	}}
+	n, err := p.ReadFrom(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(input)) {
+		t.Errorf("ReadFrom() n = %d, want %d", n, len(input))
+	}
+	want := []ID{tests[6].id, tests[7].id, tests[1].id}
+	if len(got) != len(want) {
+		t.Fatalf("ReadFrom() parsed %d IDs, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ID %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBulkParserLinesError(t *testing.T) {
+	input := "06bqer9xnm79tfnl\n\n06BQER9XNM7BN100\n06bqer9xnm7cgpb0\n"
+	calls := 0
+	p := BulkParser{Fn: func(ID) error { calls++; return nil }}
+	_, err := p.ReadFrom(strings.NewReader(input))
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("ReadFrom() err = %v, want *ParseError", err)
+	}
+	if pe.Line != 3 {
+		t.Errorf("ParseError.Line = %d, want 3", pe.Line)
+	}
+	if want := "kid: line 3: kid: invalid id: character 'B' at position 2"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, ErrInvalidID) {
+		t.Errorf("errors.Is(%v, ErrInvalidID) = false", err)
+	}
+	if calls != 1 {
+		t.Errorf("Fn called %d times before the error, want 1", calls)
+	}
+}
+
+func TestBulkParserBinary(t *testing.T) {
+	var input []byte
+	for _, v := range tests[:6] {
+		input = append(input, v.id[:]...)
+	}
+	var got []ID
+	p := BulkParser{Format: BulkBinary, Fn: func(id ID) error {
+		got = append(got, id)
+		return nil
+	}}
+	// a one-byte reader forces records to be reassembled across reads
+	n, err := p.ReadFrom(iotest.OneByteReader(bytes.NewReader(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(input)) || len(got) != 6 {
+		t.Fatalf("ReadFrom() = %d bytes, %d IDs, want %d bytes, 6 IDs", n, len(got), len(input))
+	}
+	for i := range got {
+		if got[i] != tests[i].id {
+			t.Errorf("ID %d = %v, want %v", i, got[i], tests[i].id)
+		}
+	}
+
+	// a short trailing record is reported against its record number
+	_, err = p.ReadFrom(bytes.NewReader(input[:2*rawLen+3]))
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Record != 3 || pe.Line != 0 || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadFrom(short record) err = %v, want record 3 io.ErrUnexpectedEOF", err)
+	}
+	if want := "kid: record 3: unexpected EOF"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestBulkParserFnError(t *testing.T) {
+	stop := errors.New("stop")
+	p := BulkParser{Fn: func(ID) error { return stop }}
+	if _, err := p.ReadFrom(strings.NewReader("06bqer9xnm79tfnl\n")); err != stop {
+		t.Errorf("ReadFrom() err = %v, want %v", err, stop)
+	}
+	p.Format = BulkBinary
+	if _, err := p.ReadFrom(bytes.NewReader(tests[0].id[:])); err != stop {
+		t.Errorf("ReadFrom(binary) err = %v, want %v", err, stop)
+	}
+}
+
+func BenchmarkBulkParserLines(b *testing.B) {
+	var buf bytes.Buffer
+	for range 10000 {
+		buf.WriteString(New().String())
+		buf.WriteByte('\n')
+	}
+	input := buf.Bytes()
+	var p BulkParser
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := p.ReadFrom(bytes.NewReader(input)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package kid
+
+import (
+	"math"
+	"time"
+)
+
+// MaxPerMillisecond is the number of timestamp+sequence slots in one
+// millisecond: the 12-bit sequence space claimed by New and Generator.New.
+// Generating faster than this borrows slots from future milliseconds; see the
+// package documentation.
+const MaxPerMillisecond = 1 << 12
+
+// SustainedRateLimit returns the highest rate, in IDs per second, a single
+// Generator can sustain without its embedded timestamps drifting ahead of
+// the wall clock: MaxPerMillisecond slots in each of 1,000 milliseconds.
+func SustainedRateLimit() float64 {
+	return MaxPerMillisecond * 1000
+}
+
+// EstimateBorrowAt estimates how long a Generator can issue IDs at
+// ratePerSec before timestamp borrowing begins: the point at which the slots
+// claimed beyond real time add up to a full millisecond, so embedded
+// timestamps lead the wall clock. Above the limit the lead grows by
+// (ratePerSec - SustainedRateLimit()) slots per second.
+//
+// At or below SustainedRateLimit borrowing never begins, and
+// EstimateBorrowAt returns the maximum time.Duration, as it does for a NaN
+// rate and for estimates longer than that.
+func EstimateBorrowAt(ratePerSec float64) time.Duration {
+	excess := ratePerSec - SustainedRateLimit()
+	if excess <= 0 {
+		return math.MaxInt64
+	}
+	d := MaxPerMillisecond / excess * float64(time.Second)
+	if !(d < math.MaxInt64) { // NaN, or too long for a Duration
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
+
+// randomValues is the number of values of an ID's two random bytes.
+const randomValues = 1 << 16
+
+// CollisionOdds estimates the probability that, within one millisecond,
+// some two of hosts Generators (hosts, processes or shards), each issuing
+// ratePerMs IDs per millisecond, produce the same ID.
+//
+// IDs from one Generator never collide: its timestamp+sequence values are
+// unique. Two uncoordinated Generators can claim the same timestamp+sequence
+// slot, and the two random bytes are then the only separation, differing in
+// all but 1 of 65,536 cases. Modelling each Generator's claims as spread
+// uniformly over the MaxPerMillisecond slots of each millisecond, a pair of
+// Generators coincides in ratePerMs × min(ratePerMs, MaxPerMillisecond) /
+// MaxPerMillisecond slots per millisecond, and the expected collisions
+// among all hosts(hosts-1)/2 pairs are taken as a Poisson rate.
+//
+// CollisionOdds returns 0 for fewer than 2 hosts. It does not apply to
+// Generators with distinct WithNode values, which cannot collide.
+func CollisionOdds(ratePerMs, hosts int) float64 {
+	return -math.Expm1(-collisionRate(ratePerMs, hosts))
+}
+
+// CollisionOddsOver is CollisionOdds over a period d rather than one
+// millisecond: the probability of at least one collision while hosts
+// Generators each issue ratePerMs IDs per millisecond for d.
+func CollisionOddsOver(ratePerMs, hosts int, d time.Duration) float64 {
+	return -math.Expm1(-collisionRate(ratePerMs, hosts) * float64(d) / float64(time.Millisecond))
+}
+
+// collisionRate returns the expected number of colliding ID pairs per
+// millisecond; see CollisionOdds.
+func collisionRate(ratePerMs, hosts int) float64 {
+	if hosts < 2 || ratePerMs < 1 {
+		return 0
+	}
+	r := float64(ratePerMs)
+	pairs := float64(hosts) * float64(hosts-1) / 2
+	coincident := r * min(r, MaxPerMillisecond) / MaxPerMillisecond
+	return pairs * coincident / randomValues
+}
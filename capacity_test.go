@@ -0,0 +1,68 @@
+package kid
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSustainedRateLimit(t *testing.T) {
+	if got, want := SustainedRateLimit(), 4_096_000.0; got != want {
+		t.Errorf("SustainedRateLimit() = %v, want %v", got, want)
+	}
+}
+
+func TestEstimateBorrowAt(t *testing.T) {
+	tests := []struct {
+		rate float64
+		want time.Duration
+	}{
+		{0, math.MaxInt64},
+		{1_000_000, math.MaxInt64},
+		{SustainedRateLimit(), math.MaxInt64},
+		// one millisecond of excess slots (4,096) accrues in one second
+		{SustainedRateLimit() + MaxPerMillisecond, time.Second},
+		// ... and in one millisecond at double the limit
+		{2 * SustainedRateLimit(), time.Millisecond},
+		// longer than a Duration holds
+		{SustainedRateLimit() + 1e-7, math.MaxInt64},
+		{math.NaN(), math.MaxInt64},
+	}
+	for _, tt := range tests {
+		if got := EstimateBorrowAt(tt.rate); got != tt.want {
+			t.Errorf("EstimateBorrowAt(%v) = %v, want %v", tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestCollisionOdds(t *testing.T) {
+	near := func(got, want float64) bool {
+		return math.Abs(got-want) <= 1e-9*want
+	}
+	// 2 hosts at 64 IDs/ms: 64*64/4096 = 1 coincident slot per ms
+	if got, want := CollisionOdds(64, 2), -math.Expm1(-1.0/65536); !near(got, want) {
+		t.Errorf("CollisionOdds(64, 2) = %v, want %v", got, want)
+	}
+	// 10 hosts: 45 pairs
+	if got, want := CollisionOdds(64, 10), -math.Expm1(-45.0/65536); !near(got, want) {
+		t.Errorf("CollisionOdds(64, 10) = %v, want %v", got, want)
+	}
+	// saturated: every slot coincides, and borrowed slots too
+	if got, want := CollisionOdds(2*MaxPerMillisecond, 2), -math.Expm1(-2*MaxPerMillisecond/65536.0); !near(got, want) {
+		t.Errorf("CollisionOdds(%d, 2) = %v, want %v", 2*MaxPerMillisecond, got, want)
+	}
+	for _, tt := range []struct{ rate, hosts int }{{1000, 1}, {1000, 0}, {0, 10}} {
+		if got := CollisionOdds(tt.rate, tt.hosts); got != 0 {
+			t.Errorf("CollisionOdds(%d, %d) = %v, want 0", tt.rate, tt.hosts, got)
+		}
+	}
+	if a, b := CollisionOdds(10, 5), CollisionOdds(20, 5); !(a < b) {
+		t.Errorf("CollisionOdds not increasing in rate: %v, %v", a, b)
+	}
+
+	// over a second, 1000 independent milliseconds
+	p := CollisionOdds(64, 2)
+	if got, want := CollisionOddsOver(64, 2, time.Second), 1-math.Pow(1-p, 1000); !near(got, want) {
+		t.Errorf("CollisionOddsOver(64, 2, 1s) = %v, want %v", got, want)
+	}
+}
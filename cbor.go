@@ -0,0 +1,42 @@
+package kid
+
+// CBOR (RFC 8949) initial bytes used by MarshalCBOR and UnmarshalCBOR.
+const (
+	cborBytes10 = 0x40 | rawLen     // major type 2, byte string of 10 bytes
+	cborText16  = 0x60 | encodedLen // major type 3, text string of 16 bytes
+	cborNull    = 0xf6
+)
+
+// MarshalCBOR encodes id as a CBOR byte string holding the 10 raw bytes, 11
+// bytes in all, or as CBOR null for the nil ID. It satisfies the
+// cbor.Marshaler interface of github.com/fxamacker/cbor, which needs no
+// import to implement, so IDs serialize compactly in CBOR-based protocols.
+func (id ID) MarshalCBOR() ([]byte, error) {
+	if id == Nil {
+		return []byte{cborNull}, nil
+	}
+	b := make([]byte, 1+rawLen)
+	b[0] = cborBytes10
+	copy(b[1:], id[:])
+	return b, nil
+}
+
+// UnmarshalCBOR decodes a single CBOR data item, satisfying the
+// cbor.Unmarshaler interface of github.com/fxamacker/cbor. It accepts a
+// 10-byte byte string, as written by MarshalCBOR, a 16-character text string
+// of the encoded ID, or null, which yields the nil ID; on error, id is set to
+// the nil ID and ErrInvalidID is returned.
+func (id *ID) UnmarshalCBOR(data []byte) error {
+	switch {
+	case len(data) == 1 && data[0] == cborNull:
+		*id = Nil
+		return nil
+	case len(data) == 1+rawLen && data[0] == cborBytes10:
+		copy(id[:], data[1:])
+		return nil
+	case len(data) == 1+encodedLen && data[0] == cborText16:
+		return id.UnmarshalText(data[1:])
+	}
+	*id = Nil
+	return ErrInvalidID
+}
@@ -0,0 +1,42 @@
+package kid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestCBOR(t *testing.T) {
+	id := tests[6].id
+	b, err := id.MarshalCBOR()
+	// h'019576E13DAD0E9D3AB3' in CBOR diagnostic notation
+	if want, _ := hex.DecodeString("4a019576e13dad0e9d3ab3"); err != nil || !bytes.Equal(b, want) {
+		t.Errorf("MarshalCBOR() = %x, %v, want %x", b, err, want)
+	}
+	for _, data := range [][]byte{b, append([]byte{0x70}, "06bqer9xnm79tfnl"...)} {
+		var got ID
+		if err := got.UnmarshalCBOR(data); err != nil || got != id {
+			t.Errorf("UnmarshalCBOR(%x) = %v, %v, want %v", data, got, err, id)
+		}
+	}
+	if b, _ := Nil.MarshalCBOR(); !bytes.Equal(b, []byte{0xf6}) {
+		t.Errorf("Nil.MarshalCBOR() = %x, want f6 (null)", b)
+	}
+	got := id
+	if err := got.UnmarshalCBOR([]byte{0xf6}); err != nil || got != Nil {
+		t.Errorf("UnmarshalCBOR(null) = %v, %v, want Nil", got, err)
+	}
+	for _, data := range [][]byte{
+		nil,
+		{0x49, 1, 2, 3, 4, 5, 6, 7, 8, 9}, // 9-byte byte string
+		{0x58, 0x0a, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, // non-preferred length encoding
+		append([]byte{0x70}, "06BQER9XNM79TFNL"...), // not the kid alphabet
+		{0x1a, 0x00, 0x01, 0x02, 0x03},              // unsigned integer
+	} {
+		got := id
+		if err := got.UnmarshalCBOR(data); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("UnmarshalCBOR(%x) = %v, %v, want ErrInvalidID", data, got, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package kid
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // DST rules, independent of the host's zoneinfo
+)
+
+// fakeClock is a manually advanced clock for Generators. It is not
+// goroutine-safe.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+// runUntilResync generates one ID per step of simulated time, checking that
+// output stays strictly increasing, until an ID's timestamp again matches
+// the clock. It returns the simulated time that took and the largest lead
+// of an embedded timestamp over the clock.
+func runUntilResync(t *testing.T, g *Generator, c *fakeClock, prev ID, step, limit time.Duration) (elapsed, maxLead time.Duration) {
+	t.Helper()
+	for elapsed = 0; elapsed <= limit; elapsed += step {
+		id := g.New()
+		if id.Compare(prev) <= 0 {
+			t.Fatalf("after %v: %v does not sort after %v", elapsed, id, prev)
+		}
+		prev = id
+		lead := time.Duration(id.Timestamp()-c.t.UnixMilli()) * time.Millisecond
+		if lead <= 0 {
+			return elapsed, maxLead
+		}
+		maxLead = max(maxLead, lead)
+		c.t = c.t.Add(step)
+	}
+	t.Fatalf("timestamps still ahead of the clock after %v", limit)
+	return
+}
+
+// TestClockDSTTransition proves daylight saving transitions are invisible to
+// ID generation: clocks report instants, and the repeated local hour at a
+// fall-back transition maps to distinct, increasing Unix times.
+func TestClockDSTTransition(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 01:59:59 EDT, one second before clocks fall back to 01:00:00 EST
+	c := &fakeClock{t: time.Date(2025, 11, 2, 1, 59, 59, 0, ny)}
+	g := NewGenerator(WithClock(c.now))
+	var prev ID
+	for range 2000 {
+		id := g.New()
+		if id.Compare(prev) <= 0 {
+			t.Fatalf("%v (%s): %v does not sort after %v", c.t, c.t.Format("15:04:05 MST"), id, prev)
+		}
+		if got, want := id.Timestamp(), c.t.UnixMilli(); got != want {
+			t.Fatalf("%v: Timestamp() = %d, want %d", c.t, got, want)
+		}
+		prev = id
+		c.t = c.t.Add(time.Millisecond)
+	}
+	if c.t.Hour() != 1 || c.t.Format("MST") != "EST" {
+		t.Fatalf("clock did not cross the transition: %v", c.t)
+	}
+}
+
+// TestClockLeapSecondSmear drives a Generator with a clock smeared across a
+// leap second: running slow by 1s over 24 hours (~11.6ppm) is still strictly
+// forward, so timestamps track the smeared clock exactly.
+func TestClockLeapSecondSmear(t *testing.T) {
+	start := time.Date(2016, 12, 31, 12, 0, 0, 0, time.UTC)
+	c := &fakeClock{t: start}
+	g := NewGenerator(WithClock(c.now))
+	const rate = float64(24*time.Hour) / float64(24*time.Hour+time.Second)
+	var prev ID
+	for wall := time.Duration(0); wall <= 24*time.Hour; wall += 997 * time.Millisecond {
+		c.t = start.Add(time.Duration(float64(wall) * rate))
+		id := g.New()
+		if id.Compare(prev) <= 0 {
+			t.Fatalf("%v: %v does not sort after %v", c.t, id, prev)
+		}
+		if got, want := id.Timestamp(), c.t.UnixMilli(); got != want {
+			t.Fatalf("%v: Timestamp() = %d, want %d", c.t, got, want)
+		}
+		prev = id
+	}
+}
+
+// TestClockLeapSecondStep drives a Generator through an unsmeared leap
+// second, where the clock repeats 23:59:59: output stays monotonic, and
+// timestamps lead the clock for the repeated second before resyncing.
+func TestClockLeapSecondStep(t *testing.T) {
+	c := &fakeClock{t: time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)}
+	g := NewGenerator(WithClock(c.now))
+	var prev ID
+	for range 1000 {
+		prev = g.New()
+		c.t = c.t.Add(time.Millisecond)
+	}
+	c.t = c.t.Add(-time.Second) // 23:59:60 reads as 23:59:59 again
+	elapsed, maxLead := runUntilResync(t, g, c, prev, 100*time.Microsecond, 2*time.Second)
+	t.Logf("1s step back: timestamps led the clock by up to %v, resynced after %v", maxLead, elapsed)
+	if maxLead > time.Second || elapsed < 990*time.Millisecond || elapsed > 1010*time.Millisecond {
+		t.Errorf("lead %v, resync after %v; want a lead under 1s for ~1s", maxLead, elapsed)
+	}
+}
+
+// TestClockLargeBackwardStep drives a Generator through a multi-minute
+// backwards NTP step. Output stays monotonic; while the clock catches up,
+// each ID takes the next sequence slot after the last pre-step timestamp, so
+// the embedded timestamps lead real time by (up to) the size of the step.
+func TestClockLargeBackwardStep(t *testing.T) {
+	for _, back := range []time.Duration{time.Minute, 5 * time.Minute} {
+		c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+		g := NewGenerator(WithClock(c.now))
+		prev := g.New()
+		c.t = c.t.Add(-back)
+		elapsed, maxLead := runUntilResync(t, g, c, prev, 5*time.Millisecond, back+time.Second)
+		t.Logf("%v step back: timestamps led the clock by up to %v, resynced after %v", back, maxLead, elapsed)
+		// recovery takes the full step, plus the slots claimed meanwhile:
+		// one per 5ms, at MaxPerMillisecond slots per millisecond
+		want := back + time.Duration(back/(5*time.Millisecond))*time.Millisecond/MaxPerMillisecond
+		if maxLead > want || elapsed < want-5*time.Millisecond || elapsed > want+5*time.Millisecond {
+			t.Errorf("%v step: lead %v, resync after %v; want ~%v", back, maxLead, elapsed, want)
+		}
+	}
+}
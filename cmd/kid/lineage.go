@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mwyvr/kid"
+)
+
+// lineage renders the parent-child pairs in the named file, or standard
+// input if name is "-", as a graph in format "dot" or "mermaid". Each line
+// holds a parent and a child ID separated by whitespace; blank lines are
+// ignored.
+func lineage(w io.Writer, format, name string) error {
+	write := kid.WriteDOT
+	switch format {
+	case "dot":
+	case "mermaid":
+		write = kid.WriteMermaid
+	default:
+		return fmt.Errorf("lineage: unknown format %q, want dot or mermaid", format)
+	}
+
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var links []kid.Link
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return fmt.Errorf("%s: line %d: want parent and child IDs", name, n)
+		}
+		parent, err := kid.FromString(fields[0])
+		if err != nil {
+			return fmt.Errorf("%s: line %d: %w", name, n, err)
+		}
+		child, err := kid.FromString(fields[1])
+		if err != nil {
+			return fmt.Errorf("%s: line %d: %w", name, n, err)
+		}
+		links = append(links, kid.Link{Parent: parent, Child: child})
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return write(w, links)
+}
@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/mwyvr/kid"
 )
@@ -15,16 +16,24 @@ import (
 func main() {
 	count := 1
 	showVersion := false
+	tz := "UTC"
 	flag.IntVar(&count, "c", count, "Generate N-count IDs")
 	flag.BoolVar(&showVersion, "version", showVersion, "Print version and exit")
+	flag.StringVar(&tz, "tz", tz, `Show decoded times in location, e.g. "Local", "America/Vancouver"`)
 	flag.Usage = func() {
 		fs := flag.CommandLine
 		fcount := fs.Lookup("c")
+		ftz := fs.Lookup("tz")
 
 		fmt.Printf("Usage: kid\n\n")
 		fmt.Printf("Options:\n")
 		fmt.Printf("  kid 06bpk9h5kd17xd7z\t\tDecode the supplied Base32 ID\n")
 		fmt.Printf("  kid -%s N\t\t\t%s default: %s\n", fcount.Name, fcount.Usage, fcount.DefValue)
+		fmt.Printf("  kid ts ID|millis|RFC3339\tConvert an ID to its timestamp, or a time to its first/last IDs\n")
+		fmt.Printf("  kid setdiff a.txt b.txt\tPrint IDs found in only one file: \"< id\" (a), \"> id\" (b)\n")
+		fmt.Printf("  kid lineage dot|mermaid [file]\tGraph \"parent child\" ID pairs, one per line\n")
+		fmt.Printf("  kid serve [addr]\t\tServe GET /id, /ids?n=N and /decode/ID over HTTP, default: localhost:8080\n")
+		fmt.Printf("  kid -%s zone ID\t\t%s default: %s\n", ftz.Name, ftz.Usage, ftz.DefValue)
 		fmt.Printf("  kid -version\t\t\tPrint version and exit\n\n")
 		fmt.Printf("With no parameters, kid generates %s random ID encoded as Base32.\n", fcount.DefValue)
 		fmt.Printf("Generate and inspect 4 random IDs using Linux/Unix command substitution:\n")
@@ -38,6 +47,61 @@ func main() {
 		return
 	}
 
+	if len(args) > 0 && args[0] == "ts" {
+		if err := ts(os.Stdout, args[1:], location(tz)); err != nil {
+			fmt.Fprintf(os.Stderr, "kid: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(args) > 0 && args[0] == "setdiff" {
+		if len(args) != 3 {
+			fmt.Fprintf(flag.CommandLine.Output(), "kid: Error, setdiff requires two file names.\n")
+			flag.Usage()
+			os.Exit(1)
+		}
+		if err := setdiff(os.Stdout, args[1], args[2]); err != nil {
+			fmt.Fprintf(os.Stderr, "kid: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(args) > 0 && args[0] == "lineage" {
+		if len(args) < 2 || len(args) > 3 {
+			fmt.Fprintf(flag.CommandLine.Output(), "kid: Error, lineage requires a format and at most one file name.\n")
+			flag.Usage()
+			os.Exit(1)
+		}
+		name := "-"
+		if len(args) == 3 {
+			name = args[2]
+		}
+		if err := lineage(os.Stdout, args[1], name); err != nil {
+			fmt.Fprintf(os.Stderr, "kid: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(args) > 0 && args[0] == "serve" {
+		if len(args) > 2 {
+			fmt.Fprintf(flag.CommandLine.Output(), "kid: Error, serve takes at most one address.\n")
+			flag.Usage()
+			os.Exit(1)
+		}
+		addr := "localhost:8080"
+		if len(args) == 2 {
+			addr = args[1]
+		}
+		if err := serve(addr, location(tz)); err != nil {
+			fmt.Fprintf(os.Stderr, "kid: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if count > 1 && len(args) > 0 {
 		fmt.Fprintf(flag.CommandLine.Output(),
 			"kid: Error, cannot generate ID(s) and inspect at the same time.\n")
@@ -47,6 +111,7 @@ func main() {
 
 	if len(args) > 0 {
 		// attempt to decode each as an kid
+		loc := location(tz)
 		for _, arg := range args {
 			id, err := kid.FromString(arg)
 			if err != nil {
@@ -54,8 +119,7 @@ func main() {
 				continue
 			}
 
-			fmt.Printf("%s ts:%d seq:%4d rnd:%5d %s ID{%s }\n", arg,
-				id.Timestamp(), id.Sequence(), id.Random(), id.Time(), asHex(id.Bytes()))
+			fmt.Printf("%s ID{%s }\n", kid.Display(id, loc), asHex(id.Bytes()))
 		}
 	} else {
 		// generate one or -c N ids
@@ -65,6 +129,18 @@ func main() {
 	}
 }
 
+// location loads the -tz location, exiting if it is unknown. Only the
+// commands displaying times call it, so a bad -tz does not break plain
+// generation.
+func location(tz string) *time.Location {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "kid: %s\n", err)
+		os.Exit(1)
+	}
+	return loc
+}
+
 func asHex(b []byte) string {
 	s := []string{}
 	for _, v := range b {
@@ -74,10 +150,18 @@ func asHex(b []byte) string {
 	return strings.Join(s, ",")
 }
 
-// version reports the module version recorded by the Go toolchain: the tagged
-// version (e.g. v1.3.0) when installed via `go install .../cmd/kid@<tag>`, a
-// pseudo-version for untagged commits, or "(devel)" for local builds.
+// buildVersion is set by release builds (see cmd/kid/release) with
+// -ldflags "-X main.buildVersion=v1.3.0".
+var buildVersion string
+
+// version reports buildVersion if set, otherwise the module version recorded
+// by the Go toolchain: the tagged version (e.g. v1.3.0) when installed via
+// `go install .../cmd/kid@<tag>`, a pseudo-version for untagged commits, or
+// "(devel)" for local builds.
 func version() string {
+	if buildVersion != "" {
+		return buildVersion
+	}
 	if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Version != "" {
 		return bi.Main.Version
 	}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidhttp"
+)
+
+// maxServeBatch is the largest count a GET /ids request may ask for.
+const maxServeBatch = 10000
+
+// serve runs an HTTP ID issuer on addr, for services that cannot link the
+// library; see serveHandler for the API.
+func serve(addr string, loc *time.Location) error {
+	log.Printf("kid: serving on http://%s", addr)
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           serveHandler(loc),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
+
+// serveHandler answers:
+//
+//	GET /id            one new ID, as text
+//	GET /ids?n=100     n new IDs (default 1, at most 10000), one per line, or
+//	                   a JSON array given ?format=json or Accept: application/json
+//	GET /decode/{id}   the components of id as a JSON object; times are in loc
+//
+// Invalid input is answered with status 400 and a JSON error carrying a
+// machine-readable code, as written by kidhttp.Error and read by
+// kidhttp.ReadError:
+//
+//	{"code":"invalid_id","message":"kid: invalid id: length 7, want 16"}
+func serveHandler(loc *time.Location) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /id", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, kid.New())
+	})
+	mux.HandleFunc("GET /ids", func(w http.ResponseWriter, r *http.Request) {
+		n := 1
+		if s := r.URL.Query().Get("n"); s != "" {
+			var err error
+			if n, err = strconv.Atoi(s); err != nil || n < 1 || n > maxServeBatch {
+				kidhttp.Error(w, &kid.Error{Code: kid.CodeInvalidArgument, Message: fmt.Sprintf("n must be 1-%d", maxServeBatch)})
+				return
+			}
+		}
+		ids := make([]kid.ID, n)
+		for i := range ids {
+			ids[i] = kid.New()
+		}
+		if r.URL.Query().Get("format") == "json" ||
+			strings.Contains(r.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(ids) //nolint:errcheck
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		buf := make([]byte, 0, n*17)
+		for _, id := range ids {
+			buf = id.AppendEncode(buf)
+			buf = append(buf, '\n')
+		}
+		w.Write(buf) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /decode/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := kid.FromString(r.PathValue("id"))
+		if err != nil {
+			kidhttp.Error(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct { //nolint:errcheck
+			ID        kid.ID `json:"id"`
+			Hex       string `json:"hex"`
+			Timestamp int64  `json:"timestamp"`
+			Time      string `json:"time"`
+			Sequence  int32  `json:"sequence"`
+			Random    int32  `json:"random"`
+		}{id, id.Hex(), id.Timestamp(), id.Time().In(loc).Format(tsLayout), id.Sequence(), id.Random()})
+	})
+	return mux
+}
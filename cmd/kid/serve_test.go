@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidhttp"
+)
+
+func get(h http.Handler, target string, header ...string) *http.Response {
+	r := httptest.NewRequest("GET", target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec.Result()
+}
+
+// lines returns the IDs of a text reply, failing if any is not one.
+func lines(t *testing.T, resp *http.Response) []kid.ID {
+	t.Helper()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []kid.ID
+	for _, s := range strings.Split(strings.TrimSuffix(string(b), "\n"), "\n") {
+		id, err := kid.FromString(s)
+		if err != nil {
+			t.Fatalf("reply line %q: %v", s, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestServeID(t *testing.T) {
+	h := serveHandler(time.UTC)
+	resp := get(h, "/id")
+	if resp.StatusCode != 200 || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
+		t.Fatalf("GET /id = %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if ids := lines(t, resp); len(ids) != 1 {
+		t.Errorf("GET /id = %v, want one ID", ids)
+	}
+}
+
+func TestServeIDs(t *testing.T) {
+	h := serveHandler(time.UTC)
+	tests := []struct {
+		target string
+		header []string
+		n      int
+		json   bool
+	}{
+		{"/ids", nil, 1, false},
+		{"/ids?n=10", nil, 10, false},
+		{"/ids?n=10000", nil, 10000, false},
+		{"/ids?n=3&format=json", nil, 3, true},
+		{"/ids?n=3", []string{"Accept", "application/json"}, 3, true},
+		{"/ids?n=3", []string{"Accept", "text/html, application/json;q=0.9"}, 3, true},
+		{"/ids?n=3&format=text", nil, 3, false},
+	}
+	for _, tt := range tests {
+		resp := get(h, tt.target, tt.header...)
+		if resp.StatusCode != 200 {
+			t.Errorf("GET %s %v = %d, want 200", tt.target, tt.header, resp.StatusCode)
+			continue
+		}
+		var ids []kid.ID
+		if tt.json {
+			if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+				t.Errorf("GET %s %v Content-Type = %q, want application/json", tt.target, tt.header, ct)
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&ids); err != nil {
+				t.Errorf("GET %s %v: %v", tt.target, tt.header, err)
+				continue
+			}
+		} else {
+			ids = lines(t, resp)
+		}
+		if len(ids) != tt.n {
+			t.Errorf("GET %s %v = %d IDs, want %d", tt.target, tt.header, len(ids), tt.n)
+		}
+		for i := 1; i < len(ids); i++ {
+			if ids[i].Compare(ids[i-1]) <= 0 {
+				t.Errorf("GET %s: ID %d, %v, not after %v", tt.target, i, ids[i], ids[i-1])
+				break
+			}
+		}
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	h := serveHandler(time.UTC)
+	tests := []struct {
+		target string
+		code   kid.Code
+		msg    string
+	}{
+		{"/ids?n=0", kid.CodeInvalidArgument, "n must be 1-10000"},
+		{"/ids?n=10001", kid.CodeInvalidArgument, "n must be 1-10000"},
+		{"/ids?n=-1", kid.CodeInvalidArgument, "n must be 1-10000"},
+		{"/ids?n=ten", kid.CodeInvalidArgument, "n must be 1-10000"},
+		{"/decode/06bqer9", kid.CodeInvalidID, "kid: invalid id: length 7, want 16"},
+		{"/decode/06bqer9xNm79tfnl", kid.CodeInvalidID, `kid: invalid id: character 'N' at position 8`},
+	}
+	for _, tt := range tests {
+		resp := get(h, tt.target)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want 400", tt.target, resp.StatusCode)
+		}
+		err := kidhttp.ReadError(resp)
+		var e *kid.Error
+		if !errors.As(err, &e) || e.Code != tt.code || e.Message != tt.msg {
+			t.Errorf("GET %s error = %#v, want %q: %q", tt.target, err, tt.code, tt.msg)
+		}
+	}
+	for _, target := range []string{"/", "/decode/", "/decode/06bqer9xnm79tfnl/x"} {
+		if resp := get(h, target); resp.StatusCode != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", target, resp.StatusCode)
+		}
+	}
+	r := httptest.NewRequest("POST", "/id", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /id = %d, want 405", rec.Code)
+	}
+}
+
+func TestServeDecode(t *testing.T) {
+	vancouver, err := time.LoadLocation("America/Vancouver")
+	if err != nil {
+		t.Skip(err)
+	}
+	id, err := kid.FromString("06bqer9xnm79tfnl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type decoded struct {
+		ID        kid.ID `json:"id"`
+		Hex       string `json:"hex"`
+		Timestamp int64  `json:"timestamp"`
+		Time      string `json:"time"`
+		Sequence  int32  `json:"sequence"`
+		Random    int32  `json:"random"`
+	}
+	for _, tt := range []struct {
+		loc  *time.Location
+		want string
+	}{
+		{time.UTC, "2025-03-08T17:50:27.757Z"},
+		{vancouver, "2025-03-08T09:50:27.757-08:00"},
+	} {
+		resp := get(serveHandler(tt.loc), "/decode/06bqer9xnm79tfnl")
+		if resp.StatusCode != 200 || resp.Header.Get("Content-Type") != "application/json" {
+			t.Fatalf("GET /decode = %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		var got decoded
+		if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		want := decoded{id, "019576e13dad0e9d3ab3", 1741456227757, tt.want, 3741, 15027}
+		if got != want {
+			t.Errorf("GET /decode in %v = %+v, want %+v", tt.loc, got, want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/mwyvr/kid"
+)
+
+// setdiff prints the IDs present in only one of two files, each holding one
+// encoded ID per line. IDs only in a are printed as "< id", IDs only in b as
+// "> id", in ascending order. Either name, but not both, may be "-" for
+// standard input.
+//
+// The files need not be sorted or free of duplicates: each is loaded, sorted
+// and de-duplicated in memory (10 bytes per ID), and the difference is then
+// streamed out by a single merge pass over both sets.
+func setdiff(w io.Writer, nameA, nameB string) error {
+	if nameA == "-" && nameB == "-" {
+		return errors.New("setdiff: standard input can be only one of the files")
+	}
+	a, err := readIDFile(nameA)
+	if err != nil {
+		return err
+	}
+	b, err := readIDFile(nameB)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i].Compare(b[j]) < 0):
+			fmt.Fprintf(bw, "< %s\n", a[i])
+			i++
+		case i == len(a) || a[i].Compare(b[j]) > 0:
+			fmt.Fprintf(bw, "> %s\n", b[j])
+			j++
+		default: // in both
+			i++
+			j++
+		}
+	}
+	return bw.Flush()
+}
+
+// readIDFile reads one ID per line from the named file, or standard input if
+// name is "-", returning the IDs sorted and de-duplicated. Surrounding
+// whitespace and blank lines are ignored.
+func readIDFile(name string) ([]kid.ID, error) {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var ids []kid.ID
+	p := kid.BulkParser{Fn: func(id kid.ID) error {
+		ids = append(ids, id)
+		return nil
+	}}
+	if _, err := p.ReadFrom(r); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	kid.Sort(ids)
+	return slices.Compact(ids), nil
+}
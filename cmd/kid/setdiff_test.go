@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetdiff(t *testing.T) {
+	// in ascending order
+	const (
+		id1 = "0000000003zzzzzz"
+		id2 = "06bqer9xnm000000"
+		id3 = "06bqer9xnm79tfnl"
+		id4 = "06bqer9xnqzzzzzz"
+	)
+	tests := []struct {
+		name string
+		a, b string
+		want string
+		err  string
+	}{
+		{"empty", "", "", "", ""},
+		{"equal", id1 + "\n" + id2 + "\n", id2 + "\n" + id1 + "\n", "", ""},
+		{"one side", id1 + "\n" + id2 + "\n", "", "< " + id1 + "\n< " + id2 + "\n", ""},
+		{"unsorted", id4 + "\n" + id1 + "\n" + id3 + "\n", id2 + "\n" + id3 + "\n", "< " + id1 + "\n> " + id2 + "\n< " + id4 + "\n", ""},
+		{"duplicates", id1 + "\n" + id1 + "\n" + id2 + "\n", id3 + "\n" + id3 + "\n" + id2 + "\n", "< " + id1 + "\n> " + id3 + "\n", ""},
+		{"blank lines and spaces", "\n  " + id1 + "  \n\n", id1 + "\n", "", ""},
+		{"malformed line", id1 + "\n" + id2 + "\nnot-an-id\n", id1 + "\n", "", "a: kid: line 3: kid: invalid id: length 9, want 16"},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+		if err := os.WriteFile(a, []byte(tt.a), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(b, []byte(tt.b), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		var out strings.Builder
+		err := setdiff(&out, a, b)
+		if out.String() != tt.want {
+			t.Errorf("%s: setdiff() printed %q, want %q", tt.name, out.String(), tt.want)
+		}
+		if msg := strings.ReplaceAll(errString(err), dir+string(filepath.Separator), ""); msg != tt.err {
+			t.Errorf("%s: setdiff() error = %q, want %q", tt.name, msg, tt.err)
+		}
+	}
+
+	// standard input can be read only once
+	if err := setdiff(new(strings.Builder), "-", "-"); err == nil {
+		t.Errorf("setdiff(-, -) succeeded, want an error")
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/mwyvr/kid"
+)
+
+// tsLayout is RFC 3339 with the millisecond resolution of an ID timestamp.
+const tsLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// ts converts between IDs and timestamps. An ID argument prints its
+// timestamp as Unix milliseconds and RFC 3339 in loc; a Unix millisecond or
+// RFC 3339 argument prints the first and last possible IDs for that
+// millisecond, the bounds of a range query. Each argument is tried as an ID
+// first: a 16-digit number is a valid ID, while timestamps in milliseconds
+// have at most 15 digits.
+func ts(w io.Writer, args []string, loc *time.Location) error {
+	for _, arg := range args {
+		if id, err := kid.FromString(arg); err == nil {
+			fmt.Fprintf(w, "%d %s\n", id.Timestamp(), id.Time().In(loc).Format(tsLayout))
+			continue
+		}
+		var milli int64
+		if n, err := strconv.ParseInt(arg, 10, 64); err == nil {
+			milli = n
+		} else if t, err := time.Parse(time.RFC3339Nano, arg); err == nil {
+			milli = t.UnixMilli()
+		} else {
+			return fmt.Errorf("%s: not an ID, Unix milliseconds or RFC 3339 time", arg)
+		}
+		if milli < 0 || milli >= 1<<48 {
+			return fmt.Errorf("%s: outside the 6-byte timestamp range", arg)
+		}
+		t := time.UnixMilli(milli)
+		fmt.Fprintf(w, "%s %s\n", kid.FirstForTime(t), kid.LastForTime(t))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTS(t *testing.T) {
+	vancouver, err := time.LoadLocation("America/Vancouver")
+	if err != nil {
+		t.Skip(err)
+	}
+	tests := []struct {
+		name string
+		args []string
+		loc  *time.Location
+		want string
+		err  string
+	}{
+		{"id", []string{"06bqer9xnm79tfnl"}, time.UTC, "1741456227757 2025-03-08T17:50:27.757Z\n", ""},
+		{"id in loc", []string{"06bqer9xnm79tfnl"}, vancouver, "1741456227757 2025-03-08T09:50:27.757-08:00\n", ""},
+		{"millis", []string{"1741456227757"}, time.UTC, "06bqer9xnm000000 06bqer9xnqzzzzzz\n", ""},
+		{"rfc3339", []string{"2025-03-08T17:50:27.757Z"}, time.UTC, "06bqer9xnm000000 06bqer9xnqzzzzzz\n", ""},
+		{"rfc3339 offset", []string{"2025-03-08T09:50:27.7579-08:00"}, time.UTC, "06bqer9xnm000000 06bqer9xnqzzzzzz\n", ""},
+		{"first", []string{"0"}, time.UTC, "0000000000000000 0000000003zzzzzz\n", ""},
+		{"last", []string{"281474976710655"}, time.UTC, "zzzzzzzzzw000000 zzzzzzzzzzzzzzzz\n", ""},
+		{"16 digits is an id", []string{"1000000000000000"}, time.UTC, "8796093022208 2248-09-26T15:10:22.208Z\n", ""},
+		{"several", []string{"06bqer9xnm79tfnl", "0"}, time.UTC, "1741456227757 2025-03-08T17:50:27.757Z\n0000000000000000 0000000003zzzzzz\n", ""},
+		{"past the range", []string{"281474976710656"}, time.UTC, "", "281474976710656: outside the 6-byte timestamp range"},
+		{"negative", []string{"-1"}, time.UTC, "", "-1: outside the 6-byte timestamp range"},
+		{"before 1970", []string{"1969-12-31T23:59:59Z"}, time.UTC, "", "1969-12-31T23:59:59Z: outside the 6-byte timestamp range"},
+		{"garbage", []string{"nope"}, time.UTC, "", "nope: not an ID, Unix milliseconds or RFC 3339 time"},
+		{"stops at the first error", []string{"0", "nope", "1"}, time.UTC, "0000000000000000 0000000003zzzzzz\n", "nope: not an ID, Unix milliseconds or RFC 3339 time"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		err := ts(&b, tt.args, tt.loc)
+		if b.String() != tt.want {
+			t.Errorf("%s: ts(%q) printed %q, want %q", tt.name, tt.args, b.String(), tt.want)
+		}
+		if msg := errString(err); msg != tt.err {
+			t.Errorf("%s: ts(%q) error = %q, want %q", tt.name, tt.args, msg, tt.err)
+		}
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
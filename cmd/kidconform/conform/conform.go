@@ -0,0 +1,258 @@
+// Package conform defines the kid conformance protocol: a small HTTP API,
+// served by cmd/kidconform from the Go implementation, that ports to other
+// languages can query as an oracle or implement themselves to be checked.
+//
+// Endpoints, all GET, all answering JSON:
+//
+//	/encode?hex=<20 hex digits>  {"id": "06bqer9xnm79tfnl"}
+//	/decode?id=<encoded ID>      {"hex": ..., "timestamp": ..., "sequence": ..., "random": ...}
+//	/generate?n=<count>          {"ids": ["06bqer9xnm79tfnl", ...]}
+//	/vectors                     {"valid": [<decoded>...], "invalid": ["..."]}
+//
+// Invalid input is answered with status 400 and {"error": "..."}.
+package conform
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mwyvr/kid"
+)
+
+// MaxGenerate is the largest count a /generate request may ask for.
+const MaxGenerate = 10000
+
+// Decoded is an ID broken into its parts, as answered by /decode.
+type Decoded struct {
+	ID        string `json:"id"`
+	Hex       string `json:"hex"`
+	Timestamp int64  `json:"timestamp"`
+	Sequence  int32  `json:"sequence"`
+	Random    int32  `json:"random"`
+}
+
+// Vectors are fixed test cases: valid IDs with their parts, and strings
+// every implementation must reject.
+type Vectors struct {
+	Valid   []Decoded `json:"valid"`
+	Invalid []string  `json:"invalid"`
+}
+
+func decoded(id kid.ID) Decoded {
+	return Decoded{
+		ID:        id.String(),
+		Hex:       id.Hex(),
+		Timestamp: id.Timestamp(),
+		Sequence:  id.Sequence(),
+		Random:    id.Random(),
+	}
+}
+
+// TestVectors returns the conformance test vectors.
+func TestVectors() Vectors {
+	var v Vectors
+	for _, id := range []kid.ID{
+		kid.Nil,
+		kid.Max,
+		kid.MustFromString("06bqer9xnm79tfnl"),
+		kid.Nil.Next(),
+		kid.Max.Prev(),
+		{0x80, 0, 0, 0, 0, 0, 0, 0, 0, 0x01},
+		{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0xfe, 0xdc},
+		{0xde, 0xad, 0xbe, 0xef, 0xca, 0xfe, 0xba, 0xbe, 0x55, 0xaa},
+	} {
+		v.Valid = append(v.Valid, decoded(id))
+	}
+	v.Invalid = []string{
+		"",
+		"06bqer9xnm79tfn",   // short
+		"06bqer9xnm79tfnl0", // long
+		"06BQER9XNM79TFNL",  // upper case
+		"06bqer9xnm79tfna",  // excluded vowels
+		"06bqer9xnm79tfni",
+		"06bqer9xnm79tfno",
+		"06bqer9xnm79tfnu",
+		"06bqer9xnm79tfn-",
+		"06bqer9xnm79tfn\x00",
+		"06bqer9xnm79tfé", // 16 bytes, 15 characters
+	}
+	return v
+}
+
+// Handler serves the conformance protocol from the Go implementation.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /encode", func(w http.ResponseWriter, r *http.Request) {
+		b, err := hex.DecodeString(r.URL.Query().Get("hex"))
+		if err == nil && len(b) != 10 {
+			err = errors.New("want 10 bytes")
+		}
+		if err != nil {
+			reply(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		id, _ := kid.FromBytes(b)
+		reply(w, http.StatusOK, map[string]string{"id": id.String()})
+	})
+	mux.HandleFunc("GET /decode", func(w http.ResponseWriter, r *http.Request) {
+		id, err := kid.FromString(r.URL.Query().Get("id"))
+		if err != nil {
+			reply(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		reply(w, http.StatusOK, decoded(id))
+	})
+	mux.HandleFunc("GET /generate", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(r.URL.Query().Get("n"))
+		if err != nil || n < 1 || n > MaxGenerate {
+			reply(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("n must be 1-%d", MaxGenerate)})
+			return
+		}
+		ids := make([]kid.ID, n)
+		for i := range ids {
+			ids[i] = kid.New()
+		}
+		reply(w, http.StatusOK, map[string][]kid.ID{"ids": ids})
+	})
+	mux.HandleFunc("GET /vectors", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, http.StatusOK, TestVectors())
+	})
+	return mux
+}
+
+func reply(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// ErrRejected is returned by Client methods when the server answers 400.
+var ErrRejected = errors.New("conform: input rejected")
+
+// Client queries a conformance server: cmd/kidconform, or a port's own
+// implementation of the protocol.
+type Client struct {
+	Base string       // e.g. "http://localhost:9464"
+	HTTP *http.Client // nil: http.DefaultClient
+}
+
+// Encode asks the server to encode an ID given as 20 hex digits.
+func (c *Client) Encode(hexID string) (string, error) {
+	var v struct{ ID string }
+	err := c.get("/encode", url.Values{"hex": {hexID}}, &v)
+	return v.ID, err
+}
+
+// Decode asks the server to decode id.
+func (c *Client) Decode(id string) (Decoded, error) {
+	var v Decoded
+	err := c.get("/decode", url.Values{"id": {id}}, &v)
+	return v, err
+}
+
+// Generate asks the server for n new IDs.
+func (c *Client) Generate(n int) ([]string, error) {
+	var v struct{ IDs []string }
+	err := c.get("/generate", url.Values{"n": {strconv.Itoa(n)}}, &v)
+	return v.IDs, err
+}
+
+// Vectors fetches the server's test vectors.
+func (c *Client) Vectors() (Vectors, error) {
+	var v Vectors
+	err := c.get("/vectors", nil, &v)
+	return v, err
+}
+
+func (c *Client) get(path string, q url.Values, v any) error {
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	u := c.Base + path
+	if q != nil {
+		u += "?" + q.Encode()
+	}
+	resp, err := hc.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return fmt.Errorf("conform: %s: %w", path, err)
+		}
+		return nil
+	case http.StatusBadRequest:
+		return ErrRejected
+	default:
+		return fmt.Errorf("conform: %s: %s", path, resp.Status)
+	}
+}
+
+// Check runs the conformance suite against the server behind c, using the
+// Go implementation as the oracle: the test vectors, n random IDs through
+// encode and decode, and n generated IDs, which must be valid, strictly
+// increasing and timestamped within a minute of now. It returns every
+// failure found; an empty result means the server conforms.
+func Check(c *Client, n int) []error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	expect := func(want Decoded) {
+		if got, err := c.Encode(want.Hex); err != nil || got != want.ID {
+			fail("encode %s = %q, %v; want %q", want.Hex, got, err, want.ID)
+		}
+		if got, err := c.Decode(want.ID); err != nil || got != want {
+			fail("decode %s = %+v, %v; want %+v", want.ID, got, err, want)
+		}
+	}
+
+	v := TestVectors()
+	for _, want := range v.Valid {
+		expect(want)
+	}
+	for _, s := range v.Invalid {
+		if got, err := c.Decode(s); !errors.Is(err, ErrRejected) {
+			fail("decode %q = %+v, %v; want rejection", s, got, err)
+		}
+	}
+	for range n {
+		var id kid.ID
+		for i := range id {
+			id[i] = byte(rand.Uint32()) //nolint:gosec
+		}
+		expect(decoded(id))
+	}
+
+	ids, err := c.Generate(n)
+	if err != nil || len(ids) != n {
+		fail("generate %d = %d IDs, %v", n, len(ids), err)
+		return errs
+	}
+	var prev kid.ID
+	for _, s := range ids {
+		id, err := kid.FromString(s)
+		if err != nil {
+			fail("generated %q: %v", s, err)
+			continue
+		}
+		if id.Compare(prev) <= 0 {
+			fail("generated %s does not sort after %s", id, prev)
+		}
+		if skew := time.Since(id.Time()); skew > time.Minute || skew < -time.Minute {
+			fail("generated %s is timestamped %v, %v from now", id, id.Time(), skew)
+		}
+		prev = id
+	}
+	return errs
+}
@@ -0,0 +1,31 @@
+package conform
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckOracle(t *testing.T) {
+	srv := httptest.NewServer(Handler())
+	defer srv.Close()
+	for _, err := range Check(&Client{Base: srv.URL}, 500) {
+		t.Error(err)
+	}
+}
+
+func TestCheckFindsFaults(t *testing.T) {
+	// a port that upper-cases its output
+	oracle := Handler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := httptest.NewRecorder()
+		oracle.ServeHTTP(rec, r)
+		w.WriteHeader(rec.Code)
+		w.Write([]byte(strings.ToUpper(rec.Body.String())))
+	}))
+	defer srv.Close()
+	if errs := Check(&Client{Base: srv.URL}, 10); len(errs) == 0 {
+		t.Error("Check() found no failures in a faulty server")
+	}
+}
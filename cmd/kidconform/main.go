@@ -0,0 +1,49 @@
+// Command kidconform is a conformance oracle for ports of kid to other
+// languages. It serves the Go implementation over the HTTP protocol of
+// package conform, or checks another server implementing that protocol
+// against it:
+//
+//	$ kidconform -addr localhost:9464              # serve the oracle
+//	$ kidconform -check http://localhost:8000 -n 5000  # check a port
+//
+// A port passes when -check reports no failures and exits 0.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/mwyvr/kid/cmd/kidconform/conform"
+)
+
+func main() {
+	addr := "localhost:9464"
+	check := ""
+	n := 1000
+	flag.StringVar(&addr, "addr", addr, "Address to serve the oracle on")
+	flag.StringVar(&check, "check", check, "Base URL of a server to check instead of serving")
+	flag.IntVar(&n, "n", n, "Random and generated IDs to check")
+	flag.Parse()
+
+	if check == "" {
+		log.Printf("kidconform: serving on http://%s", addr)
+		log.Fatal(http.ListenAndServe(addr, conform.Handler())) //nolint:gosec
+	}
+
+	if n < 1 || n > conform.MaxGenerate {
+		fmt.Fprintf(os.Stderr, "kidconform: -n must be 1-%d\n", conform.MaxGenerate)
+		os.Exit(2)
+	}
+	errs := conform.Check(&conform.Client{Base: check}, n)
+	for _, err := range errs {
+		fmt.Println("FAIL", err)
+	}
+	if len(errs) > 0 {
+		fmt.Printf("kidconform: %s: %d failures\n", check, len(errs))
+		os.Exit(1)
+	}
+	fmt.Printf("kidconform: %s: ok\n", check)
+}
@@ -0,0 +1,12 @@
+//go:build js && wasm
+
+// Command kidwasm is a WebAssembly module exposing kid to JavaScript; see
+// package kidjs for the bindings and how to load it.
+package main
+
+import "github.com/mwyvr/kid/kidjs"
+
+func main() {
+	kidjs.Register()
+	select {} // serve calls from JavaScript
+}
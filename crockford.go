@@ -0,0 +1,41 @@
+package kid
+
+// crockford is Crockford's base32 alphabet, also used by ULID.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordDec decodes Crockford base32 characters: case-insensitively, with
+// I and L read as 1 and O as 0, as the specification requires.
+var crockfordDec = func() (d [256]byte) {
+	for i := range d {
+		d[i] = maxByte
+	}
+	for i := range len(crockford) {
+		c := crockford[i]
+		d[c] = byte(i)
+		d[c|0x20] = byte(i) // lower case; digits are unaffected
+	}
+	d['I'], d['i'], d['L'], d['l'] = 1, 1, 1, 1
+	d['O'], d['o'] = 0, 0
+	return d
+}()
+
+// EncodeCrockford returns id encoded in Crockford's base32, 16 characters
+// in upper case, for shops standardized on that alphabet. It encodes the
+// same 80 bits as String, and the alphabet is in ascending ASCII order, so
+// encoded IDs sort like binary IDs.
+func (id ID) EncodeCrockford() string {
+	var text [encodedLen]byte
+	encode(text[:], id[:])
+	for i, c := range text {
+		text[i] = crockford[dec[c]]
+	}
+	return string(text[:])
+}
+
+// FromCrockford decodes s, an ID encoded in Crockford's base32, following
+// the specification: letters in either case, I and L read as 1, O as 0, and
+// hyphens ignored. On error it returns the nil ID and an
+// *InvalidLengthError or *InvalidCharError, wrapping ErrInvalidID.
+func FromCrockford(s string) (ID, error) {
+	return decodeLoose(s, &crockfordDec)
+}
@@ -0,0 +1,35 @@
+package kid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCrockford(t *testing.T) {
+	id := tests[6].id // 06bqer9xnm79tfnl
+	want := "06AQDR9XNM79TENK"
+	if got := id.EncodeCrockford(); got != want {
+		t.Errorf("EncodeCrockford() = %s, want %s", got, want)
+	}
+	for _, s := range []string{want, "06aqdr9xnm79tenk", "O6AQ-DR9X-NM79-TENK"} {
+		if got, err := FromCrockford(s); err != nil || got != id {
+			t.Errorf("FromCrockford(%q) = %v, %v, want %v", s, got, err, id)
+		}
+	}
+	if got, err := FromCrockford("0000000000000IlL"); err != nil || got != MustFromString("0000000000000111") {
+		t.Errorf("FromCrockford(aliases) = %v, %v", got, err)
+	}
+	for _, s := range []string{"", "06AQDR9XNM79TEN", "06AQDR9XNM79TENKK", "06AQDR9XNM79TENU"} {
+		if got, err := FromCrockford(s); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("FromCrockford(%q) = %v, %v, want ErrInvalidID", s, got, err)
+		}
+	}
+
+	ids := randomIDs(1000)
+	Sort(ids)
+	for i := 1; i < len(ids); i++ {
+		if a, b := ids[i-1].EncodeCrockford(), ids[i].EncodeCrockford(); a > b {
+			t.Fatalf("EncodeCrockford() order: %s > %s for sorted IDs", a, b)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Command cshared builds kid as a C shared library, so services in other
+// languages can generate byte-compatible IDs through FFI:
+//
+//	$ go build -buildmode=c-shared -o libkid.so ./cshared    # Linux
+//	$ go build -buildmode=c-shared -o libkid.dylib ./cshared # macOS
+//	$ go build -buildmode=c-shared -o kid.dll ./cshared      # Windows
+//
+// The build also writes a header, libkid.h (or kid.h), declaring:
+//
+//	void kid_new(uint8_t* out);             // 10 bytes of a new ID
+//	void kid_encode(uint8_t* id, char* out); // 16 characters and a NUL
+//	int kid_decode(char* s, uint8_t* out);   // 0, or -1 if s is invalid
+//
+// From Python, for example:
+//
+//	import ctypes
+//	lib = ctypes.CDLL("./libkid.so")
+//	raw, text = ctypes.create_string_buffer(10), ctypes.create_string_buffer(17)
+//	lib.kid_new(raw)
+//	lib.kid_encode(raw, text)
+//	print(text.value.decode())  # 06bqer9xnm79tfnl
+//
+// IDs come from the package-level kid.New, so IDs from one process loading
+// the library share its uniqueness and ordering guarantees. Building
+// requires cgo.
+package main
+
+import "github.com/mwyvr/kid"
+
+// The exported functions adapt C buffers to these; out must have room for
+// the result.
+
+func newID(out []byte) {
+	id := kid.New()
+	copy(out, id[:])
+}
+
+func encode(id, out []byte) {
+	kid.ID(id).Encode(out)
+	out[16] = 0
+}
+
+func decode(s string, out []byte) bool {
+	id, err := kid.FromString(s)
+	if err != nil {
+		return false
+	}
+	copy(out, id[:])
+	return true
+}
+
+func main() {}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mwyvr/kid"
+)
+
+func TestFunctions(t *testing.T) {
+	var a, b [10]byte
+	newID(a[:])
+	newID(b[:])
+	if kid.ID(a).IsNil() || kid.ID(b).Compare(kid.ID(a)) <= 0 {
+		t.Errorf("newID() = %x, %x, want ascending IDs", a, b)
+	}
+
+	id := kid.MustFromString("06bqer9xnm79tfnl")
+	out := bytes.Repeat([]byte{0xff}, 17)
+	encode(id[:], out)
+	if string(out) != "06bqer9xnm79tfnl\x00" {
+		t.Errorf("encode() = %q, want a NUL-terminated encoding", out)
+	}
+
+	var got [10]byte
+	if !decode("06bqer9xnm79tfnl", got[:]) || got != id {
+		t.Errorf("decode() = %x, want %x", got, id[:])
+	}
+	for _, s := range []string{"", "06bqer9xnm79tfna", "06bqer9xnm79tfnl0"} {
+		var got [10]byte
+		if decode(s, got[:]) || got != [10]byte{} {
+			t.Errorf("decode(%q) = true or wrote %x, want false", s, got)
+		}
+	}
+}
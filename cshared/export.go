@@ -0,0 +1,36 @@
+package main
+
+// #include <stdint.h>
+import "C"
+
+import "unsafe"
+
+func buf(p unsafe.Pointer, n int) []byte {
+	return unsafe.Slice((*byte)(p), n)
+}
+
+// kid_new writes the 10 bytes of a new ID to out.
+//
+//export kid_new
+func kid_new(out *C.uint8_t) {
+	newID(buf(unsafe.Pointer(out), 10))
+}
+
+// kid_encode writes the 16-character encoding of id, 10 bytes, to out,
+// followed by a NUL: 17 bytes in all.
+//
+//export kid_encode
+func kid_encode(id *C.uint8_t, out *C.char) {
+	encode(buf(unsafe.Pointer(id), 10), buf(unsafe.Pointer(out), 17))
+}
+
+// kid_decode parses s, a NUL-terminated encoded ID, writing its 10 bytes to
+// out. It returns 0, or -1 leaving out untouched if s is not a valid ID.
+//
+//export kid_decode
+func kid_decode(s *C.char, out *C.uint8_t) C.int {
+	if !decode(C.GoString(s), buf(unsafe.Pointer(out), 10)) {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+//go:build !tinygo
+
+package kid
+
+import "sync"
+
+// decPair returns the table decoding two characters at once: indexed by
+// their bytes, first byte high, it holds their 10-bit value, or a value with
+// the high bit set if either is outside the alphabet. At 128 KiB it is built
+// on first use rather than at init or generated into tables.go, so programs
+// that never decode do not pay for it; TinyGo builds, for boards where
+// 128 KiB may be all the RAM there is, decode per character instead
+// (decpair_tinygo.go).
+var decPair = sync.OnceValue(func() *[1 << 16]uint16 {
+	t := new([1 << 16]uint16)
+	for i := range t {
+		hi, lo := dec[i>>8], dec[i&0xff]
+		if hi == maxByte || lo == maxByte {
+			t[i] = 0xffff
+		} else {
+			t[i] = uint16(hi)<<5 | uint16(lo)
+		}
+	}
+	return t
+})
+
+// decodePairs decodes src, 16 characters, into id with eight decPair
+// lookups, reporting whether all were in the alphabet; it validates and
+// decodes in one pass with a single branch, against sixteen lookups to
+// validate and twenty-six more in decode. On failure id is unchanged.
+func decodePairs(id *ID, src []byte) bool {
+	_ = src[15] // bounds check hint
+	p := decPair()
+	v0 := p[uint16(src[0])<<8|uint16(src[1])]
+	v1 := p[uint16(src[2])<<8|uint16(src[3])]
+	v2 := p[uint16(src[4])<<8|uint16(src[5])]
+	v3 := p[uint16(src[6])<<8|uint16(src[7])]
+	v4 := p[uint16(src[8])<<8|uint16(src[9])]
+	v5 := p[uint16(src[10])<<8|uint16(src[11])]
+	v6 := p[uint16(src[12])<<8|uint16(src[13])]
+	v7 := p[uint16(src[14])<<8|uint16(src[15])]
+	if (v0|v1|v2|v3|v4|v5|v6|v7)&0xfc00 != 0 {
+		return false
+	}
+	// 40 bits, five bytes, in each half
+	hi := uint64(v0)<<30 | uint64(v1)<<20 | uint64(v2)<<10 | uint64(v3)
+	lo := uint64(v4)<<30 | uint64(v5)<<20 | uint64(v6)<<10 | uint64(v7)
+	id[0], id[1], id[2], id[3], id[4] = byte(hi>>32), byte(hi>>24), byte(hi>>16), byte(hi>>8), byte(hi)
+	id[5], id[6], id[7], id[8], id[9] = byte(lo>>32), byte(lo>>24), byte(lo>>16), byte(lo>>8), byte(lo)
+	return true
+}
@@ -0,0 +1,19 @@
+//go:build !tinygo
+
+package kid
+
+import "testing"
+
+func TestDecPairTable(t *testing.T) {
+	p := decPair()
+	for i := range len(p) {
+		hi, lo := dec[i>>8], dec[i&0xff]
+		valid := hi != maxByte && lo != maxByte
+		if got := p[i]; valid && got != uint16(hi)<<5|uint16(lo) || !valid && got&0xfc00 == 0 {
+			t.Fatalf("decPair()[%#04x] = %#04x", i, got)
+		}
+	}
+	if decPair() != p {
+		t.Error("decPair() built the table twice")
+	}
+}
@@ -0,0 +1,18 @@
+//go:build tinygo
+
+package kid
+
+// decodePairs decodes src, 16 characters, into id, reporting whether all
+// were in the alphabet; on failure id is unchanged. Under TinyGo it
+// validates and decodes per character, sparing RAM-starved boards the
+// 128 KiB two-character table of decpair.go.
+func decodePairs(id *ID, src []byte) bool {
+	_ = src[15] // bounds check hint
+	for _, c := range src[:encodedLen] {
+		if dec[c] == maxByte {
+			return false
+		}
+	}
+	decode(id, src)
+	return true
+}
@@ -0,0 +1,163 @@
+package kid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// InvalidLengthError is returned when decoding text of the wrong length, in
+// any of the text forms, for APIs that tell callers what was wrong with an
+// ID they sent. It wraps
+// ErrInvalidID, so errors.Is(err, ErrInvalidID) holds.
+type InvalidLengthError struct {
+	Got  int // length of the input
+	Want int // length of the encoded form: 16 for ID, 13 for ID64, 26 for ID128
+}
+
+func (e *InvalidLengthError) Error() string {
+	return fmt.Sprintf("kid: invalid id: length %d, want %d", e.Got, e.Want)
+}
+
+// Unwrap returns ErrInvalidID.
+func (e *InvalidLengthError) Unwrap() error {
+	return ErrInvalidID
+}
+
+// InvalidCharError is returned when decoding text containing a character
+// outside its alphabet, or, for forms with spare bits, a character giving a
+// value too large for them. It wraps ErrInvalidID.
+type InvalidCharError struct {
+	Pos  int  // 0-based position of the first bad character
+	Char byte // the character itself
+}
+
+func (e *InvalidCharError) Error() string {
+	return fmt.Sprintf("kid: invalid id: character %q at position %d", e.Char, e.Pos)
+}
+
+// Unwrap returns ErrInvalidID.
+func (e *InvalidCharError) Unwrap() error {
+	return ErrInvalidID
+}
+
+// textError explains why text, which failed to decode, is not an encoding
+// of want characters whose first may be at most maxFirst. It is called only
+// on failure, keeping the decoders' success paths free of bookkeeping.
+func textError(text []byte, want int, maxFirst byte) error {
+	if len(text) != want {
+		return &InvalidLengthError{Got: len(text), Want: want}
+	}
+	for i, c := range text {
+		if d := dec[c]; d == maxByte || i == 0 && d > maxFirst {
+			return &InvalidCharError{Pos: i, Char: c}
+		}
+	}
+	return ErrInvalidID
+}
+
+// Code classifies an error for clients of a network surface issuing or
+// checking IDs, such as `kid serve` or the kidgrpc Issuer: a stable,
+// machine-readable string that survives the trip across HTTP or gRPC, where
+// Go error values do not. kidhttp and kidgrpc map codes to status codes.
+type Code string
+
+// The codes of the errors of this package, and of network surfaces.
+const (
+	CodeInvalidID       Code = "invalid_id"       // ErrInvalidID
+	CodeInvalidArgument Code = "invalid_argument" // a malformed request, other than an ID
+	CodeTimeRange       Code = "time_range"       // ErrTimeRange
+	CodeBadSignature    Code = "bad_signature"    // ErrBadSignature
+	CodeClockRegression Code = "clock_regression" // ErrClockRegression
+	CodeBackpressure    Code = "backpressure"     // ErrBackpressure
+	CodeQuotaExceeded   Code = "quota_exceeded"   // ErrQuotaExceeded
+	CodeNodeLost        Code = "node_lost"        // ErrNodeLost
+	CodeNoFreeNode      Code = "no_free_node"     // ErrNoFreeNode
+	CodeUnavailable     Code = "unavailable"      // the service, temporarily
+	CodeInternal        Code = "internal"         // anything else
+)
+
+// codeErrs are the errors of the codes that have one, in the order CodeOf
+// tests them.
+var codeErrs = []struct {
+	code Code
+	err  error
+}{
+	{CodeInvalidID, ErrInvalidID},
+	{CodeTimeRange, ErrTimeRange},
+	{CodeBadSignature, ErrBadSignature},
+	{CodeClockRegression, ErrClockRegression},
+	{CodeBackpressure, ErrBackpressure},
+	{CodeQuotaExceeded, ErrQuotaExceeded},
+	{CodeNodeLost, ErrNodeLost},
+	{CodeNoFreeNode, ErrNoFreeNode},
+}
+
+// Temporary reports whether an operation failing with code c may succeed
+// if retried, after a backoff, with no change to the request: the clock
+// catching up, a quota refilling or a node lease coming free.
+func (c Code) Temporary() bool {
+	switch c {
+	case CodeClockRegression, CodeBackpressure, CodeQuotaExceeded, CodeNodeLost, CodeNoFreeNode, CodeUnavailable:
+		return true
+	}
+	return false
+}
+
+// CodeOf returns the Code of err: that of an *Error in its chain, else that
+// of the error of this package it wraps, else CodeInternal. It returns ""
+// for a nil err.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	for _, c := range codeErrs {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return CodeInternal
+}
+
+// IsTemporary reports whether err, if not nil, is worth retrying: whether
+// CodeOf(err) is Temporary.
+func IsTemporary(err error) bool {
+	return CodeOf(err).Temporary()
+}
+
+// Error is an error with its Code, as sent by network surfaces and decoded
+// by their clients (kidhttp.ReadError, kidgrpc.FromError). It unwraps to
+// the error of this package its code stands for, if any, so a client can
+// test errors.Is(err, kid.ErrBackpressure) as a caller of the library
+// would.
+type Error struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewError returns the *Error reporting err, with err's code and message.
+func NewError(err error) *Error {
+	return &Error{Code: CodeOf(err), Message: err.Error()}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Temporary reports whether e.Code is Temporary.
+func (e *Error) Temporary() bool {
+	return e.Code.Temporary()
+}
+
+// Unwrap returns the error of this package that e.Code stands for, or nil.
+func (e *Error) Unwrap() error {
+	for _, c := range codeErrs {
+		if c.code == e.Code {
+			return c.err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package kid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestInvalidIDErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+		msg  string
+	}{
+		{"short", fromString("06bqer9"), &InvalidLengthError{Got: 7, Want: 16}, "kid: invalid id: length 7, want 16"},
+		{"upper case", fromString("06bqer9xNm79tfnl"), &InvalidCharError{Pos: 8, Char: 'N'}, `kid: invalid id: character 'N' at position 8`},
+		{"last", fromString("06bqer9xnm79tfnu"), &InvalidCharError{Pos: 15, Char: 'u'}, `kid: invalid id: character 'u' at position 15`},
+		{"json", json.Unmarshal([]byte(`"06bqer9xnm79tfn"`), new(ID)), &InvalidLengthError{Got: 15, Want: 16}, "kid: invalid id: length 15, want 16"},
+		{"json char", json.Unmarshal([]byte(`"o6bqer9xnm79tfnl"`), new(ID)), &InvalidCharError{Pos: 0, Char: 'o'}, `kid: invalid id: character 'o' at position 0`},
+		{"json number", new(ID).UnmarshalJSON([]byte("1234567890123456")), ErrInvalidID, "kid: invalid id"},
+		{"ID64 range", new(ID64).UnmarshalText([]byte("h000000000000")), &InvalidCharError{Pos: 0, Char: 'h'}, `kid: invalid id: character 'h' at position 0`},
+		{"ID64 length", new(ID64).UnmarshalText([]byte("0")), &InvalidLengthError{Got: 1, Want: 13}, "kid: invalid id: length 1, want 13"},
+		{"ID128 range", new(ID128).UnmarshalText([]byte("80000000000000000000000000")), &InvalidCharError{Pos: 0, Char: '8'}, `kid: invalid id: character '8' at position 0`},
+		{"loose", err(FromStringLoose("06BQ-ER9X-NM79-TFN")), &InvalidLengthError{Got: 15, Want: 16}, "kid: invalid id: length 15, want 16"},
+		{"loose char", err(FromStringLoose("06bqer9x nm79tfnl")), &InvalidCharError{Pos: 8, Char: ' '}, `kid: invalid id: character ' ' at position 8`},
+		{"profile", err(PrettyProfile.FromString("06bq-er9x-nm79")), &InvalidLengthError{Got: 14, Want: 19}, "kid: invalid id: length 14, want 19"},
+		{"profile separator", err(PrettyProfile.FromString("06bq_er9x-nm79-tfnl")), &InvalidCharError{Pos: 4, Char: '_'}, `kid: invalid id: character '_' at position 4`},
+		{"hex", err(FromHex("019576e13dad0e9d3ab")), &InvalidLengthError{Got: 19, Want: 20}, "kid: invalid id: length 19, want 20"},
+		{"hex char", err(FromHex("019576e13dad0e9d3abg")), &InvalidCharError{Pos: 19, Char: 'g'}, `kid: invalid id: character 'g' at position 19`},
+		{"base62", err(FromBase62("0")), &InvalidLengthError{Got: 1, Want: 14}, "kid: invalid id: length 1, want 14"},
+		{"base62 range", err(FromBase62("zzzzzzzzzzzzzz")), &InvalidCharError{Pos: 0, Char: 'z'}, `kid: invalid id: character 'z' at position 0`},
+		{"base64", err(FromBase64("AZV24T2tDp06s")), &InvalidLengthError{Got: 13, Want: 14}, "kid: invalid id: length 13, want 14"},
+		{"base64 char", err(FromBase64("AZV2+T2tDp06sw")), &InvalidCharError{Pos: 4, Char: '+'}, `kid: invalid id: character '+' at position 4`},
+		{"base64 spare bits", err(FromBase64("AZV24T2tDp06sx")), &InvalidCharError{Pos: 13, Char: 'x'}, `kid: invalid id: character 'x' at position 13`},
+		{"ULID", err(ParseULID("01JNVE2FDD")), &InvalidLengthError{Got: 10, Want: 26}, "kid: invalid id: length 10, want 26"},
+		{"ULID range", err(ParseULID("81JNVE2FDD3MXKMR0000000000")), &InvalidCharError{Pos: 0, Char: '8'}, `kid: invalid id: character '8' at position 0`},
+		{"ULID char", err(ParseULID("01JNVE2FDD3MXKMR000000000U")), &InvalidCharError{Pos: 25, Char: 'U'}, `kid: invalid id: character 'U' at position 25`},
+		{"typed json", new(Typed).UnmarshalJSON([]byte("123")), nil, "kid: invalid id: Typed JSON is not a string: 123"},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, ErrInvalidID) {
+			t.Errorf("%s: errors.Is(%v, ErrInvalidID) = false", tt.name, tt.err)
+		}
+		if tt.err.Error() != tt.msg {
+			t.Errorf("%s: Error() = %q, want %q", tt.name, tt.err.Error(), tt.msg)
+		}
+		switch want := tt.want.(type) {
+		case *InvalidLengthError:
+			var got *InvalidLengthError
+			if !errors.As(tt.err, &got) || *got != *want {
+				t.Errorf("%s: err = %#v, want %#v", tt.name, tt.err, want)
+			}
+		case *InvalidCharError:
+			var got *InvalidCharError
+			if !errors.As(tt.err, &got) || *got != *want {
+				t.Errorf("%s: err = %#v, want %#v", tt.name, tt.err, want)
+			}
+		default: // nil checks only the message
+			if want != nil && tt.err != want {
+				t.Errorf("%s: err = %v, want %v", tt.name, tt.err, want)
+			}
+		}
+	}
+}
+
+func fromString(s string) error {
+	_, err := FromString(s)
+	return err
+}
+
+// err returns the error of a decoder's results.
+func err[T any](_ T, err error) error {
+	return err
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		err       error
+		code      Code
+		temporary bool
+	}{
+		{nil, "", false},
+		{fromString("06bqer9"), CodeInvalidID, false},
+		{ErrChecksum, CodeInvalidID, false},
+		{fmt.Errorf("backfill: %w", ErrTimeRange), CodeTimeRange, false},
+		{ErrBadSignature, CodeBadSignature, false},
+		{ErrClockRegression, CodeClockRegression, true},
+		{fmt.Errorf("%w: 3ms ahead", ErrBackpressure), CodeBackpressure, true},
+		{ErrQuotaExceeded, CodeQuotaExceeded, true},
+		{ErrNodeLost, CodeNodeLost, true},
+		{ErrNoFreeNode, CodeNoFreeNode, true},
+		{&Error{Code: CodeUnavailable, Message: "draining"}, CodeUnavailable, true},
+		{fmt.Errorf("call: %w", &Error{Code: CodeInvalidArgument}), CodeInvalidArgument, false},
+		{errors.New("disk on fire"), CodeInternal, false},
+	}
+	for _, tt := range tests {
+		if got := CodeOf(tt.err); got != tt.code {
+			t.Errorf("CodeOf(%v) = %q, want %q", tt.err, got, tt.code)
+		}
+		if got := IsTemporary(tt.err); got != tt.temporary {
+			t.Errorf("IsTemporary(%v) = %t, want %t", tt.err, got, tt.temporary)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	err := error(NewError(fmt.Errorf("%w: 3ms ahead", ErrBackpressure)))
+	if err.Error() != "kid: generator too far ahead of the clock: 3ms ahead" {
+		t.Errorf("Error() = %q", err)
+	}
+	// as decoded by a client: the code alone restores the error's identity
+	err = &Error{Code: CodeBackpressure, Message: err.Error()}
+	if !errors.Is(err, ErrBackpressure) || errors.Is(err, ErrNodeLost) {
+		t.Errorf("errors.Is(%v, ErrBackpressure) = false", err)
+	}
+	if e := (&Error{Code: CodeInternal}); e.Unwrap() != nil || e.Temporary() {
+		t.Errorf("CodeInternal Error unwraps to %v, Temporary %t", e.Unwrap(), e.Temporary())
+	}
+}
@@ -0,0 +1,101 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/mwyvr/kid"
+	"github.com/oklog/ulid"
+	"github.com/rs/xid"
+)
+
+// Decoding: kid.FromString validates and decodes with eight two-character
+// table lookups; BenchmarkKidFromStringPerChar is the per-character decoder
+// it replaced, kept for comparison.
+
+var decodeInputs = func() []string {
+	s := make([]string, 1024)
+	for i := range s {
+		s[i] = kid.New().String()
+	}
+	return s
+}()
+
+func BenchmarkKidFromString(b *testing.B) {
+	var r kid.ID
+	for i := range b.N {
+		r, _ = kid.FromString(decodeInputs[i&1023])
+	}
+	resultKID = r
+}
+
+func BenchmarkKidFromStringPerChar(b *testing.B) {
+	var r kid.ID
+	for i := range b.N {
+		r, _ = perCharFromString(decodeInputs[i&1023])
+	}
+	resultKID = r
+}
+
+func BenchmarkXidFromString(b *testing.B) {
+	in := make([]string, len(decodeInputs))
+	for i := range in {
+		in[i] = xid.New().String()
+	}
+	var r xid.ID
+	b.ResetTimer()
+	for i := range b.N {
+		r, _ = xid.FromString(in[i&1023])
+	}
+	resultXID = r
+}
+
+func BenchmarkUlidParse(b *testing.B) {
+	in := make([]string, len(decodeInputs))
+	for i := range in {
+		in[i] = kid.NewULID().String()
+	}
+	var r ulid.ULID
+	b.ResetTimer()
+	for i := range b.N {
+		r, _ = ulid.Parse(in[i&1023])
+	}
+	resultULID = r
+}
+
+// perCharDec is the kid alphabet's decoding map, as kid's tables.go.
+var perCharDec = func() (d [256]byte) {
+	const alphabet = "0123456789bcdefghjklmnpqrstvwxyz"
+	for i := range d {
+		d[i] = 0xff
+	}
+	for i := range len(alphabet) {
+		d[alphabet[i]] = byte(i)
+	}
+	return d
+}()
+
+// perCharFromString is kid.FromString as it was before two-character
+// lookups: a validating pass over the characters, then an unrolled decode.
+func perCharFromString(s string) (kid.ID, error) {
+	var id kid.ID
+	if len(s) != 16 {
+		return id, kid.ErrInvalidID
+	}
+	for i := range len(s) {
+		if perCharDec[s[i]] == 0xff {
+			return id, kid.ErrInvalidID
+		}
+	}
+	dec := &perCharDec
+	id[9] = dec[s[14]]<<5 | dec[s[15]]
+	id[8] = dec[s[12]]<<7 | dec[s[13]]<<2 | dec[s[14]]>>3
+	id[7] = dec[s[11]]<<4 | dec[s[12]]>>1
+	id[6] = dec[s[9]]<<6 | dec[s[10]]<<1 | dec[s[11]]>>4
+	id[5] = dec[s[8]]<<3 | dec[s[9]]>>2
+	id[4] = dec[s[6]]<<5 | dec[s[7]]
+	id[3] = dec[s[4]]<<7 | dec[s[5]]<<2 | dec[s[6]]>>3
+	id[2] = dec[s[3]]<<4 | dec[s[4]]>>1
+	id[1] = dec[s[1]]<<6 | dec[s[2]]<<1 | dec[s[3]]>>4
+	id[0] = dec[s[0]]<<3 | dec[s[1]]>>2
+	return id, nil
+}
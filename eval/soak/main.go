@@ -0,0 +1,348 @@
+// Command soak is a long-running confidence test for kid.New: it generates
+// IDs at a configurable rate for hours or days and watches for any ID that
+// repeats or sorts out of order — including across restarts of the process.
+//
+// Every ID is checked twice:
+//
+//  1. Ordering: each ID must sort after its predecessor. Within a process
+//     this is New's guarantee, and strictly increasing output cannot repeat.
+//  2. Membership: each ID is looked up in, then added to, a Bloom filter of
+//     every ID generated so far. A hit is a *possible* duplicate: expected
+//     false positives are reported alongside, and grow as the filter fills,
+//     so size -mbits to the run.
+//
+// The filter, the last ID and the counters are checkpointed to -state every
+// -checkpoint interval and on exit (SIGINT/SIGTERM). A later run resumes from
+// the checkpoint, so its first ID must sort after the previous run's last ID
+// and must not hit the filter. kid's monotonicity is per process unless the
+// Generator persists its state: with -kidstate, IDs come from a Generator
+// using kid.WithState and a kid.FileState, and ordering must hold across
+// restarts. Without it, a restart whose clock reads behind the checkpointed
+// ID is counted separately as a restart regression rather than an
+// in-process ordering violation.
+//
+// Metrics are served in the Prometheus text format on -metrics (disable with
+// an empty address), using only the standard library.
+//
+// Usage:
+//
+//	$ go run . -rate 50000 -duration 8h -state /var/tmp/kid-soak.state
+//	soak: resuming 1,800,000,000 IDs from /var/tmp/kid-soak.state (run 3)
+//	soak: 1,830,000,000 IDs  possible dupes: 0 (expected false positives 0.0)  ordering violations: 0  restart regressions: 0
+//
+// Memory: the filter holds -mbits mebibits (128MiB by default), plus the
+// same again briefly while checkpointing.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/mwyvr/kid"
+)
+
+const hashes = 7 // Bloom filter hash functions
+
+// stats are updated by the generator and read by the metrics handler.
+var stats struct {
+	ids, hits, ordering, restarts, runs atomic.Uint64
+	checkpoints                         atomic.Uint64
+}
+
+func main() {
+	var (
+		rate       = 10000
+		duration   time.Duration
+		statePath  = "kid-soak.state"
+		checkpoint = time.Minute
+		metrics    = "localhost:9464"
+		mbits      = 1024
+		kidState   string
+	)
+	flag.IntVar(&rate, "rate", rate, "IDs generated per second (0 for unthrottled)")
+	flag.DurationVar(&duration, "duration", duration, "Stop after this long (0 runs until interrupted)")
+	flag.StringVar(&statePath, "state", statePath, "Checkpoint file, resumed if present")
+	flag.DurationVar(&checkpoint, "checkpoint", checkpoint, "Interval between checkpoints")
+	flag.StringVar(&metrics, "metrics", metrics, "Address to serve Prometheus metrics on (empty to disable)")
+	flag.IntVar(&mbits, "mbits", mbits, "Bloom filter size in mebibits; only used for a new state file")
+	flag.StringVar(&kidState, "kidstate", kidState, "Persist generator state in this file (kid.WithState)")
+	flag.Parse()
+
+	st, err := load(statePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		st = &state{bits: make([]uint64, mbits<<20/64)}
+		fmt.Printf("soak: new state %s, %d Mib filter\n", statePath, mbits)
+	case err != nil:
+		log.Fatalf("soak: %v", err)
+	default:
+		fmt.Printf("soak: resuming %s IDs from %s (run %d)\n", commas(st.ids), statePath, st.runs+1)
+	}
+	st.runs++
+	stats.ids.Store(st.ids)
+	stats.hits.Store(st.hits)
+	stats.ordering.Store(st.ordering)
+	stats.restarts.Store(st.restarts)
+	stats.runs.Store(st.runs)
+
+	if metrics != "" {
+		http.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+			writeMetrics(w, st)
+		})
+		go func() {
+			log.Printf("soak: metrics: %v", http.ListenAndServe(metrics, nil)) //nolint:gosec
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	var opts []kid.Option[kid.Generator]
+	if kidState != "" {
+		opts = append(opts, kid.WithState(kid.NewFileState(kidState)))
+	}
+	run(ctx, kid.NewGenerator(opts...), st, rate, checkpoint, statePath)
+	if err := save(statePath, st); err != nil {
+		log.Fatalf("soak: %v", err)
+	}
+	report(st)
+	// Filter hits are false positives until they clearly outnumber the
+	// expected count: more than three standard deviations (Poisson) over.
+	fp := st.expectedFalsePositives()
+	if st.ordering > 0 || st.restarts > 0 || float64(st.hits) > fp+3*math.Sqrt(fp)+1 {
+		fmt.Println("!!! FAILURES DETECTED !!!")
+		os.Exit(1)
+	}
+}
+
+// run generates IDs until ctx is done, in batches every 10ms to hold rate.
+func run(ctx context.Context, g *kid.Generator, st *state, rate int, every time.Duration, path string) {
+	const tick = 10 * time.Millisecond
+	batch := max(rate/int(time.Second/tick), 1)
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	next := time.Now().Add(every)
+	resumed := st.ids > 0
+	for {
+		n := batch
+		if rate == 0 {
+			n = 100000
+		} else {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+		for range n {
+			st.add(g.New(), resumed)
+			resumed = false
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Now().After(next) {
+			if err := save(path, st); err != nil {
+				log.Printf("soak: checkpoint: %v", err)
+			}
+			stats.checkpoints.Add(1)
+			report(st)
+			next = time.Now().Add(every)
+		}
+	}
+}
+
+// state is the checkpointed progress of a soak run.
+type state struct {
+	bits                                []uint64 // Bloom filter
+	last                                kid.ID
+	ids, hits, ordering, restarts, runs uint64
+}
+
+// An overfull filter hits on nearly every ID; past maxPrinted per run,
+// possible duplicates are only counted.
+const maxPrinted = 100
+
+var printed int
+
+// add checks and records id; first marks the first ID after a resume.
+func (s *state) add(id kid.ID, first bool) {
+	if id.Compare(s.last) <= 0 {
+		if first {
+			s.restarts++
+			stats.restarts.Add(1)
+			fmt.Printf("restart regression: %v sorts before last checkpointed ID %v\n", id, s.last)
+		} else {
+			s.ordering++
+			stats.ordering.Add(1)
+			fmt.Printf("ordering violation: %v sorts before %v\n", id, s.last)
+		}
+	}
+	s.last = id
+
+	h1, h2 := hash(id)
+	m := uint64(len(s.bits)) * 64
+	present := true
+	for i := range uint64(hashes) {
+		bit := (h1 + i*h2) % m
+		if s.bits[bit/64]&(1<<(bit%64)) == 0 {
+			present = false
+			s.bits[bit/64] |= 1 << (bit % 64)
+		}
+	}
+	if present {
+		s.hits++
+		stats.hits.Add(1)
+		if printed++; printed <= maxPrinted {
+			fmt.Printf("possible duplicate: %v\n", id)
+		}
+		if printed == maxPrinted {
+			fmt.Printf("possible duplicate: further hits this run are counted, not printed\n")
+		}
+	}
+	s.ids++
+	stats.ids.Add(1)
+}
+
+// expectedFalsePositives estimates how many of the filter hits so far are
+// false positives, summing the false positive rate as the filter filled.
+func (s *state) expectedFalsePositives() float64 {
+	m, k, n := float64(len(s.bits)*64), float64(hashes), float64(s.ids)
+	// integral over inserts 0..n of (1 - e^(-k*i/m))^k, by the midpoint rule
+	const steps = 1000
+	var sum float64
+	for i := range steps {
+		x := n * (float64(i) + 0.5) / steps
+		sum += math.Pow(1-math.Exp(-k*x/m), k)
+	}
+	return sum * n / steps
+}
+
+// hash derives the two Bloom filter hashes from id with the splitmix64
+// finalizer, which unlike hash/maphash is stable across processes, as the
+// checkpoint requires.
+func hash(id kid.ID) (h1, h2 uint64) {
+	h1 = mix(binary.BigEndian.Uint64(id[:8]) ^ mix(uint64(binary.BigEndian.Uint16(id[8:]))))
+	return h1, mix(h1) | 1
+}
+
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// The state file holds a magic string, the counters, the last ID and the
+// filter, all big endian.
+const magic = "kidsoak1"
+
+func save(path string, s *state) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	w.WriteString(magic)
+	for _, v := range []uint64{s.ids, s.hits, s.ordering, s.restarts, s.runs, uint64(len(s.bits))} {
+		binary.Write(w, binary.BigEndian, v)
+	}
+	w.Write(s.last[:])
+	binary.Write(w, binary.BigEndian, s.bits)
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func load(path string) (*state, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	head := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, head); err != nil || string(head) != magic {
+		return nil, fmt.Errorf("%s: not a soak state file", path)
+	}
+	s := &state{}
+	var words uint64
+	for _, v := range []*uint64{&s.ids, &s.hits, &s.ordering, &s.restarts, &s.runs, &words} {
+		if err := binary.Read(r, binary.BigEndian, v); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if _, err := io.ReadFull(r, s.last[:]); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	s.bits = make([]uint64, words)
+	if err := binary.Read(r, binary.BigEndian, s.bits); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+func report(s *state) {
+	fmt.Printf("soak: %s IDs  possible dupes: %s (expected false positives %.1f)  ordering violations: %s  restart regressions: %s\n",
+		commas(s.ids), commas(s.hits), s.expectedFalsePositives(), commas(s.ordering), commas(s.restarts))
+}
+
+func writeMetrics(w io.Writer, s *state) {
+	for _, m := range []struct {
+		name, help string
+		value      uint64
+	}{
+		{"kid_soak_ids_total", "IDs generated, across all runs.", stats.ids.Load()},
+		{"kid_soak_possible_duplicates_total", "Bloom filter hits: possible duplicate IDs.", stats.hits.Load()},
+		{"kid_soak_ordering_violations_total", "IDs that did not sort after their predecessor within a run.", stats.ordering.Load()},
+		{"kid_soak_restart_regressions_total", "Runs whose first ID sorted before the checkpointed last ID.", stats.restarts.Load()},
+		{"kid_soak_runs_total", "Process starts recorded in the state file.", stats.runs.Load()},
+		{"kid_soak_checkpoints_total", "Checkpoints written by this run.", stats.checkpoints.Load()},
+	} {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", m.name, m.help, m.name, m.name, m.value)
+	}
+	// The filter belongs to the generating goroutine; its size is fixed.
+	fmt.Fprintf(w, "# HELP kid_soak_filter_bits Bloom filter size in bits.\n# TYPE kid_soak_filter_bits gauge\nkid_soak_filter_bits %d\n", len(s.bits)*64)
+}
+
+// commas renders n with thousands separators, e.g. 1234567 -> "1,234,567".
+func commas(n uint64) string {
+	s := strconv.FormatUint(n, 10)
+	if len(s) <= 3 {
+		return s
+	}
+	var b []byte
+	for i := range len(s) {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b = append(b, ',')
+		}
+		b = append(b, s[i])
+	}
+	return string(b)
+}
@@ -0,0 +1,11 @@
+// Package tinygo checks that kid builds and behaves in TinyGo builds on
+// WASI, without CI:
+//
+//	tinygo test -target=wasip1 ./eval/tinygo
+//
+// The tests use only the standard library and the parts of kid available in
+// TinyGo builds. TinyGo has not yet been run on them; they pass under go
+// test, and with the gc toolchain for the same build configuration:
+//
+//	GOOS=wasip1 GOARCH=wasm go test -tags tinygo -exec <wasi runner> ./eval/tinygo
+package tinygo
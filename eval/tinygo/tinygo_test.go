@@ -0,0 +1,58 @@
+package tinygo
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mwyvr/kid"
+)
+
+func TestGenerate(t *testing.T) {
+	prev := kid.New()
+	for range 10000 {
+		id := kid.New()
+		if id.Compare(prev) <= 0 {
+			t.Fatalf("%v does not sort after %v", id, prev)
+		}
+		prev = id
+	}
+	if d := time.Since(prev.Time()); d < -time.Second || d > time.Minute {
+		t.Errorf("Time() is %v from now", d)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	const s = "06bqer9xnm79tfnl"
+	id, err := kid.FromString(s)
+	if err != nil || id.String() != s {
+		t.Fatalf("FromString(%s) = %v, %v", s, id, err)
+	}
+	if id.Timestamp() != 1741456227757 || id.Sequence() != 3741 || id.Random() != 15027 {
+		t.Errorf("components = %d, %d, %d", id.Timestamp(), id.Sequence(), id.Random())
+	}
+	if _, err := kid.FromString("06bqer9xnm79tfna"); err == nil {
+		t.Error("FromString accepted a character outside the alphabet")
+	}
+	b, _ := id.MarshalJSON()
+	var back kid.ID
+	if err := back.UnmarshalJSON(b); err != nil || back != id {
+		t.Errorf("JSON round trip = %v, %v", back, err)
+	}
+	if u, err := kid.FromULID(id.ULID()); err != nil || u != id {
+		t.Errorf("ULID round trip = %v, %v", u, err)
+	}
+	if got := kid.New64().String(); len(got) != 13 {
+		t.Errorf("New64().String() = %q", got)
+	}
+}
+
+// TestEntropyHook checks that a board's entropy source can replace the
+// runtime-seeded default.
+func TestEntropyHook(t *testing.T) {
+	src := bytes.NewReader([]byte{0xca, 0xfe})
+	g := kid.NewGenerator(kid.WithRandom(src))
+	if id := g.New(); id.Random() != 0xcafe {
+		t.Errorf("Random() = %#x, want 0xcafe", id.Random())
+	}
+}
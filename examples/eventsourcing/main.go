@@ -0,0 +1,276 @@
+// Command eventsourcing is a reference program showing one kid.ID flowing
+// through every layer of an event-sourced service:
+//
+//   - as the primary key of an events table (see schema; k-sorted keys keep
+//     inserts at the right edge of the index, and ORDER BY id is time order),
+//   - as the key of a message on a partitioned log, so all events for one
+//     aggregate land, in order, on one partition,
+//   - as a JSON API field, in its 16-character string form, and
+//   - as a request correlation ID, returned in X-Request-ID and attached to
+//     every log line for the request.
+//
+// To stay runnable with the standard library alone, the database and the
+// log are in-memory stand-ins behind small interfaces: sqlStore shows the
+// database/sql equivalent of memStore (link a driver such as pgx's stdlib
+// package and pass -dsn), and Producer is the shape of a Kafka producer,
+// where partitionFor would be the message key's partitioner.
+//
+// Usage:
+//
+//	$ go run ./examples/eventsourcing
+//	$ curl -s -d '{"type":"order.placed","data":{"sku":"A1"}}' localhost:8080/events
+//	{"id":"06hm9pgdwh5ghk8m","aggregate":"06hm9pgdwh5ghk8m","type":"order.placed",...}
+//	$ curl -s localhost:8080/aggregates/06hm9pgdwh5ghk8m/events
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"hash/fnv"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidhttp"
+)
+
+// schema is the Postgres table behind sqlStore. Encoded IDs sort like
+// binary ones, so a char(16) key (or bytea, storing id.Bytes()) orders by
+// creation time.
+const schema = `CREATE TABLE IF NOT EXISTS events (
+	id        char(16) PRIMARY KEY,
+	aggregate char(16) NOT NULL,
+	type      text NOT NULL,
+	data      jsonb NOT NULL
+)`
+
+// Event is an immutable fact about an aggregate. Its ID records when it
+// happened; the aggregate's ID is that of its first event.
+type Event struct {
+	ID        kid.ID          `json:"id"`
+	Aggregate kid.ID          `json:"aggregate"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	Time      time.Time       `json:"time"` // derived from ID, for readers
+}
+
+// Store persists events.
+type Store interface {
+	Append(ctx context.Context, e Event) error
+	// Events returns an aggregate's events in the order they happened.
+	Events(ctx context.Context, aggregate kid.ID) ([]Event, error)
+}
+
+// Producer publishes messages to a partitioned log, like a Kafka producer.
+type Producer interface {
+	Publish(ctx context.Context, key kid.ID, value []byte) error
+}
+
+// partitionFor maps a message key to one of n partitions. Keying messages by
+// aggregate ID sends an aggregate's events to one partition, preserving
+// their order for consumers.
+func partitionFor(key kid.ID, n int) int {
+	h := fnv.New32a()
+	h.Write(key.Bytes())
+	return int(h.Sum32() % uint32(n)) //nolint:gosec
+}
+
+// memStore is an in-memory Store.
+type memStore struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *memStore) Append(_ context.Context, e Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+	return nil
+}
+
+func (s *memStore) Events(_ context.Context, aggregate kid.ID) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Event
+	for _, e := range s.events {
+		if e.Aggregate == aggregate {
+			out = append(out, e)
+		}
+	}
+	slices.SortFunc(out, func(a, b Event) int { return a.ID.Compare(b.ID) })
+	return out, nil
+}
+
+// sqlStore is a Store over database/sql and schema. kid.ID implements
+// driver.Valuer and sql.Scanner, so IDs are passed and scanned directly.
+type sqlStore struct {
+	db *sql.DB
+}
+
+func (s *sqlStore) Append(ctx context.Context, e Event) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO events (id, aggregate, type, data) VALUES ($1, $2, $3, $4)`,
+		e.ID, e.Aggregate, e.Type, []byte(e.Data))
+	return err
+}
+
+func (s *sqlStore) Events(ctx context.Context, aggregate kid.ID) ([]Event, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, aggregate, type, data FROM events WHERE aggregate = $1 ORDER BY id`, aggregate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Event
+	for rows.Next() {
+		var e Event
+		if err := rows.Scan(&e.ID, &e.Aggregate, &e.Type, &e.Data); err != nil {
+			return nil, err
+		}
+		e.Time = e.ID.Time()
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// memLog is an in-memory Producer with a fixed number of partitions.
+type memLog struct {
+	mu         sync.Mutex
+	partitions [][]kid.ID // message keys, per partition
+}
+
+func newMemLog(n int) *memLog {
+	return &memLog{partitions: make([][]kid.ID, n)}
+}
+
+func (l *memLog) Publish(_ context.Context, key kid.ID, _ []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	p := partitionFor(key, len(l.partitions))
+	l.partitions[p] = append(l.partitions[p], key)
+	return nil
+}
+
+// server is the JSON API.
+type server struct {
+	store Store
+	log   Producer
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /events", s.appendEvent)
+	mux.HandleFunc("GET /aggregates/{id}/events", s.aggregateEvents)
+	return correlate(mux)
+}
+
+// correlate gives each request a correlation ID, from X-Request-ID if the
+// caller supplied a valid one, otherwise new, echoes it in the response (see
+// kidhttp) and attaches it to the request's logger.
+func correlate(next http.Handler) http.Handler {
+	return kidhttp.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rid, _ := kidhttp.FromContext(r.Context())
+		logger := slog.Default().With("request_id", rid)
+		logger.Info("request", "method", r.Method, "path", r.URL.Path)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), loggerKey{}, logger)))
+	}))
+}
+
+type loggerKey struct{}
+
+func logger(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+// appendEvent records an event. Without an aggregate, the event starts a
+// new aggregate identified by the event's own ID.
+func (s *server) appendEvent(w http.ResponseWriter, r *http.Request) {
+	var e Event
+	if err := json.NewDecoder(r.Body).Decode(&e); err != nil || e.Type == "" {
+		http.Error(w, "want a JSON event with a type", http.StatusBadRequest)
+		return
+	}
+	e.ID = kid.New()
+	e.Time = e.ID.Time()
+	if e.Aggregate.IsNil() {
+		e.Aggregate = e.ID
+	}
+	if e.Data == nil {
+		e.Data = json.RawMessage("{}")
+	}
+	ctx := r.Context()
+	if err := s.store.Append(ctx, e); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	msg, _ := json.Marshal(e)
+	if err := s.log.Publish(ctx, e.Aggregate, msg); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	logger(ctx).Info("event appended", "event_id", e.ID, "aggregate", e.Aggregate, "type", e.Type)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(msg)
+}
+
+func (s *server) aggregateEvents(w http.ResponseWriter, r *http.Request) {
+	id, err := kid.FromString(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	events, err := s.store.Events(r.Context(), id)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if len(events) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(events)
+}
+
+func (s *server) fail(w http.ResponseWriter, r *http.Request, err error) {
+	logger(r.Context()).Error("request failed", "err", err)
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
+
+func main() {
+	addr := "localhost:8080"
+	driver, dsn := "pgx", ""
+	flag.StringVar(&addr, "addr", addr, "Listen address")
+	flag.StringVar(&driver, "driver", driver, "database/sql driver name, used with -dsn")
+	flag.StringVar(&dsn, "dsn", dsn, "Database to store events in (default: in memory); the driver must be linked in")
+	flag.Parse()
+
+	var store Store = &memStore{}
+	if dsn != "" {
+		db, err := sql.Open(driver, dsn)
+		if err == nil {
+			_, err = db.Exec(schema)
+		}
+		if err != nil {
+			log.Fatal(errors.Join(errors.New("eventsourcing: opening database"), err))
+		}
+		store = &sqlStore{db: db}
+	}
+
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, nil)))
+	s := &server{store: store, log: newMemLog(8)}
+	slog.Info("listening", "addr", addr)
+	log.Fatal(http.ListenAndServe(addr, s.routes())) //nolint:gosec
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mwyvr/kid"
+)
+
+func post(t *testing.T, h http.Handler, body string) (Event, *httptest.ResponseRecorder) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/events", strings.NewReader(body)))
+	var e Event
+	if w.Code == http.StatusCreated {
+		if err := json.Unmarshal(w.Body.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return e, w
+}
+
+func TestEventFlow(t *testing.T) {
+	var logs bytes.Buffer
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	mlog := newMemLog(8)
+	h := (&server{store: &memStore{}, log: mlog}).routes()
+
+	first, w := post(t, h, `{"type":"order.placed","data":{"sku":"A1"}}`)
+	if w.Code != http.StatusCreated || first.Aggregate != first.ID {
+		t.Fatalf("POST = %d %s, want 201 starting a new aggregate", w.Code, w.Body)
+	}
+	second, _ := post(t, h, `{"aggregate":"`+first.ID.String()+`","type":"order.shipped"}`)
+	if second.Aggregate != first.ID || second.ID.Compare(first.ID) <= 0 {
+		t.Errorf("second event = %+v, want aggregate %v and an ID after it", second, first.ID)
+	}
+
+	// the API returns the aggregate's events in order
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/aggregates/"+first.ID.String()+"/events", nil))
+	var events []Event
+	if err := json.Unmarshal(w.Body.Bytes(), &events); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].ID != first.ID || events[1].ID != second.ID {
+		t.Errorf("GET events = %s, want [%v %v]", w.Body, first.ID, second.ID)
+	}
+
+	// both messages are keyed by the aggregate, on its partition
+	p := partitionFor(first.ID, 8)
+	if got := mlog.partitions[p]; len(got) != 2 || got[0] != first.ID || got[1] != first.ID {
+		t.Errorf("partition %d = %v, want the aggregate's two messages", p, got)
+	}
+
+	// log lines carry the request's correlation ID
+	rid := w.Header().Get("X-Request-ID")
+	if _, err := kid.FromString(rid); err != nil {
+		t.Fatalf("X-Request-ID = %q: %v", rid, err)
+	}
+	if !strings.Contains(logs.String(), `"request_id":"`+rid+`"`) {
+		t.Errorf("logs do not mention request_id %s:\n%s", rid, logs.String())
+	}
+}
+
+func TestCorrelationIDPropagates(t *testing.T) {
+	h := (&server{store: &memStore{}, log: newMemLog(1)}).routes()
+	rid := kid.New().String()
+	r := httptest.NewRequest("GET", "/aggregates/"+kid.New().String()+"/events", nil)
+	r.Header.Set("X-Request-ID", rid)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNotFound || w.Header().Get("X-Request-ID") != rid {
+		t.Errorf("GET = %d, X-Request-ID %q; want 404, %q", w.Code, w.Header().Get("X-Request-ID"), rid)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	h := (&server{store: &memStore{}, log: newMemLog(1)}).routes()
+	if _, w := post(t, h, `{"data":{}}`); w.Code != http.StatusBadRequest {
+		t.Errorf("POST without a type = %d, want 400", w.Code)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/aggregates/not-an-id/events", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("GET with a bad ID = %d, want 400", w.Code)
+	}
+}
+
+func TestPartitionFor(t *testing.T) {
+	counts := make([]int, 8)
+	for range 8000 {
+		counts[partitionFor(kid.New(), 8)]++
+	}
+	for p, n := range counts {
+		if n < 800 || n > 1200 {
+			t.Errorf("partition %d got %d of 8000 keys, want about 1000", p, n)
+		}
+	}
+}
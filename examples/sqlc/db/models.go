@@ -0,0 +1,13 @@
+package db
+
+import (
+	"github.com/mwyvr/kid"
+)
+
+type Order struct {
+	ID         kid.ID
+	CustomerID kid.ID
+	ParentID   *kid.ID
+	ShipmentID kid.NullID
+	Total      int64
+}
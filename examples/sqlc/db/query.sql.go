@@ -0,0 +1,101 @@
+// source: query.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/mwyvr/kid"
+)
+
+const createOrder = `-- name: CreateOrder :exec
+INSERT INTO orders (id, customer_id, parent_id, shipment_id, total)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateOrderParams struct {
+	ID         kid.ID
+	CustomerID kid.ID
+	ParentID   *kid.ID
+	ShipmentID kid.NullID
+	Total      int64
+}
+
+func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) error {
+	_, err := q.db.ExecContext(ctx, createOrder,
+		arg.ID,
+		arg.CustomerID,
+		arg.ParentID,
+		arg.ShipmentID,
+		arg.Total,
+	)
+	return err
+}
+
+const getOrder = `-- name: GetOrder :one
+SELECT id, customer_id, parent_id, shipment_id, total FROM orders
+WHERE id = ?
+`
+
+func (q *Queries) GetOrder(ctx context.Context, id kid.ID) (Order, error) {
+	row := q.db.QueryRowContext(ctx, getOrder, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.CustomerID,
+		&i.ParentID,
+		&i.ShipmentID,
+		&i.Total,
+	)
+	return i, err
+}
+
+const listCustomerOrders = `-- name: ListCustomerOrders :many
+SELECT id, customer_id, parent_id, shipment_id, total FROM orders
+WHERE customer_id = ?
+ORDER BY id
+`
+
+func (q *Queries) ListCustomerOrders(ctx context.Context, customerID kid.ID) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listCustomerOrders, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.CustomerID,
+			&i.ParentID,
+			&i.ShipmentID,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const shipOrder = `-- name: ShipOrder :exec
+UPDATE orders SET shipment_id = ?
+WHERE id = ?
+`
+
+type ShipOrderParams struct {
+	ShipmentID kid.NullID
+	ID         kid.ID
+}
+
+func (q *Queries) ShipOrder(ctx context.Context, arg ShipOrderParams) error {
+	_, err := q.db.ExecContext(ctx, shipOrder, arg.ShipmentID, arg.ID)
+	return err
+}
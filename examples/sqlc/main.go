@@ -0,0 +1,84 @@
+// Command sqlc shows kid.IDs in code generated by sqlc
+// (https://sqlc.dev), against SQLite. sqlc.yaml maps the ID columns of
+// schema.sql to kid types with overrides alone:
+//
+//   - NOT NULL columns to kid.ID,
+//   - nullable columns to *kid.ID (pointer: true), or to kid.NullID.
+//
+// kid.ID's driver.Valuer and sql.Scanner cover all three, so no adapter
+// types are needed; package db is sqlc's output, and regenerates with
+// `sqlc generate`.
+//
+// Usage:
+//
+//	$ cd examples/sqlc && go run .
+package main
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"log"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/examples/sqlc/db"
+)
+
+//go:embed schema.sql
+var schema string
+
+// open returns a migrated in-memory database.
+func open() (*sql.DB, error) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	conn.SetMaxOpenConns(1) // each connection would be a new database
+	if _, err := conn.Exec(schema); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func main() {
+	conn, err := open()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	q := db.New(conn)
+
+	customer := kid.New()
+	first := db.CreateOrderParams{ID: kid.New(), CustomerID: customer, Total: 1200}
+	// a follow-up order refers to the first; neither has shipped
+	second := db.CreateOrderParams{ID: kid.New(), CustomerID: customer, ParentID: &first.ID, Total: 300}
+	for _, o := range []db.CreateOrderParams{first, second} {
+		if err := q.CreateOrder(ctx, o); err != nil {
+			log.Fatal(err)
+		}
+	}
+	err = q.ShipOrder(ctx, db.ShipOrderParams{ID: first.ID, ShipmentID: kid.NullID{ID: kid.New(), Valid: true}})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	orders, err := q.ListCustomerOrders(ctx, customer)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, o := range orders {
+		parent, shipment := "-", "-"
+		if o.ParentID != nil {
+			parent = o.ParentID.String()
+		}
+		if o.ShipmentID.Valid {
+			shipment = o.ShipmentID.ID.String()
+		}
+		fmt.Printf("order %s placed %s parent %s shipment %s total %d\n",
+			o.ID, o.ID.Time().Format("15:04:05.000"), parent, shipment, o.Total)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/examples/sqlc/db"
+)
+
+func TestGeneratedQueries(t *testing.T) {
+	conn, err := open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+	q := db.New(conn)
+
+	customer := kid.New()
+	parent := db.CreateOrderParams{ID: kid.New(), CustomerID: customer, Total: 1}
+	child := db.CreateOrderParams{ID: kid.New(), CustomerID: customer, ParentID: &parent.ID, Total: 2}
+	for _, o := range []db.CreateOrderParams{child, parent} { // out of order
+		if err := q.CreateOrder(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+	shipment := kid.NullID{ID: kid.New(), Valid: true}
+	if err := q.ShipOrder(ctx, db.ShipOrderParams{ID: child.ID, ShipmentID: shipment}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := q.GetOrder(ctx, child.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != child.ID || got.CustomerID != customer || got.ParentID == nil || *got.ParentID != parent.ID || got.ShipmentID != shipment {
+		t.Errorf("GetOrder = %+v, want %+v shipped as %v", got, child, shipment)
+	}
+	got, err = q.GetOrder(ctx, parent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ParentID != nil || got.ShipmentID.Valid {
+		t.Errorf("GetOrder = %+v, want NULL parent and shipment", got)
+	}
+
+	// ORDER BY id is creation order
+	orders, err := q.ListCustomerOrders(ctx, customer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 2 || orders[0].ID != parent.ID || orders[1].ID != child.ID {
+		t.Errorf("ListCustomerOrders = %+v, want [%v %v]", orders, parent.ID, child.ID)
+	}
+
+	// IDs are stored as their 16-character strings
+	var stored string
+	if err := conn.QueryRow("SELECT id FROM orders WHERE total = 2").Scan(&stored); err != nil || stored != child.ID.String() {
+		t.Errorf("stored id = %q, %v, want %q", stored, err, child.ID.String())
+	}
+
+	if _, err := q.GetOrder(ctx, kid.New()); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetOrder(unknown) = %v, want sql.ErrNoRows", err)
+	}
+}
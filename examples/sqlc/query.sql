@@ -0,0 +1,16 @@
+-- name: CreateOrder :exec
+INSERT INTO orders (id, customer_id, parent_id, shipment_id, total)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: GetOrder :one
+SELECT * FROM orders
+WHERE id = ?;
+
+-- name: ListCustomerOrders :many
+SELECT * FROM orders
+WHERE customer_id = ?
+ORDER BY id;
+
+-- name: ShipOrder :exec
+UPDATE orders SET shipment_id = ?
+WHERE id = ?;
@@ -0,0 +1,7 @@
+CREATE TABLE orders (
+  id          CHAR(16) PRIMARY KEY,
+  customer_id CHAR(16) NOT NULL,
+  parent_id   CHAR(16),
+  shipment_id CHAR(16),
+  total       INTEGER NOT NULL
+);
@@ -0,0 +1,11 @@
+module github.com/mwyvr/kid/examples/urlshort
+
+go 1.23.0
+
+require (
+	github.com/mattn/go-sqlite3 v1.14.22
+	github.com/mwyvr/kid v0.0.0
+)
+
+// The example builds against the enclosing tree.
+replace github.com/mwyvr/kid => ../../
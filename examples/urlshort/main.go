@@ -0,0 +1,214 @@
+// Command urlshort is a URL shortener showing kid end to end: an ID is
+// the primary key of a SQLite table, the short code of a link, a field of
+// a JSON API, a keyset pagination cursor and a request correlation ID.
+//
+//   - POST /links stores {"url": ...} under a new ID and returns the link.
+//   - GET /{id} redirects to the link's URL.
+//   - GET /links?after=<id>&limit=<n> lists links oldest first, a page at
+//     a time; each page's "next" is the cursor for the following one.
+//
+// IDs sort by creation time, so ORDER BY id is creation order and the last
+// ID of a page is a complete cursor: the next page starts at
+// cursor.Next(), the smallest ID greater than it. kidhttp.Middleware gives
+// every request an ID, returned in X-Request-ID and logged with it.
+//
+// Usage:
+//
+//	$ cd examples/urlshort && go run .
+//	$ curl -s -d '{"url":"https://go.dev"}' localhost:8080/links
+//	{"id":"06hm9pgdwh5ghk8m","url":"https://go.dev","created":"..."}
+//	$ curl -si localhost:8080/06hm9pgdwh5ghk8m
+//	$ curl -s 'localhost:8080/links?limit=10'
+package main
+
+import (
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidhttp"
+)
+
+//go:embed schema.sql
+var schema string
+
+// maxLimit caps the page size a client may ask for.
+const maxLimit = 100
+
+// open returns a migrated database; an empty dsn is in memory.
+func open(dsn string) (*sql.DB, error) {
+	if dsn == "" {
+		dsn = ":memory:"
+	}
+	conn, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetMaxOpenConns(1) // each :memory: connection would be a new database
+	if _, err := conn.Exec(schema); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Link is a shortened URL, as stored and as served.
+type Link struct {
+	ID      kid.ID    `json:"id"`
+	URL     string    `json:"url"`
+	Created time.Time `json:"created"`
+}
+
+// Page is one page of GET /links. Next is the cursor for the following
+// page, absent on the last.
+type Page struct {
+	Links []Link  `json:"links"`
+	Next  *kid.ID `json:"next,omitempty"`
+}
+
+type server struct {
+	db *sql.DB
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /links", s.shorten)
+	mux.HandleFunc("GET /links", s.list)
+	mux.HandleFunc("GET /{id}", s.redirect)
+	return kidhttp.Middleware(logRequests(mux))
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rid, _ := kidhttp.FromContext(r.Context())
+		slog.Info("request", "request_id", rid, "method", r.Method, "path", r.URL.Path)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *server) shorten(w http.ResponseWriter, r *http.Request) {
+	var in struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, "want a JSON object with a url", http.StatusBadRequest)
+		return
+	}
+	if u, err := url.Parse(in.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		http.Error(w, "want an absolute http or https url", http.StatusBadRequest)
+		return
+	}
+	l := Link{ID: kid.New(), URL: in.URL}
+	l.Created = l.ID.Time()
+	if _, err := s.db.ExecContext(r.Context(), "INSERT INTO links (id, url) VALUES (?, ?)", l.ID, l.URL); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/"+l.ID.String())
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(l)
+}
+
+func (s *server) redirect(w http.ResponseWriter, r *http.Request) {
+	id, err := kid.FromString(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	var target string
+	err = s.db.QueryRowContext(r.Context(), "SELECT url FROM links WHERE id = ?", id).Scan(&target)
+	if err == sql.ErrNoRows {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+func (s *server) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := 20
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxLimit {
+			http.Error(w, "limit must be 1 to "+strconv.Itoa(maxLimit), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	// The first page starts at the smallest ID, later ones just past the
+	// cursor: "id >= cursor.Next()" selects the rows of "id > cursor". Nil
+	// is bound as its string, as its Value would be NULL.
+	from := kid.Nil
+	if v := q.Get("after"); v != "" {
+		after, err := kid.FromString(v)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		from = after.Next()
+	}
+	// One more than a page tells whether there is a next page.
+	rows, err := s.db.QueryContext(r.Context(),
+		"SELECT id, url FROM links WHERE id >= ? ORDER BY id LIMIT ?", from.String(), limit+1)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	defer rows.Close()
+	page := Page{Links: []Link{}}
+	for rows.Next() {
+		var l Link
+		if err := rows.Scan(&l.ID, &l.URL); err != nil {
+			s.fail(w, r, err)
+			return
+		}
+		l.Created = l.ID.Time()
+		page.Links = append(page.Links, l)
+	}
+	if err := rows.Err(); err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	if len(page.Links) > limit {
+		page.Links = page.Links[:limit]
+		page.Next = &page.Links[limit-1].ID
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (s *server) fail(w http.ResponseWriter, r *http.Request, err error) {
+	rid, _ := kidhttp.FromContext(r.Context())
+	slog.Error("request failed", "request_id", rid, "err", err)
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
+
+func main() {
+	addr, dsn := "localhost:8080", ""
+	flag.StringVar(&addr, "addr", addr, "Listen address")
+	flag.StringVar(&dsn, "db", dsn, "SQLite database file (default: in memory)")
+	flag.Parse()
+
+	conn, err := open(dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+	s := &server{db: conn}
+	slog.Info("listening", "addr", addr)
+	log.Fatal(http.ListenAndServe(addr, s.handler()))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidhttp"
+)
+
+func newServer(t *testing.T) http.Handler {
+	t.Helper()
+	conn, err := open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return (&server{db: conn}).handler()
+}
+
+func do(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+func TestShortenAndRedirect(t *testing.T) {
+	h := newServer(t)
+	rec := do(h, "POST", "/links", `{"url":"https://go.dev/doc"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /links = %d %s, want 201", rec.Code, rec.Body)
+	}
+	if _, err := kid.FromString(rec.Header().Get(kidhttp.Header)); err != nil {
+		t.Errorf("%s = %q, want a request ID", kidhttp.Header, rec.Header().Get(kidhttp.Header))
+	}
+	var l Link
+	if err := json.NewDecoder(rec.Body).Decode(&l); err != nil {
+		t.Fatal(err)
+	}
+	if l.ID.IsNil() || l.URL != "https://go.dev/doc" || !l.Created.Equal(l.ID.Time()) {
+		t.Errorf("POST /links = %+v", l)
+	}
+
+	rec = do(h, "GET", "/"+l.ID.String(), "")
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != l.URL {
+		t.Errorf("GET /%s = %d to %q, want 302 to %q", l.ID, rec.Code, rec.Header().Get("Location"), l.URL)
+	}
+	for _, path := range []string{"/" + kid.New().String(), "/not-an-id"} {
+		if rec := do(h, "GET", path, ""); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", path, rec.Code)
+		}
+	}
+	for _, body := range []string{``, `{}`, `{"url":"go.dev"}`, `{"url":"ftp://go.dev"}`} {
+		if rec := do(h, "POST", "/links", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("POST /links %s = %d, want 400", body, rec.Code)
+		}
+	}
+}
+
+func TestListPages(t *testing.T) {
+	h := newServer(t)
+	var want []kid.ID
+	for i := 0; i < 7; i++ {
+		rec := do(h, "POST", "/links", `{"url":"https://example.com/`+string(rune('a'+i))+`"}`)
+		var l Link
+		if err := json.NewDecoder(rec.Body).Decode(&l); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, l.ID)
+	}
+
+	var got []kid.ID
+	pages := 0
+	for target := "/links?limit=3"; ; pages++ {
+		rec := do(h, "GET", target, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s = %d %s", target, rec.Code, rec.Body)
+		}
+		var p Page
+		if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range p.Links {
+			got = append(got, l.ID)
+		}
+		if p.Next == nil {
+			break
+		}
+		target = "/links?limit=3&after=" + p.Next.String()
+	}
+	if pages != 2 || len(got) != len(want) {
+		t.Fatalf("listed %d links in %d pages, want %d in 3", len(got), pages+1, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("link %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	for _, q := range []string{"limit=0", "limit=101", "limit=x", "after=bad"} {
+		if rec := do(h, "GET", "/links?"+q, ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("GET /links?%s = %d, want 400", q, rec.Code)
+		}
+	}
+}
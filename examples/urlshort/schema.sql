@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS links (
+  id  CHAR(16) PRIMARY KEY,
+  url TEXT NOT NULL
+);
@@ -2,6 +2,7 @@ package kid
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 )
 
@@ -17,7 +18,7 @@ func FuzzFromString(f *testing.F) {
 	f.Fuzz(func(t *testing.T, s string) {
 		id, err := FromString(s)
 		if err != nil {
-			if id != nilID {
+			if id != Nil {
 				t.Fatalf("FromString(%q) errored but returned non-nil ID %v", s, id)
 			}
 			return
@@ -39,14 +40,14 @@ func FuzzUnmarshalJSON(f *testing.F) {
 	f.Fuzz(func(t *testing.T, b []byte) {
 		var id ID
 		if err := id.UnmarshalJSON(b); err != nil {
-			if id != nilID {
+			if id != Nil {
 				t.Fatalf("UnmarshalJSON(%q) errored but left non-nil ID %v", b, id)
 			}
 			return
 		}
 		if string(b) == "null" {
-			if id != nilID {
-				t.Fatalf("UnmarshalJSON(null) = %v, want nilID", id)
+			if id != Nil {
+				t.Fatalf("UnmarshalJSON(null) = %v, want Nil", id)
 			}
 			return
 		}
@@ -54,7 +55,7 @@ func FuzzUnmarshalJSON(f *testing.F) {
 			t.Fatalf("UnmarshalJSON accepted non-string JSON: %q", b)
 		}
 		// the nil ID marshals to null (asymmetric by design); skip roundtrip
-		if id == nilID {
+		if id == Nil {
 			return
 		}
 		got, err := id.MarshalJSON()
@@ -90,3 +91,47 @@ func FuzzFromBytes(f *testing.F) {
 		}
 	})
 }
+
+// FuzzDecodeEncodeRoundTrip checks the claim on decode that every 16
+// characters of the alphabet are the canonical encoding of exactly one ID:
+// decodePairs and decode agree on any such input, re-encoding it yields the
+// input, and any 10 bytes encode to a string that every decoder maps back.
+func FuzzDecodeEncodeRoundTrip(f *testing.F) {
+	f.Add([]byte("06bqer9xnm79tfnl"))
+	f.Add([]byte("zzzzzzzzzzzzzzzz"))
+	f.Add([]byte("06bqer9xnm79tfn\x00"))
+	f.Add(bytes.Repeat([]byte{0xff}, rawLen))
+	f.Add([]byte{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		if len(b) == encodedLen {
+			var pairs, chars ID
+			if decodePairs(&pairs, b) != IsValid(string(b)) {
+				t.Fatalf("decodePairs(%q) and IsValid disagree", b)
+			}
+			if IsValid(string(b)) {
+				decode(&chars, b)
+				if pairs != chars {
+					t.Fatalf("decodePairs(%q) = %v, decode = %v", b, pairs, chars)
+				}
+				if s := pairs.String(); s != string(b) {
+					t.Fatalf("%q decodes to %v, which encodes as %q", b, pairs, s)
+				}
+			}
+		}
+		if len(b) < rawLen {
+			return
+		}
+		id := ID(b[:rawLen])
+		s := id.String()
+		if back, err := FromString(s); err != nil || back != id {
+			t.Fatalf("FromString(%q) = %v, %v, want %v", s, back, err, id)
+		}
+		if back, err := FromStringLoose(strings.ToUpper(s)); err != nil || back != id {
+			t.Fatalf("FromStringLoose(%q) = %v, %v, want %v", strings.ToUpper(s), back, err, id)
+		}
+		var back ID
+		if err := back.UnmarshalJSON(id.AppendJSON(nil)); err != nil || back != id {
+			t.Fatalf("UnmarshalJSON(%s) = %v, %v, want %v", id.AppendJSON(nil), back, err, id)
+		}
+	})
+}
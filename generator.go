@@ -0,0 +1,465 @@
+package kid
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	mrand "math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Generator produces IDs from its own timestamp+sequence state. The
+// package-level New draws from a default Generator; applications needing
+// independent ID streams, a custom clock or a custom random source can
+// create their own with NewGenerator.
+//
+// The uniqueness and ordering guarantees of New hold per Generator. IDs from
+// different Generators are not coordinated: like IDs from different
+// processes, two Generators can claim the same timestamp+sequence, leaving
+// the two random bytes as the only separation.
+//
+// A Generator is goroutine-safe and must not be copied after first use.
+type Generator struct {
+	// lastTime is the last ts+seq we returned stored as:
+	//
+	//	52 bits of time in milliseconds since epoch
+	//	12 bits of (fractional nanoseconds) >> 8
+	lastTime atomic.Int64
+	now      func() time.Time
+	epoch    int64 // Unix nanoseconds of timestamp zero
+
+	// lastDated is the last ts+seq returned by NewWithTime, in the same
+	// layout as lastTime.
+	lastDated atomic.Int64
+
+	// fixed replaces the random bytes with tail; see WithNoRandom and
+	// WithNode.
+	fixed bool
+	tail  [2]byte
+
+	// store persists ceiling, a ts+seq at or above every value lastTime
+	// has issued; see WithState.
+	store   StateStore
+	smu     sync.Mutex // serializes store writes
+	ceiling atomic.Int64
+
+	// Clock regression handling; see WithRegressionPolicy. watch is set if
+	// either, or an Observer, is configured, enabling the checks in
+	// Generate.
+	policy     RegressionPolicy
+	onRegress  func(behind time.Duration)
+	watch      bool
+	maxClock   atomic.Int64 // latest clock reading, epoch-relative nanoseconds
+	regressing atomic.Bool  // clock currently behind maxClock
+
+	maxLead time.Duration // see WithMaxLead
+
+	// shards replaces lastTime if there is more than one; see WithShards.
+	nshards   int
+	shards    []genShard
+	shardBits uint // log2(len(shards))
+
+	obs  Observer      // see WithObserver; nil if unset
+	obsN BatchObserver // obs, if it is one
+
+	audit io.Writer  // see WithAudit; nil if unset
+	amu   sync.Mutex // serializes audit writes
+
+	mu     sync.Mutex  // guards rand, rbuf and pool
+	rand   io.Reader   // nil: math/rand/v2's ChaCha8
+	custom atomic.Bool // rand != nil, for the unlocked fast path
+	rbuf   [8]byte
+
+	// pool holds bytes read ahead from rand, pool[rpos:rend] unused; nil
+	// until the first read. unbuffered disables it; see
+	// WithUnbufferedRandom.
+	pool       []byte
+	rpos, rend int
+	unbuffered bool
+}
+
+// randPoolSize is how many bytes a Generator reads ahead from its random
+// source (WithRandom) at a time: 2048 IDs' worth.
+const randPoolSize = 4096
+
+// std is the Generator behind the package-level New.
+var std = NewGenerator()
+
+// SetDefaultRandom sets the random source of the default Generator, behind
+// the package-level New, as WithRandom does for a Generator of its own. It
+// is the entropy hook for targets where math/rand/v2's runtime seed may be
+// weak, such as TinyGo targets without a good system entropy source: pass
+// a better one, from init or early in main. It is safe to
+// call while IDs are being generated: each takes its random bytes from
+// either the old source or r. A nil r restores the default.
+func SetDefaultRandom(r io.Reader) {
+	std.mu.Lock()
+	std.rand, std.rpos, std.rend = r, 0, 0 // drop bytes read ahead from the old source
+	std.custom.Store(r != nil)
+	std.mu.Unlock()
+}
+
+// WithClock sets the clock a Generator reads timestamps from, time.Now by
+// default. A clock that stands still or steps backwards is tolerated: the
+// sequence advances instead, exactly as for wall clock regressions (see
+// WithRegressionPolicy for alternatives). kidtest.FixedClock provides a
+// frozen clock for tests.
+func WithClock(now func() time.Time) Option[Generator] {
+	return func(g *Generator) {
+		g.now = now
+	}
+}
+
+// WithRandom sets the source of the two random bytes of each ID (and the
+// random bytes of ID64 and ID128 values), replacing
+// math/rand/v2's ChaCha8 generator; crypto/rand.Reader, a hardware RNG or a
+// seeded deterministic reader for reproducible tests all qualify. Reads are
+// serialized by the Generator, so r need not be goroutine-safe, but each ID
+// then costs a mutex acquisition. Reads are buffered, 4 KiB at a time, so
+// the cost of a Read call, a system call for crypto/rand.Reader, is shared
+// by thousands of IDs; see WithUnbufferedRandom to turn this off.
+//
+// New panics if r returns an error; Generate returns it.
+func WithRandom(r io.Reader) Option[Generator] {
+	return func(g *Generator) {
+		g.rand = r
+		g.custom.Store(r != nil)
+	}
+}
+
+// WithUnbufferedRandom makes a Generator read the random bytes of each ID
+// from its random source (WithRandom) as the ID is generated, rather than
+// from a read-ahead buffer: random bytes are then never held in memory
+// before use, and a source whose output changes over time, such as one
+// reseeded from outside, takes effect with the next ID. Each ID costs a Read
+// call.
+func WithUnbufferedRandom() Option[Generator] {
+	return func(g *Generator) {
+		g.unbuffered = true
+	}
+}
+
+// WithNoRandom leaves the two random bytes of every ID zero, so IDs carry
+// only timestamp+sequence: with a fixed clock (WithClock), output is fully
+// deterministic, easing replay and debugging.
+//
+// Such IDs are unique only among those issued by this Generator. Without
+// random bytes nothing separates them from IDs of another Generator,
+// process or host that claims the same timestamp+sequence.
+func WithNoRandom() Option[Generator] {
+	return func(g *Generator) {
+		g.fixed, g.tail = true, [2]byte{}
+	}
+}
+
+// WithEpoch sets the instant a Generator's timestamps count from, the Unix
+// epoch by default. A recent epoch (e.g. 2020-01-01) starts the 6-byte
+// millisecond space at a project-specific zero, extending its ~8,900 year
+// span past the Unix-based limit; clock readings before the epoch are not
+// representable, and Generate returns ErrTimeRange (New panics) for them.
+//
+// The epoch is not recorded in the ID: read timestamps back with
+// Generator.Time or ID.TimeWithEpoch, as ID.Time assumes the Unix epoch.
+func WithEpoch(epoch time.Time) Option[Generator] {
+	return func(g *Generator) {
+		g.epoch = epoch.UnixNano()
+	}
+}
+
+// NewGenerator returns a Generator configured by opts. If a StateStore is
+// configured (WithState) and loading from it fails, NewGenerator panics.
+func NewGenerator(opts ...Option[Generator]) *Generator {
+	g := &Generator{now: time.Now, nshards: 1}
+	apply(g, opts)
+	g.initShards()
+	g.watch = g.policy != AdvanceSequence || g.onRegress != nil || g.obs != nil
+	if g.store != nil {
+		last, err := g.store.Load()
+		if err != nil {
+			panic(fmt.Errorf("kid: loading generator state: %w", err))
+		}
+		g.resume(last)
+		g.ceiling.Store(last)
+	}
+	return g
+}
+
+// New generates a new unique ID; see the package-level New for the layout of
+// an ID and the guarantees it carries, which hold per Generator.
+//
+// New panics where Generate would return an error: under the
+// ErrorOnRegression policy, or for a clock reading before the epoch.
+func (g *Generator) New() ID {
+	if g.watch {
+		id, err := g.Generate()
+		if err != nil {
+			panic(err)
+		}
+		return id
+	}
+	return g.newID(g.getTS())
+}
+
+// All returns an infinite iterator of fresh IDs from g, each produced by
+// g.New as the loop asks for it; break to stop.
+func (g *Generator) All() iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		for yield(g.New()) {
+		}
+	}
+}
+
+// NewWithTime generates an ID whose timestamp is t rather than the clock
+// reading, for back-dating (e.g. backfilling historical records so their IDs
+// sort among contemporaries) or forward-dating. t must fall within the range
+// of the 6-byte timestamp, from the Generator's epoch (see WithEpoch) to
+// 2^48-1 milliseconds after it: with the Unix epoch, MinTime to MaxTime.
+//
+// The sequence is derived from t's sub-millisecond fraction, as New derives
+// it from the clock, and is kept strictly increasing across calls that land
+// in the same millisecond, so IDs for non-decreasing times are unique and
+// ordered; a burst overflowing a millisecond carries into the next. IDs
+// dated to a millisecond already left behind, and IDs that coincide with
+// ones from New, are separated only by their random bytes.
+//
+// NewWithTime panics where GenerateWithTime would return an error.
+func (g *Generator) NewWithTime(t time.Time) ID {
+	id, err := g.GenerateWithTime(t)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// GenerateWithTime is like NewWithTime, reporting failure rather than
+// panicking: it returns ErrTimeRange if t is out of range, and with a
+// failing random source (WithRandom) the error it returned.
+func (g *Generator) GenerateWithTime(t time.Time) (ID, error) {
+	milli, sub := g.sinceEpoch(t)
+	if milli < 0 || milli > maxMilli {
+		return Nil, ErrTimeRange
+	}
+	now := milli<<12 + sub>>8
+	for {
+		last := g.lastDated.Load()
+		next := now
+		if last>>12 == milli && next <= last {
+			next = last + 1
+		}
+		if next>>12 > maxMilli {
+			return Nil, ErrTimeRange // a burst carried past the last millisecond
+		}
+		if g.lastDated.CompareAndSwap(last, next) {
+			return g.assemble(next>>12, next&0xfff)
+		}
+	}
+}
+
+// newID assembles an ID from a claimed timestamp and sequence, adding the
+// random bytes. It panics if the random source (WithRandom) fails.
+func (g *Generator) newID(t, s int64) ID {
+	id, err := g.assemble(t, s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// assemble is newID, returning any random source or audit (WithAudit)
+// error.
+func (g *Generator) assemble(t, s int64) (id ID, err error) {
+	// timestamp, 6 bytes, big endian
+	id[0] = byte(t >> 40)
+	id[1] = byte(t >> 32)
+	id[2] = byte(t >> 24)
+	id[3] = byte(t >> 16)
+	id[4] = byte(t >> 8)
+	id[5] = byte(t)
+	// sequence, 2 bytes, big endian
+	id[6] = byte(s >> 8)
+	id[7] = byte(s)
+	switch {
+	case g.fixed:
+		id[8], id[9] = g.tail[0], g.tail[1]
+	case g.custom.Load():
+		if err := g.readRandom(id[8:]); err != nil {
+			return id, err
+		}
+	default:
+		// Two random bytes from the runtime-seeded ChaCha8 generator; see
+		// the package documentation for the security properties of this
+		// choice.
+		r := mrand.Uint32()
+		id[8] = byte(r >> 8)
+		id[9] = byte(r)
+	}
+	if g.audit != nil {
+		return id, g.record(id.String(), t, s, id[8:])
+	}
+	return id, nil
+}
+
+// Time returns the timestamp of id, an ID from g, as a Time relative to the
+// Generator's epoch; see WithEpoch.
+func (g *Generator) Time(id ID) time.Time {
+	return id.TimeWithEpoch(time.Unix(0, g.epoch))
+}
+
+// FirstForTime is like the package-level FirstForTime, with t measured from
+// the Generator's epoch.
+func (g *Generator) FirstForTime(t time.Time) ID {
+	return forTimestamp(g.milli(t), 0x00)
+}
+
+// LastForTime is like the package-level LastForTime, with t measured from
+// the Generator's epoch.
+func (g *Generator) LastForTime(t time.Time) ID {
+	return forTimestamp(g.milli(t), 0xff)
+}
+
+// milli returns t in milliseconds since the Generator's epoch, rounded
+// down, for forTimestamp to clamp.
+func (g *Generator) milli(t time.Time) int64 {
+	milli, _ := g.sinceEpoch(t)
+	return milli
+}
+
+// sinceEpoch returns t as milliseconds since the Generator's epoch, rounded
+// down, and the nanoseconds beyond. The two are computed apart as
+// t.UnixNano overflows past the year 2262, well within the timestamp range.
+func (g *Generator) sinceEpoch(t time.Time) (milli, sub int64) {
+	milli = t.UnixMilli() - g.epoch/nanoPerMilli
+	sub = int64(t.Nanosecond()%nanoPerMilli) - g.epoch%nanoPerMilli
+	if sub < 0 {
+		milli, sub = milli-1, sub+nanoPerMilli
+	} else if sub >= nanoPerMilli {
+		milli, sub = milli+1, sub-nanoPerMilli
+	}
+	return milli, sub
+}
+
+// readRandom fills dst, of at most 8 bytes, from the Generator's random
+// source, through the read-ahead pool unless WithUnbufferedRandom is set.
+func (g *Generator) readRandom(dst []byte) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.rand == nil {
+		// replaced by SetDefaultRandom(nil) since the caller checked custom
+		for i := range dst {
+			dst[i] = byte(mrand.Uint32())
+		}
+		return nil
+	}
+	if g.unbuffered {
+		buf := g.rbuf[:len(dst)]
+		_, err := io.ReadFull(g.rand, buf)
+		copy(dst, buf)
+		if err != nil {
+			return fmt.Errorf("kid: reading random bytes: %w", err)
+		}
+		return nil
+	}
+	if g.rend-g.rpos < len(dst) {
+		if err := g.refill(len(dst)); err != nil {
+			return fmt.Errorf("kid: reading random bytes: %w", err)
+		}
+	}
+	g.rpos += copy(dst, g.pool[g.rpos:g.rend])
+	return nil
+}
+
+// refill tops up the pool from the random source so that it holds at least
+// need bytes, reading as much as a single Read returns up to its capacity:
+// a source with only a few bytes left, such as a test's bytes.Reader, still
+// serves them. g.mu must be held.
+func (g *Generator) refill(need int) error {
+	if g.pool == nil {
+		g.pool = make([]byte, randPoolSize)
+	}
+	n := copy(g.pool, g.pool[g.rpos:g.rend])
+	m, err := io.ReadAtLeast(g.rand, g.pool[n:], need-n)
+	g.rpos, g.rend = 0, n+m
+	return err
+}
+
+// getTS provides the basis of ID timestamp uniqueness; the time encoding is
+// borrowed from getV7Time, converted from mutex protection to a lock-free
+// compare-and-swap:
+// https://github.com/google/uuid/blob/2d3c2a9cc518326daf99a383f07c4d3c44317e4d/version7.go#L88
+
+const nanoPerMilli = 1000000
+
+// getTS returns:
+//   - the number of milliseconds elapsed since the epoch, by default January 1,
+//     1970 UTC, and,
+//   - a sequence value
+//
+// The fast path claims a clock-derived value with a single compare-and-swap;
+// if the clock is not ahead of the last issued value, or the swap loses a
+// race, getTS instead claims the next sequence slot with a wait-free atomic
+// increment. Both operations strictly increase lastTime and return exactly
+// the value they installed, so every call — across all goroutines — returns
+// a (milli << 12 + seq) strictly greater than that of any previous call,
+// even if the wall clock steps backwards. There is no retry loop: under
+// contention every caller completes in a bounded number of atomic
+// operations, which avoids CAS retry storms on hardware where the shared
+// cache line is expensive to bounce (notably multi-cluster arm64 CPUs such
+// as Apple silicon). The clock path re-synchronizes the timestamp to real
+// time whenever the wall clock is ahead.
+//
+// Note: At time of writing, the available timer resolution provided by the Go
+// runtime, operating system and hardware can vary from < 1ms to several ms.
+// https://pkg.go.dev/time#hdr-Timer_Resolution
+func (g *Generator) getTS() (milli, seq int64) {
+	nano := g.now().UnixNano() - g.epoch
+	if nano < 0 {
+		panic(ErrTimeRange) // as Generate, for a clock before the epoch
+	}
+	return g.claim(nano)
+}
+
+// claim returns the timestamp and sequence for a clock reading of nano
+// nanoseconds since the epoch; see getTS.
+func (g *Generator) claim(nano int64) (milli, seq int64) {
+	var now int64
+	if g.shards != nil {
+		now = g.claimShard(nano)
+	} else {
+		milli = nano / nanoPerMilli
+		// Sequence number is between 0 and 3906 (nanoPerMilli>>8)
+		seq = (nano - milli*nanoPerMilli) >> 8
+		now = milli<<12 + seq
+		if last := g.lastTime.Load(); !(now > last && g.lastTime.CompareAndSwap(last, now)) {
+			// The wall clock is not ahead, or another goroutine won the
+			// race: claim the next slot wait-free.
+			now = g.lastTime.Add(1)
+			if g.obs != nil && now&0xfff == 0 {
+				g.obs.Rollover()
+			}
+		}
+	}
+	if g.obs != nil {
+		g.obs.Issued()
+	}
+	if g.store != nil && now > g.ceiling.Load() {
+		g.reserve(now)
+	}
+	return now >> 12, now & 0xfff
+}
+
+// reserve persists a new ceiling a stateLease beyond now before now, a
+// claimed ts+seq, is used, so a restarted Generator resumes above it.
+func (g *Generator) reserve(now int64) {
+	g.smu.Lock()
+	defer g.smu.Unlock()
+	if now <= g.ceiling.Load() {
+		return // raised while we waited
+	}
+	next := now + stateLease
+	if err := g.store.Store(next); err != nil {
+		panic(fmt.Errorf("kid: storing generator state: %w", err))
+	}
+	g.ceiling.Store(next)
+}
@@ -0,0 +1,398 @@
+package kid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	mrand "math/rand/v2"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGeneratorWithClock(t *testing.T) {
+	fixed := time.Date(2026, 7, 6, 12, 0, 0, 512_000, time.UTC)
+	g := NewGenerator(WithClock(func() time.Time { return fixed }))
+	id := g.New()
+	if got, want := id.Time(), fixed.Truncate(time.Millisecond); !got.Equal(want) {
+		t.Errorf("Time() = %v, want %v", got, want)
+	}
+	if got, want := id.Sequence(), int32(512_000>>8); got != want {
+		t.Errorf("Sequence() = %d, want %d", got, want)
+	}
+	// the frozen clock is absorbed by the sequence
+	if next := g.New(); next.Compare(id) <= 0 || next.Sequence() != id.Sequence()+1 {
+		t.Errorf("New() under a frozen clock = %v, want sequence %d", next, id.Sequence()+1)
+	}
+}
+
+// TestGeneratorIndependentState verifies Generators do not share state with
+// each other or with the package-level New.
+func TestGeneratorIndependentState(t *testing.T) {
+	resetClock(t)
+
+	fixed := time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return fixed }
+	std.now = clock
+	for range 100 {
+		New()
+	}
+	a := NewGenerator(WithClock(clock))
+	b := NewGenerator(WithClock(clock))
+	for i := range 100 {
+		ida, idb := a.New(), b.New()
+		if !bytes.Equal(ida[:8], idb[:8]) {
+			t.Fatalf("call %d: generators share state: %v / %v", i, ida, idb)
+		}
+		if ida.Sequence() != int32(i) {
+			t.Fatalf("call %d: Sequence() = %d, want %d", i, ida.Sequence(), i)
+		}
+	}
+}
+
+func TestGeneratorWithRandom(t *testing.T) {
+	src := []byte{0x01, 0x02, 0x03, 0x04}
+	g := NewGenerator(WithRandom(bytes.NewReader(src)))
+	for i := 0; i < len(src); i += 2 {
+		if id := g.New(); !bytes.Equal(id[8:], src[i:i+2]) {
+			t.Errorf("random bytes = %v, want %v", id[8:], src[i:i+2])
+		}
+	}
+	// the exhausted reader errors, and New panics
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(error).Error(), "reading random bytes") {
+			t.Errorf("New() with a failing random source: recover() = %v, want panic", r)
+		}
+	}()
+	g.New()
+}
+
+// readCounter counts the Read calls made on it.
+type readCounter struct {
+	r     io.Reader
+	reads int
+}
+
+func (c *readCounter) Read(p []byte) (int, error) {
+	c.reads++
+	return c.r.Read(p)
+}
+
+func TestGeneratorRandomPool(t *testing.T) {
+	seed := [32]byte{1}
+	for _, tt := range []struct {
+		opts  []Option[Generator]
+		reads int
+	}{
+		{nil, 3}, // 5000 IDs of 2 bytes from 4096-byte reads
+		{[]Option[Generator]{WithUnbufferedRandom()}, 5000},
+	} {
+		src := &readCounter{r: mrand.NewChaCha8(seed)}
+		g := NewGenerator(append(tt.opts, WithRandom(src))...)
+		want := mrand.NewChaCha8(seed)
+		for range 5000 {
+			var rnd [2]byte
+			want.Read(rnd[:])
+			if id := g.New(); !bytes.Equal(id[8:], rnd[:]) {
+				t.Fatalf("random bytes = %v, want %v", id[8:], rnd)
+			}
+		}
+		if src.reads != tt.reads {
+			t.Errorf("%d options: %d reads, want %d", len(tt.opts), src.reads, tt.reads)
+		}
+	}
+}
+
+func TestSetDefaultRandom(t *testing.T) {
+	defer SetDefaultRandom(nil)
+	SetDefaultRandom(bytes.NewReader([]byte{0xca, 0xfe}))
+	if id := New(); id.Random() != 0xcafe {
+		t.Errorf("Random() = %#x, want 0xcafe", id.Random())
+	}
+
+	// replacing the source mid-generation is safe (run with -race)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 1000 {
+			New()
+		}
+	}()
+	for i := range 100 {
+		if i%2 == 0 {
+			SetDefaultRandom(rand.Reader)
+		} else {
+			SetDefaultRandom(nil)
+		}
+	}
+	wg.Wait()
+}
+
+func TestGeneratorUniqueParallel(t *testing.T) {
+	const goroutines, per = 8, 10000
+	g := NewGenerator(WithRandom(rand.Reader))
+	results := make([][]ID, goroutines)
+	var wg sync.WaitGroup
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range per {
+				results[i] = append(results[i], g.New())
+			}
+		}()
+	}
+	wg.Wait()
+	var all []ID
+	for _, r := range results {
+		all = append(all, r...)
+	}
+	Sort(all)
+	for i := 1; i < len(all); i++ {
+		if bytes.Equal(all[i-1][:8], all[i][:8]) {
+			t.Fatalf("duplicate ts+seq: %v / %v", all[i-1], all[i])
+		}
+	}
+}
+
+func BenchmarkGeneratorWithRandom(b *testing.B) {
+	g := NewGenerator(WithRandom(rand.Reader))
+	var r ID
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r = g.New()
+		}
+		benchResultID = r
+	})
+}
+
+func BenchmarkGeneratorWithRandomUnbuffered(b *testing.B) {
+	g := NewGenerator(WithRandom(rand.Reader), WithUnbufferedRandom())
+	var r ID
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r = g.New()
+		}
+		benchResultID = r
+	})
+}
+
+func TestNewWithTime(t *testing.T) {
+	g := NewGenerator()
+	past := time.Date(1999, 12, 31, 23, 59, 59, 999_000_000, time.UTC)
+	prev := g.NewWithTime(past)
+	if got := prev.Time(); !got.Equal(past) {
+		t.Errorf("Time() = %v, want %v", got, past)
+	}
+	// repeated times in one millisecond get strictly increasing sequences
+	for i := range 100 {
+		id := g.NewWithTime(past)
+		if id.Compare(prev) <= 0 || id.Sequence() != prev.Sequence()+1 {
+			t.Fatalf("call %d: %v does not follow %v", i, id, prev)
+		}
+		if !id.Time().Equal(past) {
+			t.Fatalf("call %d: Time() = %v, want %v", i, id.Time(), past)
+		}
+		prev = id
+	}
+	// a later time sorts after, with its own derived sequence
+	later := past.Add(time.Millisecond + 512*time.Microsecond)
+	id := g.NewWithTime(later)
+	if id.Compare(prev) <= 0 || !id.Time().Equal(later.Truncate(time.Millisecond)) {
+		t.Errorf("NewWithTime(%v) = %v, want after %v", later, id, prev)
+	}
+	if got, want := id.Sequence(), int32(512_000>>8); got != want {
+		t.Errorf("Sequence() = %d, want %d", got, want)
+	}
+	// the package-level function forward-dates from the default generator
+	future := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := NewWithTime(future).Time(); !got.Equal(future) {
+		t.Errorf("NewWithTime(%v).Time() = %v", future, got)
+	}
+}
+
+func TestGenerateWithTimeRange(t *testing.T) {
+	g := NewGenerator()
+	for _, tt := range []struct {
+		t   time.Time
+		err error
+	}{
+		{MinTime(), nil},
+		{MaxTime(), nil}, // beyond the range of UnixNano
+		{MaxTime().Add(999 * time.Microsecond), nil},
+		{MinTime().Add(-time.Nanosecond), ErrTimeRange},
+		{MaxTime().Add(time.Millisecond), ErrTimeRange},
+	} {
+		id, err := g.GenerateWithTime(tt.t)
+		if err != tt.err {
+			t.Errorf("GenerateWithTime(%v) err = %v, want %v", tt.t, err, tt.err)
+		}
+		if err == nil && !id.Time().Equal(tt.t.Truncate(time.Millisecond)) {
+			t.Errorf("GenerateWithTime(%v).Time() = %v", tt.t, id.Time())
+		}
+	}
+	if MaxTime().Year() != 10889 || !FirstForTime(MaxTime()).Time().Equal(MaxTime()) {
+		t.Errorf("MaxTime() = %v", MaxTime())
+	}
+
+	// a custom epoch shifts the range; the epoch need not be a whole
+	// millisecond
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 500_000, time.UTC)
+	g = NewGenerator(WithEpoch(epoch))
+	if _, err := g.GenerateWithTime(epoch.Add(-time.Nanosecond)); err != ErrTimeRange {
+		t.Errorf("GenerateWithTime(before epoch) err = %v, want ErrTimeRange", err)
+	}
+	at := epoch.Add(1500 * time.Microsecond)
+	if id, err := g.GenerateWithTime(at); err != nil || id.Timestamp() != 1 || id.Sequence() != 500_000>>8 {
+		t.Errorf("GenerateWithTime(%v) = %d, %d, %v, want 1, %d", at, id.Timestamp(), id.Sequence(), err, 500_000>>8)
+	}
+
+	// a burst in the last millisecond cannot carry past it
+	last := MaxTime().Add(999 * time.Microsecond)
+	g = NewGenerator()
+	var err error
+	for range 4097 {
+		if _, err = g.GenerateWithTime(last); err != nil {
+			break
+		}
+	}
+	if err != ErrTimeRange {
+		t.Errorf("GenerateWithTime() burst past MaxTime err = %v, want ErrTimeRange", err)
+	}
+	defer func() {
+		if r := recover(); r != ErrTimeRange {
+			t.Errorf("NewWithTime(before MinTime) recovered %v, want ErrTimeRange", r)
+		}
+	}()
+	NewWithTime(MinTime().Add(-time.Millisecond))
+}
+
+func TestGeneratorWithEpoch(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithEpoch(epoch), WithClock(func() time.Time { return now }))
+	id := g.New()
+	if got, want := id.Timestamp(), now.Sub(epoch).Milliseconds(); got != want {
+		t.Errorf("Timestamp() = %d, want %d", got, want)
+	}
+	if got := g.Time(id); !got.Equal(now) {
+		t.Errorf("Generator.Time() = %v, want %v", got, now)
+	}
+	if got := id.TimeWithEpoch(epoch); !got.Equal(now) {
+		t.Errorf("TimeWithEpoch() = %v, want %v", got, now)
+	}
+	// the whole timestamp range, beyond a Duration's
+	if got, want := Max.TimeWithEpoch(time.Unix(0, 0)), Max.Time(); !got.Equal(want) {
+		t.Errorf("Max.TimeWithEpoch(Unix epoch) = %v, want %v", got, want)
+	}
+	if got, want := g.Time(Max), time.UnixMilli(epoch.UnixMilli()+maxMilli); !got.Equal(want) {
+		t.Errorf("Generator.Time(Max) = %v, want %v", got, want)
+	}
+	if got := g.Time(g.FirstForTime(g.Time(Max))); !got.Equal(g.Time(Max)) {
+		t.Errorf("Max round trip = %v, want %v", got, g.Time(Max))
+	}
+	subEpoch := epoch.Add(250 * time.Microsecond)
+	if got, want := (ID{5: 1}).TimeWithEpoch(subEpoch), subEpoch.Add(time.Millisecond); !got.Equal(want) {
+		t.Errorf("TimeWithEpoch(%v) = %v, want %v", subEpoch, got, want)
+	}
+	// back-dated IDs count from the same epoch
+	past := time.Date(2021, 3, 4, 5, 6, 7, 8_000_000, time.UTC)
+	if got := g.Time(g.NewWithTime(past)); !got.Equal(past) {
+		t.Errorf("Time(NewWithTime(%v)) = %v", past, got)
+	}
+}
+
+func TestGeneratorClockBeforeEpoch(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := func() time.Time { return epoch.Add(-time.Millisecond) }
+	g := NewGenerator(WithEpoch(epoch), WithClock(before))
+	if id, err := g.Generate(); err != ErrTimeRange || id != Nil {
+		t.Errorf("Generate() = %v, %v, want ErrTimeRange", id, err)
+	}
+	defer func() {
+		if r := recover(); r != ErrTimeRange {
+			t.Errorf("New() recovered %v, want ErrTimeRange", r)
+		}
+	}()
+	g.New()
+}
+
+func TestGeneratorForTimeFarFuture(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithEpoch(epoch))
+	// beyond the reach of UnixNano, within the timestamp range
+	at := time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := at.UnixMilli() - epoch.UnixMilli()
+	if got := g.FirstForTime(at); got.Timestamp() != want || got != FirstForTime(time.UnixMilli(want)) {
+		t.Errorf("FirstForTime(%v) = %v, timestamp %d, want %d", at, got, got.Timestamp(), want)
+	}
+	if got := g.LastForTime(at); got.Timestamp() != want {
+		t.Errorf("LastForTime(%v) timestamp = %d, want %d", at, got.Timestamp(), want)
+	}
+	// beyond the range, and before the epoch, the bounds clamp
+	if got := g.LastForTime(time.Date(20000, 1, 1, 0, 0, 0, 0, time.UTC)); got != Max {
+		t.Errorf("LastForTime(year 20000) = %v, want %v", got, Max)
+	}
+	if got := g.FirstForTime(time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)); got != Nil {
+		t.Errorf("FirstForTime(year 1000) = %v, want %v", got, Nil)
+	}
+}
+
+// A fixed clock and a seeded random source make a Generator fully
+// deterministic, for snapshot tests of code that produces IDs.
+func ExampleWithRandom() {
+	var seed [32]byte // any fixed seed
+	g := NewGenerator(
+		WithClock(func() time.Time { return time.Date(2025, 3, 8, 17, 50, 27, 757_000_000, time.UTC) }),
+		WithRandom(mrand.NewChaCha8(seed)),
+	)
+	for range 3 {
+		fmt.Println(g.New())
+	}
+	// Output:
+	// 06bqer9xnm001pd7
+	// 06bqer9xnm002zpf
+	// 06bqer9xnm004v9p
+}
+
+func TestGeneratorWithNoRandom(t *testing.T) {
+	fixed := time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithNoRandom(), WithClock(func() time.Time { return fixed }))
+	for i := range 10 {
+		id := g.New()
+		if id.Random() != 0 || id.Sequence() != int32(i) {
+			t.Errorf("call %d: New() = %v, want sequence %d and zero random bytes", i, id, i)
+		}
+	}
+	if id := g.NewWithTime(fixed); id.Random() != 0 {
+		t.Errorf("NewWithTime() random = %d, want 0", id.Random())
+	}
+}
+
+func TestAll(t *testing.T) {
+	var ids []ID
+	for id := range All() {
+		ids = append(ids, id)
+		if len(ids) == 5 {
+			break
+		}
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i].Compare(ids[i-1]) <= 0 {
+			t.Errorf("ID %d does not sort after its predecessor", i)
+		}
+	}
+	g := NewGenerator(WithNoRandom())
+	n := 0
+	for id := range g.All() {
+		if id.Random() != 0 {
+			t.Fatalf("Generator.All() ignored the generator's options: %v", id)
+		}
+		if n++; n == 3 {
+			break
+		}
+	}
+}
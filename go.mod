@@ -4,3 +4,12 @@ go 1.23.0
 
 // Package kid has no dependencies outside of the Go standard library.
 // If running anything under eval/* run `go mod tidy` to pull in dependencies.
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/matoous/go-nanoid/v2 v2.1.0
+	github.com/oklog/ulid v1.3.1
+	github.com/rs/xid v1.6.0
+	github.com/segmentio/ksuid v1.0.4
+	github.com/sony/sonyflake v1.3.0
+)
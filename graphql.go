@@ -0,0 +1,40 @@
+package kid
+
+import (
+	"fmt"
+	"io"
+)
+
+// MarshalGQL writes id as a GraphQL string value, or null for the nil ID,
+// as MarshalJSON does; declare fields that may hold the nil ID nullable.
+// Together with UnmarshalGQL it satisfies the graphql.Marshaler and
+// graphql.Unmarshaler interfaces of github.com/99designs/gqlgen, which need
+// no import to implement, so kid.ID can be bound to a custom scalar:
+//
+//	# schema.graphqls
+//	scalar KID
+//
+//	# gqlgen.yml
+//	models:
+//	  KID:
+//	    model: github.com/mwyvr/kid.ID
+func (id ID) MarshalGQL(w io.Writer) {
+	b, _ := id.MarshalJSON()
+	w.Write(b) //nolint:errcheck
+}
+
+// UnmarshalGQL decodes a GraphQL input value, which must be a string holding
+// a 16-character encoded ID, or null, which yields the nil ID. On error, id
+// is set to the nil ID.
+func (id *ID) UnmarshalGQL(v any) error {
+	switch val := v.(type) {
+	case string:
+		return id.UnmarshalText([]byte(val))
+	case nil:
+		*id = Nil
+		return nil
+	default:
+		*id = Nil
+		return fmt.Errorf("%w: GraphQL input of type %T, want string", ErrInvalidID, v)
+	}
+}
@@ -0,0 +1,35 @@
+package kid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGraphQL(t *testing.T) {
+	id := tests[6].id
+	var b strings.Builder
+	id.MarshalGQL(&b)
+	if b.String() != `"06bqer9xnm79tfnl"` {
+		t.Errorf("MarshalGQL() wrote %s, want %q", b.String(), "06bqer9xnm79tfnl")
+	}
+	b.Reset()
+	Nil.MarshalGQL(&b)
+	if b.String() != "null" {
+		t.Errorf("Nil.MarshalGQL() wrote %s, want null", b.String())
+	}
+
+	var got ID
+	if err := got.UnmarshalGQL("06bqer9xnm79tfnl"); err != nil || got != id {
+		t.Errorf("UnmarshalGQL(string) = %v, %v, want %v", got, err, id)
+	}
+	if err := got.UnmarshalGQL(nil); err != nil || got != Nil {
+		t.Errorf("UnmarshalGQL(nil) = %v, %v, want Nil", got, err)
+	}
+	for _, v := range []any{"06bqer9xnm79tfn", 42, []byte("06bqer9xnm79tfnl"), map[string]any{}} {
+		got := id
+		if err := got.UnmarshalGQL(v); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("UnmarshalGQL(%#v) = %v, %v, want ErrInvalidID", v, got, err)
+		}
+	}
+}
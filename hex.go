@@ -0,0 +1,38 @@
+package kid
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// hexLen is the length of an ID in hexadecimal.
+const hexLen = 2 * rawLen
+
+// Hex returns id as 20 lower case hexadecimal digits, the least surprising
+// form for debugging, log correlation and languages without a decoder for
+// the kid alphabet. Hex-encoded IDs sort like binary IDs.
+func (id ID) Hex() string {
+	var text [hexLen]byte
+	hex.Encode(text[:], id[:])
+	return string(text[:])
+}
+
+// FromHex decodes s, 20 hexadecimal digits in either case, as from ID.Hex.
+// On error it returns the nil ID and an *InvalidLengthError or
+// *InvalidCharError, wrapping ErrInvalidID.
+func FromHex(s string) (ID, error) {
+	var id ID
+	if len(s) != hexLen {
+		return Nil, &InvalidLengthError{Got: len(s), Want: hexLen}
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		// hex.Decode reports the first bad byte, but not where it is
+		var c hex.InvalidByteError
+		if !errors.As(err, &c) {
+			return Nil, ErrInvalidID
+		}
+		return Nil, &InvalidCharError{Pos: strings.IndexByte(s, byte(c)), Char: byte(c)}
+	}
+	return id, nil
+}
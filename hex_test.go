@@ -0,0 +1,27 @@
+package kid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHex(t *testing.T) {
+	id := tests[6].id
+	want := "019576e13dad0e9d3ab3"
+	if got := id.Hex(); got != want {
+		t.Errorf("Hex() = %s, want %s", got, want)
+	}
+	for _, s := range []string{want, "019576E13DAD0E9D3AB3"} {
+		if got, err := FromHex(s); err != nil || got != id {
+			t.Errorf("FromHex(%s) = %v, %v, want %v", s, got, err, id)
+		}
+	}
+	for _, s := range []string{"", "019576e13dad0e9d3ab", "019576e13dad0e9d3ab30", "019576e13dad0e9d3abg"} {
+		if got, err := FromHex(s); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("FromHex(%q) = %v, %v, want ErrInvalidID", s, got, err)
+		}
+	}
+	if got := Max.Hex(); got != "ffffffffffffffffffff" {
+		t.Errorf("Max.Hex() = %s", got)
+	}
+}
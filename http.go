@@ -0,0 +1,40 @@
+//go:build !tinygo
+
+package kid
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// FromRequestQuery decodes the ID in query parameter key of r's URL. A
+// missing, empty or malformed parameter returns the nil ID and an error
+// wrapping ErrInvalidID that names the parameter, so handlers can respond
+// with the error text and test errors.Is(err, kid.ErrInvalidID):
+//
+//	id, err := kid.FromRequestQuery(r, "id")
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+func FromRequestQuery(r *http.Request, key string) (ID, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return Nil, fmt.Errorf("%w: query parameter %q missing", ErrInvalidID, key)
+	}
+	id, err := FromString(v)
+	if err != nil {
+		return Nil, fmt.Errorf("%w: query parameter %q: %q", err, key, v)
+	}
+	return id, nil
+}
+
+// SetQuery sets query parameter key of u to the encoded id, replacing any
+// existing values. As with url.Values.Encode, parameters are re-encoded
+// sorted by key.
+func SetQuery(u *url.URL, key string, id ID) {
+	q := u.Query()
+	q.Set(key, id.String())
+	u.RawQuery = q.Encode()
+}
@@ -0,0 +1,36 @@
+//go:build !tinygo
+
+package kid
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFromRequestQuery(t *testing.T) {
+	want := tests[6].id // 06bqer9xnm79tfnl
+	r := httptest.NewRequest("GET", "/item?id=06bqer9xnm79tfnl&bad=06BQER9XNM79TFNL&empty=", nil)
+	if id, err := FromRequestQuery(r, "id"); err != nil || id != want {
+		t.Errorf("FromRequestQuery(id) = %v, %v, want %v", id, err, want)
+	}
+	for _, key := range []string{"bad", "empty", "absent"} {
+		id, err := FromRequestQuery(r, key)
+		if !errors.Is(err, ErrInvalidID) || id != Nil {
+			t.Errorf("FromRequestQuery(%s) = %v, %v, want nil ID, ErrInvalidID", key, id, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), `"`+key+`"`) {
+			t.Errorf("FromRequestQuery(%s) error %q does not name the parameter", key, err)
+		}
+	}
+}
+
+func TestSetQuery(t *testing.T) {
+	u, _ := url.Parse("https://example.com/item?page=2&id=old")
+	SetQuery(u, "id", tests[6].id)
+	if got, want := u.String(), "https://example.com/item?id=06bqer9xnm79tfnl&page=2"; got != want {
+		t.Errorf("SetQuery() = %s, want %s", got, want)
+	}
+}
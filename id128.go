@@ -0,0 +1,196 @@
+package kid
+
+import (
+	"bytes"
+	"encoding/binary"
+	mrand "math/rand/v2"
+	"time"
+)
+
+// ID128 is an extended 16-byte variant of ID, for uncoordinated generation
+// across many hosts: it keeps the timestamp and sequence of an ID and
+// widens the random value to 8 bytes, 64 random bits where an ID has 16,
+// while remaining k-sortable. Two hosts claiming the same timestamp+sequence
+// then collide with probability 2^-64 rather than 2^-16. With WithNode only
+// 16 bits vary, the node value, the other six bytes being zero; with
+// WithNoRandom none do. Its bytes are:
+//
+//   - 6 bytes, timestamp, milliseconds since the Generator's epoch
+//   - 2 bytes, sequence, as for ID
+//   - 8 bytes, random value
+//
+// An ID128 encodes as 26 characters of the kid alphabet, the first carrying
+// 3 bits, preserving sort order.
+type ID128 [16]byte
+
+const (
+	rawLen128     = 16
+	encodedLen128 = 26
+)
+
+// New128 generates a new unique ID128 from the default Generator, which it
+// shares with New.
+func New128() ID128 {
+	return std.New128()
+}
+
+// New128 generates a new unique ID128 from g's timestamp+sequence state,
+// shared with g.New, so IDs and ID128s from one Generator never claim the
+// same timestamp+sequence. With WithNode, the node occupies the first two
+// random bytes; with WithNode or WithNoRandom, the remaining six are zero.
+//
+// Like New, New128 panics on errors Generate would return.
+func (g *Generator) New128() ID128 {
+	milli, seq, err := g.stamp()
+	if err != nil {
+		panic(err)
+	}
+	var id ID128
+	binary.BigEndian.PutUint64(id[:8], uint64(milli)<<16|uint64(seq)) //nolint:gosec
+	switch {
+	case g.fixed:
+		id[8], id[9] = g.tail[0], g.tail[1]
+	case g.custom.Load():
+		if err := g.readRandom(id[8:]); err != nil {
+			panic(err)
+		}
+	default:
+		binary.BigEndian.PutUint64(id[8:], mrand.Uint64())
+	}
+	if g.audit != nil {
+		if err := g.record(id.String(), milli, seq, id[8:]); err != nil {
+			panic(err)
+		}
+	}
+	return id
+}
+
+// FromBytes128 copies b, which must be 16 bytes long, into an ID128.
+func FromBytes128(b []byte) (ID128, error) {
+	var id ID128
+	if len(b) != rawLen128 {
+		return id, ErrInvalidID
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+// FromString128 decodes a 26-character encoded ID128.
+func FromString128(str string) (ID128, error) {
+	var id ID128
+	err := id.UnmarshalText([]byte(str))
+	return id, err
+}
+
+// IsNil returns true if id is the zero value.
+func (id ID128) IsNil() bool {
+	return id == ID128{}
+}
+
+// Encode encodes id, writing 26 bytes to dst and returning it. Encode
+// panics if len(dst) < 26.
+func (id ID128) Encode(dst []byte) []byte {
+	_ = dst[encodedLen128-1] // bounds check hint
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+	for i := encodedLen128 - 1; i >= 0; i-- {
+		dst[i] = encoding[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return dst[:encodedLen128]
+}
+
+// String implements fmt.Stringer, returning id encoded as 26 characters.
+func (id ID128) String() string {
+	var text [encodedLen128]byte
+	return string(id.Encode(text[:]))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ID128) MarshalText() ([]byte, error) {
+	return id.Encode(make([]byte, encodedLen128)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. text must be 26
+// characters of the kid alphabet, the first carrying only 3 bits (0-7); on
+// error, id is set to the zero value and an *InvalidLengthError or
+// *InvalidCharError, wrapping ErrInvalidID, is returned.
+func (id *ID128) UnmarshalText(text []byte) error {
+	if len(text) != encodedLen128 || dec[text[0]] > 0x07 {
+		*id = ID128{}
+		return textError(text, encodedLen128, 0x07)
+	}
+	var hi, lo uint64
+	for _, c := range text {
+		d := dec[c]
+		if d == maxByte {
+			*id = ID128{}
+			return textError(text, encodedLen128, 0x07)
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(d)
+	}
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding id as a
+// quoted string, or null for the zero value.
+func (id ID128) MarshalJSON() ([]byte, error) {
+	if id.IsNil() {
+		return []byte("null"), nil
+	}
+	text := make([]byte, encodedLen128+2)
+	id.Encode(text[1:])
+	text[0], text[encodedLen128+1] = '"', '"'
+	return text, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting only
+// null or a quoted 26-character encoding.
+func (id *ID128) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*id = ID128{}
+		return nil
+	}
+	if len(b) != encodedLen128+2 || b[0] != '"' || b[len(b)-1] != '"' {
+		*id = ID128{}
+		return ErrInvalidID
+	}
+	return id.UnmarshalText(b[1 : len(b)-1])
+}
+
+// Bytes returns the binary representation of id, which is simply id[:].
+func (id ID128) Bytes() []byte {
+	return id[:]
+}
+
+// Timestamp returns the timestamp component of id as milliseconds since the
+// Unix epoch, or since the custom epoch of the Generator that produced it
+// (see WithEpoch).
+func (id ID128) Timestamp() int64 {
+	return int64(binary.BigEndian.Uint64(id[:8]) >> 16) //nolint:gosec
+}
+
+// Time returns the timestamp of id as a Time with millisecond resolution.
+// Location is set to UTC.
+func (id ID128) Time() time.Time {
+	return time.UnixMilli(id.Timestamp()).UTC()
+}
+
+// Sequence returns the sequence component of id.
+func (id ID128) Sequence() int32 {
+	return int32(binary.BigEndian.Uint16(id[6:8]))
+}
+
+// Random returns the 8-byte random component of id.
+func (id ID128) Random() uint64 {
+	return binary.BigEndian.Uint64(id[8:])
+}
+
+// Compare returns an integer comparing two ID128s with bytes.Compare
+// semantics.
+func (id ID128) Compare(other ID128) int {
+	return bytes.Compare(id[:], other[:])
+}
@@ -0,0 +1,79 @@
+package kid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNew128(t *testing.T) {
+	fixed := time.Date(2026, 7, 6, 12, 0, 0, 512_000, time.UTC)
+	rnd := bytes.Repeat([]byte{0x5a}, 64)
+	g := NewGenerator(WithClock(func() time.Time { return fixed }), WithRandom(bytes.NewReader(rnd)))
+	id := g.New128()
+	if got := id.Time(); !got.Equal(fixed.Truncate(time.Millisecond)) {
+		t.Errorf("Time() = %v, want %v", got, fixed.Truncate(time.Millisecond))
+	}
+	if got, want := id.Sequence(), int32(512_000>>8); got != want {
+		t.Errorf("Sequence() = %d, want %d", got, want)
+	}
+	if got := id.Random(); got != 0x5a5a5a5a5a5a5a5a {
+		t.Errorf("Random() = %#x, want all 8 bytes from the random source", got)
+	}
+	// ID and ID128 share the generator's timestamp+sequence state
+	if next := g.New(); next.Sequence() != id.Sequence()+1 {
+		t.Errorf("New() after New128(): sequence %d, want %d", next.Sequence(), id.Sequence()+1)
+	}
+
+	prev := New128()
+	for range 10000 {
+		id := New128()
+		if id.Compare(prev) <= 0 || id.String() <= prev.String() {
+			t.Fatalf("%v does not sort after %v in every form", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestID128Encoding(t *testing.T) {
+	for _, tt := range []struct {
+		id  ID128
+		str string
+	}{
+		{ID128{}, "00000000000000000000000000"},
+		{ID128{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, "00041061050r3hh28b1d60t3hg"},
+		{ID128{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "7zzzzzzzzzzzzzzzzzzzzzzzzz"},
+	} {
+		if got := tt.id.String(); got != tt.str {
+			t.Errorf("%x.String() = %s, want %s", tt.id[:], got, tt.str)
+		}
+		if got, err := FromString128(tt.str); err != nil || got != tt.id {
+			t.Errorf("FromString128(%s) = %x, %v, want %x", tt.str, got[:], err, tt.id[:])
+		}
+	}
+	for _, s := range []string{"", "0000000000000000000000000", "000000000000000000000000000", "80000000000000000000000000", "0000000000000000000000000a"} {
+		if id, err := FromString128(s); !errors.Is(err, ErrInvalidID) || !id.IsNil() {
+			t.Errorf("FromString128(%q) = %v, %v, want ErrInvalidID", s, id, err)
+		}
+	}
+	if _, err := FromBytes128(make([]byte, 10)); err != ErrInvalidID {
+		t.Errorf("FromBytes128(10 bytes) err = %v, want ErrInvalidID", err)
+	}
+}
+
+func TestID128Marshaling(t *testing.T) {
+	id := New128()
+	b, err := json.Marshal(struct{ ID ID128 }{id})
+	if err != nil || string(b) != `{"ID":"`+id.String()+`"}` {
+		t.Errorf("json.Marshal() = %s, %v", b, err)
+	}
+	var v struct{ ID ID128 }
+	if err := json.Unmarshal(b, &v); err != nil || v.ID != id {
+		t.Errorf("json.Unmarshal() = %v, %v, want %v", v.ID, err, id)
+	}
+	if err := json.Unmarshal([]byte(`{"ID":12345678901234567890123456}`), &v); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("json.Unmarshal(number) err = %v, want ErrInvalidID", err)
+	}
+}
@@ -0,0 +1,208 @@
+package kid
+
+import (
+	"bytes"
+	"encoding/binary"
+	mrand "math/rand/v2"
+	"time"
+)
+
+// ID64 is a compact 8-byte variant of ID, for systems wanting a sortable
+// BIGINT primary key. Its 64 bits, big endian, are composed of:
+//
+//   - 42 bits, timestamp, milliseconds since ID64Epoch
+//   - 12 bits, sequence, as for ID
+//   - 10 bits, random value, or the low 10 bits of the node (WithNode)
+//
+// An ID64 converts to a non-negative int64 that sorts like the ID64 until
+// the year 2089, when the timestamp reaches the sign bit; its timestamps
+// end in 2159. It encodes as 13 characters of the kid alphabet, the first carrying
+// 4 bits, preserving sort order.
+//
+// With 10 random bits rather than 16, IDs from uncoordinated processes that
+// claim the same timestamp+sequence collide with probability 1/1024; assign
+// distinct nodes (below 1024) where that matters.
+type ID64 [8]byte
+
+const encodedLen64 = 13
+
+// ID64Epoch is the instant ID64 timestamps count from: 2020-01-01 UTC.
+var ID64Epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// id64EpochMilli is ID64Epoch in Unix milliseconds.
+var id64EpochMilli = ID64Epoch.UnixMilli()
+
+// New64 generates a new unique ID64 from the default Generator, which it
+// shares with New.
+func New64() ID64 {
+	return std.New64()
+}
+
+// New64 generates a new unique ID64 from g's timestamp+sequence state,
+// shared with g.New, so IDs and ID64s from one Generator never claim the
+// same timestamp+sequence. A Generator epoch (WithEpoch) does not apply:
+// ID64 timestamps always count from ID64Epoch.
+//
+// Like New, New64 panics on errors Generate would return, and with
+// ErrTimeRange for a clock outside ID64Epoch to 2159.
+func (g *Generator) New64() ID64 {
+	milli, seq, err := g.stamp()
+	if err != nil {
+		panic(err)
+	}
+	ts := milli + g.epoch/nanoPerMilli - id64EpochMilli
+	if ts < 0 || ts >= 1<<42 {
+		panic(ErrTimeRange)
+	}
+	var r uint64
+	switch {
+	case g.fixed:
+		r = uint64(g.tail[0])<<8 | uint64(g.tail[1])
+	case g.custom.Load():
+		var b [2]byte
+		if err := g.readRandom(b[:]); err != nil {
+			panic(err)
+		}
+		r = uint64(b[0])<<8 | uint64(b[1])
+	default:
+		r = uint64(mrand.Uint32())
+	}
+	r &= 0x3ff
+	var id ID64
+	binary.BigEndian.PutUint64(id[:], uint64(ts)<<22|uint64(seq)<<10|r) //nolint:gosec
+	if g.audit != nil {
+		if err := g.record(id.String(), milli, seq, []byte{byte(r >> 8), byte(r)}); err != nil {
+			panic(err)
+		}
+	}
+	return id
+}
+
+// FromInt64 returns the ID64 with integer value n, as from ID64.Int64.
+func FromInt64(n int64) ID64 {
+	var id ID64
+	binary.BigEndian.PutUint64(id[:], uint64(n)) //nolint:gosec
+	return id
+}
+
+// FromString64 decodes a 13-character encoded ID64.
+func FromString64(str string) (ID64, error) {
+	var id ID64
+	err := id.UnmarshalText([]byte(str))
+	return id, err
+}
+
+// Int64 returns id as an integer, for BIGINT columns.
+func (id ID64) Int64() int64 {
+	return int64(binary.BigEndian.Uint64(id[:])) //nolint:gosec
+}
+
+// IsNil returns true if id is the zero value.
+func (id ID64) IsNil() bool {
+	return id == ID64{}
+}
+
+// Encode encodes id, writing 13 bytes to dst and returning it. Encode
+// panics if len(dst) < 13.
+func (id ID64) Encode(dst []byte) []byte {
+	_ = dst[encodedLen64-1] // bounds check hint
+	v := binary.BigEndian.Uint64(id[:])
+	dst[0] = encoding[v>>60]
+	for i := 1; i < encodedLen64; i++ {
+		dst[i] = encoding[(v>>(60-5*i))&0x1f]
+	}
+	return dst[:encodedLen64]
+}
+
+// String implements fmt.Stringer, returning id encoded as 13 characters.
+func (id ID64) String() string {
+	var text [encodedLen64]byte
+	return string(id.Encode(text[:]))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ID64) MarshalText() ([]byte, error) {
+	return id.Encode(make([]byte, encodedLen64)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. text must be 13
+// characters of the kid alphabet, the first carrying only 4 bits (0-g); on
+// error, id is set to the zero value and an *InvalidLengthError or
+// *InvalidCharError, wrapping ErrInvalidID, is returned.
+func (id *ID64) UnmarshalText(text []byte) error {
+	if len(text) != encodedLen64 || dec[text[0]] > 0x0f {
+		*id = ID64{}
+		return textError(text, encodedLen64, 0x0f)
+	}
+	v := uint64(dec[text[0]])
+	for _, c := range text[1:] {
+		d := dec[c]
+		if d == maxByte {
+			*id = ID64{}
+			return textError(text, encodedLen64, 0x0f)
+		}
+		v = v<<5 | uint64(d)
+	}
+	binary.BigEndian.PutUint64(id[:], v)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding id as a
+// quoted string — not a number, which JavaScript could not represent
+// exactly — or null for the zero value.
+func (id ID64) MarshalJSON() ([]byte, error) {
+	if id.IsNil() {
+		return []byte("null"), nil
+	}
+	text := make([]byte, encodedLen64+2)
+	id.Encode(text[1:])
+	text[0], text[encodedLen64+1] = '"', '"'
+	return text, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting only
+// null or a quoted 13-character encoding.
+func (id *ID64) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*id = ID64{}
+		return nil
+	}
+	if len(b) != encodedLen64+2 || b[0] != '"' || b[len(b)-1] != '"' {
+		*id = ID64{}
+		return ErrInvalidID
+	}
+	return id.UnmarshalText(b[1 : len(b)-1])
+}
+
+// Bytes returns the binary representation of id, which is simply id[:].
+func (id ID64) Bytes() []byte {
+	return id[:]
+}
+
+// Timestamp returns the timestamp component of id as milliseconds since
+// ID64Epoch.
+func (id ID64) Timestamp() int64 {
+	return int64(binary.BigEndian.Uint64(id[:]) >> 22) //nolint:gosec
+}
+
+// Time returns the timestamp of id as a Time with millisecond resolution.
+// Location is set to UTC.
+func (id ID64) Time() time.Time {
+	return time.UnixMilli(id64EpochMilli + id.Timestamp()).UTC()
+}
+
+// Sequence returns the 12-bit sequence component of id.
+func (id ID64) Sequence() int32 {
+	return int32(binary.BigEndian.Uint64(id[:]) >> 10 & 0xfff) //nolint:gosec
+}
+
+// Random returns the 10-bit random (or node) component of id.
+func (id ID64) Random() int32 {
+	return int32(binary.BigEndian.Uint64(id[:]) & 0x3ff) //nolint:gosec
+}
+
+// Compare returns an integer comparing two ID64s with bytes.Compare
+// semantics.
+func (id ID64) Compare(other ID64) int {
+	return bytes.Compare(id[:], other[:])
+}
@@ -0,0 +1,106 @@
+package kid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNew64(t *testing.T) {
+	fixed := time.Date(2026, 7, 6, 12, 0, 0, 512_000, time.UTC)
+	g := NewGenerator(WithClock(func() time.Time { return fixed }), WithNode(0x2a5))
+	id := g.New64()
+	if got := id.Time(); !got.Equal(fixed.Truncate(time.Millisecond)) {
+		t.Errorf("Time() = %v, want %v", got, fixed.Truncate(time.Millisecond))
+	}
+	if got, want := id.Timestamp(), fixed.Sub(ID64Epoch).Milliseconds(); got != want {
+		t.Errorf("Timestamp() = %d, want %d", got, want)
+	}
+	if got, want := id.Sequence(), int32(512_000>>8); got != want {
+		t.Errorf("Sequence() = %d, want %d", got, want)
+	}
+	if got := id.Random(); got != 0x2a5 {
+		t.Errorf("Random() = %#x, want the node, 0x2a5", got)
+	}
+	// ID and ID64 share the generator's timestamp+sequence state
+	if next := g.New(); next.Sequence() != id.Sequence()+1 {
+		t.Errorf("New() after New64(): sequence %d, want %d", next.Sequence(), id.Sequence()+1)
+	}
+
+	// the 42-bit timestamp covers ID64Epoch to 2159
+	for _, at := range []time.Time{
+		ID64Epoch.Add(-time.Millisecond),
+		ID64Epoch.Add(time.Duration(1<<42) * time.Millisecond),
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrTimeRange {
+					t.Errorf("New64() at %v recovered %v, want ErrTimeRange", at, r)
+				}
+			}()
+			NewGenerator(WithClock(func() time.Time { return at })).New64()
+		}()
+	}
+
+	prev := New64()
+	for range 10000 {
+		id := New64()
+		if id.Compare(prev) <= 0 || id.Int64() <= prev.Int64() || id.String() <= prev.String() {
+			t.Fatalf("%v does not sort after %v in every form", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestID64Encoding(t *testing.T) {
+	for _, tt := range []struct {
+		id  ID64
+		str string
+	}{
+		{ID64{}, "0000000000000"},
+		{FromInt64(1), "0000000000001"},
+		{FromInt64(-1), "gzzzzzzzzzzzz"},
+		{ID64{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}, "028t5dy4tqlgg"},
+	} {
+		if got := tt.id.String(); got != tt.str {
+			t.Errorf("%x.String() = %s, want %s", tt.id[:], got, tt.str)
+		}
+		if got, err := FromString64(tt.str); err != nil || got != tt.id {
+			t.Errorf("FromString64(%s) = %x, %v, want %x", tt.str, got[:], err, tt.id[:])
+		}
+		if got := FromInt64(tt.id.Int64()); got != tt.id {
+			t.Errorf("FromInt64(Int64()) = %x, want %x", got[:], tt.id[:])
+		}
+	}
+	for _, s := range []string{"", "000000000000", "00000000000000", "h000000000000", "000000000000a", "000000000000B"} {
+		if id, err := FromString64(s); !errors.Is(err, ErrInvalidID) || !id.IsNil() {
+			t.Errorf("FromString64(%q) = %v, %v, want ErrInvalidID", s, id, err)
+		}
+	}
+}
+
+func TestID64Marshaling(t *testing.T) {
+	id := New64()
+	b, err := json.Marshal(struct{ ID ID64 }{id})
+	if err != nil || string(b) != `{"ID":"`+id.String()+`"}` {
+		t.Errorf("json.Marshal() = %s, %v", b, err)
+	}
+	var v struct{ ID ID64 }
+	if err := json.Unmarshal(b, &v); err != nil || v.ID != id {
+		t.Errorf("json.Unmarshal() = %v, %v, want %v", v.ID, err, id)
+	}
+	if err := json.Unmarshal([]byte(`{"ID":1234567890123}`), &v); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("json.Unmarshal(number) err = %v, want ErrInvalidID", err)
+	}
+}
+
+func BenchmarkNew64(b *testing.B) {
+	var r ID64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r = New64()
+		}
+		benchResultID64 = r
+	})
+}
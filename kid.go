@@ -5,7 +5,8 @@ byte binary, 16 bytes when base32 encoded), url-safe, k-sortable unique IDs.
 The 10-byte binary representation of an ID is composed of:
 
   - 6-byte value representing Unix time in milliseconds
-  - 2-byte sequence, and,
+  - 2-byte sequence field, holding a 12-bit sequence (0-4095) in its low
+    bits, and,
   - 2-byte random value (ChaCha8, seeded by the Go runtime from OS entropy).
 
 IDs encode (base32) as 16-byte URL-friendly strings. The encoding alphabet is
@@ -32,6 +33,11 @@ milliseconds: IDs remain unique and strictly k-sortable, but their embedded
 timestamps lead the wall clock until generation slows. Treat the embedded
 time as approximate metadata rather than an exact wall-clock instant.
 
+Generators: New draws from a package-level default Generator. NewGenerator
+creates independent Generators, each with its own timestamp+sequence state
+and, optionally, its own clock (WithClock) and random source (WithRandom).
+The uniqueness and ordering guarantees hold per Generator.
+
 Security note: an ID carries only 16 bits of randomness alongside values
 derived from the clock; IDs are predictable by design. Do not use kid IDs
 where unguessability matters, such as session tokens, API keys, or password
@@ -65,49 +71,46 @@ package kid
 
 import (
 	"bytes"
-	"database/sql/driver"
 	"errors"
 	"fmt"
-	mrand "math/rand/v2"
+	"io"
+	"iter"
 	"slices"
-	"sync/atomic"
 	"time"
 )
 
 // ID represents a unique identifier
 type ID [rawLen]byte
 
+//go:generate go run gentables.go
+
 const (
 	rawLen     = 10                                 // binary
 	encodedLen = 16                                 // base32
 	encoding   = "0123456789bcdefghjklmnpqrstvwxyz" // base32 encoding without: a,i,o,u
-	maxByte    = 0xFF                               // used as a sentinel value in charmap
+	maxByte    = 0xFF                               // used as a sentinel value in dec (tables.go)
+	maxMilli   = 1<<48 - 1                          // largest 6-byte timestamp
 )
 
 var (
-	nilID ID        // nilID represents the zero-value of an ID
-	dec   [256]byte // dec is the base32 decoding map
+	// Nil is the zero-value ID, encoded as "0000000000000000". It sorts
+	// before every other ID.
+	Nil ID
+
+	// Max is the highest possible ID, all bits set, encoded as
+	// "zzzzzzzzzzzzzzzz". It sorts after every other ID.
+	Max = ID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
 
 	// ErrInvalidID represents an error state, typically when decoding invalid input
 	ErrInvalidID = errors.New("kid: invalid id")
 )
 
-func init() {
-	// initialize the decoding map; used also for sanity checking input
-	for i := range len(dec) {
-		dec[i] = maxByte
-	}
-	for i := range len(encoding) {
-		dec[encoding[i]] = byte(i)
-	}
-}
-
 // New generates a new unique ID.
 //
 // This function is goroutine-safe. IDs are composed of:
 //
 //   - 6 bytes, timestamp, a Unix time in milliseconds
-//   - 2 bytes, sequence, a derived value ensuring uniqueness and order
+//   - 2 bytes, sequence, a derived 12-bit value ensuring uniqueness and order
 //   - 2 bytes, random value from math/rand/v2's ChaCha8 generator
 //
 // New is lock-free and free of retry loops: the timestamp+sequence is
@@ -119,29 +122,81 @@ func init() {
 //
 // K-orderable: Each subsequent call to New() is guaranteed to produce an ID
 // having a timestamp + sequence value greater than the previously generated ID.
-func New() (id ID) {
-	t, s := getTS() // milli << 12 + seq
-	// timestamp, 6 bytes, big endian
-	id[0] = byte(t >> 40)
-	id[1] = byte(t >> 32)
-	id[2] = byte(t >> 24)
-	id[3] = byte(t >> 16)
-	id[4] = byte(t >> 8)
-	id[5] = byte(t)
-	// sequence, 2 bytes, big endian
-	id[6] = byte(s >> 8)
-	id[7] = byte(s)
-	// Two random bytes from the runtime-seeded ChaCha8 generator; see the
-	// package documentation for the security properties of this choice.
-	r := mrand.Uint32()
-	id[8] = byte(r >> 8)
-	id[9] = byte(r)
+func New() ID {
+	return std.New()
+}
+
+// All returns an infinite iterator of fresh IDs from the default Generator,
+// for use with range over func:
+//
+//	for id := range kid.All() {
+//		if done(id) {
+//			break
+//		}
+//	}
+func All() iter.Seq[ID] {
+	return std.All()
+}
+
+// NewWithTime generates an ID whose timestamp is t rather than the current
+// time, from the default Generator; see Generator.NewWithTime.
+func NewWithTime(t time.Time) ID {
+	return std.NewWithTime(t)
+}
+
+// ErrTimeRange is returned by Generator.GenerateWithTime for a time outside
+// the range of the 6-byte timestamp, MinTime to MaxTime, and by
+// Generator.Generate when the clock reads before the Generator's epoch.
+var ErrTimeRange = errors.New("kid: time outside the timestamp range")
+
+// MinTime returns the earliest time an ID's timestamp can hold, the Unix
+// epoch. For a Generator configured WithEpoch, the range starts at its
+// epoch instead, extending as far past it.
+func MinTime() time.Time {
+	return time.UnixMilli(0).UTC()
+}
+
+// MaxTime returns the latest time an ID's timestamp can hold, 2^48-1
+// milliseconds after the Unix epoch, early in the year 10889. See MinTime.
+func MaxTime() time.Time {
+	return time.UnixMilli(maxMilli).UTC()
+}
+
+// FirstForTime returns the lowest possible ID having t's timestamp, its
+// sequence and random bytes all zeros. With LastForTime it bounds time-range
+// queries against a k-sorted ID column, in binary or encoded form:
+//
+//	WHERE id >= FirstForTime(from) AND id < FirstForTime(to)
+//	WHERE id >= FirstForTime(from) AND id <= LastForTime(to)
+//
+// Times before the Unix epoch clamp to the lowest timestamp, and times beyond
+// the 6-byte range to the highest.
+func FirstForTime(t time.Time) ID {
+	return forTimestamp(t.UnixMilli(), 0x00)
+}
+
+// LastForTime returns the highest possible ID having t's timestamp, its
+// sequence and random bytes all ones. See FirstForTime.
+func LastForTime(t time.Time) ID {
+	return forTimestamp(t.UnixMilli(), 0xff)
+}
+
+// forTimestamp returns the ID with timestamp milli, clamped to the 6-byte
+// range, and the remaining bytes set to fill.
+func forTimestamp(milli int64, fill byte) (id ID) {
+	milli = min(max(milli, 0), maxMilli)
+	for i := range 6 {
+		id[i] = byte(milli >> (40 - 8*i))
+	}
+	for i := 6; i < rawLen; i++ {
+		id[i] = fill
+	}
 	return id
 }
 
-// IsNil returns true if ID == nilID.
+// IsNil returns true if ID == Nil.
 func (id ID) IsNil() bool {
-	return id == nilID
+	return id == Nil
 }
 
 // IsZero is an alias of IsNil.
@@ -159,12 +214,59 @@ func (id ID) Encode(dst []byte) []byte {
 // String implements `fmt.Stringer`, returning id as a base32 encoded string
 // using the kid custom character set.
 // https://pkg.go.dev/fmt#Stringer
+//
+// String allocates once, for the returned string; AppendEncode into a reused
+// buffer does not allocate.
 func (id ID) String() string {
 	text := make([]byte, encodedLen)
 	encode(text, id[:])
 	return string(text)
 }
 
+// Format implements fmt.Formatter, so IDs print usefully with every common
+// verb rather than as the bytes of their encoding:
+//
+//	%s, %v  06bqer9xnm79tfnl
+//	%q      "06bqer9xnm79tfnl"
+//	%x, %X  019576e13dad0e9d3ab3, the binary ID in hex (flags apply as for []byte)
+//	%+v     the breakdown given by Display, in UTC
+//	%#v     kid.MustFromString("06bqer9xnm79tfnl")
+//
+// Width and other flags apply as for strings.
+func (id ID) Format(f fmt.State, verb rune) {
+	if verb == 's' || verb == 'v' && !f.Flag('+') && !f.Flag('#') {
+		_, wide := f.Width()
+		_, precise := f.Precision()
+		if !wide && !precise {
+			// the common case, written without a detour through Fprintf
+			text := make([]byte, encodedLen)
+			encode(text, id[:])
+			f.Write(text) //nolint:errcheck
+			return
+		}
+	}
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('+'):
+			io.WriteString(f, Display(id, nil))
+		case f.Flag('#'):
+			fmt.Fprintf(f, "kid.MustFromString(%q)", id.String())
+		default:
+			fmt.Fprintf(f, fmt.FormatString(f, 's'), id.String())
+		}
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), id.String())
+	case 'x', 'X':
+		// Slicing id itself would move it to the heap on every Format
+		// call; slicing a copy confines the allocation to this branch.
+		b := id
+		fmt.Fprintf(f, fmt.FormatString(f, verb), b[:])
+	default:
+		fmt.Fprintf(f, "%%!%c(kid.ID=%s)", verb, id.String())
+	}
+}
+
 // MarshalText implements `encoding.TextMarshaler`.
 //
 // As any ID value will always encode, error is always nil.
@@ -175,6 +277,50 @@ func (id ID) MarshalText() ([]byte, error) {
 	return text, nil
 }
 
+// AppendEncode appends the 16-byte encoded form of id to dst and returns the
+// extended buffer. With a reused buffer of sufficient capacity it does not
+// allocate, unlike String and MarshalText, for hot logging and serialization
+// paths:
+//
+//	buf = id.AppendEncode(buf[:0])
+func (id ID) AppendEncode(dst []byte) []byte {
+	dst = slices.Grow(dst, encodedLen)
+	n := len(dst)
+	dst = dst[:n+encodedLen]
+	encode(dst[n:], id[:])
+	return dst
+}
+
+// AppendText implements encoding.TextAppender, appending the encoded form of
+// id as AppendEncode does. The error is always nil.
+func (id ID) AppendText(b []byte) ([]byte, error) {
+	return id.AppendEncode(b), nil
+}
+
+// AppendBinary implements encoding.BinaryAppender, appending the 10 raw
+// bytes of id. The error is always nil.
+func (id ID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, id[:]...), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning the 10 raw
+// bytes of id, for gob, caches and other users of the compact binary form.
+// The error is always nil.
+func (id ID) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, rawLen))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, accepting exactly
+// 10 bytes; on error, id is set to the nil ID and ErrInvalidID is returned.
+func (id *ID) UnmarshalBinary(data []byte) error {
+	if len(data) != rawLen {
+		*id = Nil
+		return ErrInvalidID
+	}
+	copy(id[:], data)
+	return nil
+}
+
 // encode encodes id bytes by unrolling the stdlib base32 algorithm and removing
 // all safe checks for performance.
 //
@@ -207,7 +353,7 @@ func encode(dst, id []byte) {
 func FromBytes(b []byte) (ID, error) {
 	var id ID
 	if len(b) != rawLen {
-		return nilID, ErrInvalidID
+		return Nil, ErrInvalidID
 	}
 	copy(id[:], b)
 	return id, nil
@@ -220,22 +366,109 @@ func FromString(str string) (ID, error) {
 	return id, err
 }
 
+// looseDec is dec extended for FromStringLoose: upper case letters decode as
+// lower case, and the excluded letters i and o as their look-alikes 1 and 0.
+var looseDec = func() [256]byte {
+	d := dec
+	for c := 'a'; c <= 'z'; c++ {
+		d[c-'a'+'A'] = d[c]
+	}
+	d['i'], d['I'] = d['1'], d['1']
+	d['o'], d['O'] = d['0'], d['0']
+	return d
+}()
+
+// FromStringLoose decodes s like FromString, forgiving the ways IDs are
+// mangled by spreadsheets, databases and people: letters in either case, the
+// excluded letters i and o read as 1 and 0, and hyphens (as from
+// PrettyProfile) ignored. The strict FromString is unchanged; accept loose
+// input only where it comes from such sources.
+func FromStringLoose(s string) (ID, error) {
+	return decodeLoose(s, &looseDec)
+}
+
+// decodeLoose decodes s with the decoding table d, ignoring hyphens, which
+// do not count towards the length an *InvalidLengthError reports.
+func decodeLoose(s string, d *[256]byte) (ID, error) {
+	var text [encodedLen]byte
+	n := 0
+	for i := range len(s) {
+		c := s[i]
+		if c == '-' {
+			continue
+		}
+		v := d[c]
+		if v == maxByte {
+			return Nil, &InvalidCharError{Pos: i, Char: c}
+		}
+		if n < encodedLen {
+			text[n] = encoding[v]
+		}
+		n++
+	}
+	if n != encodedLen {
+		return Nil, &InvalidLengthError{Got: n, Want: encodedLen}
+	}
+	var id ID
+	decode(&id, text[:])
+	return id, nil
+}
+
+// MustFromString is like FromString but panics if s is not a valid encoded
+// ID. It simplifies initialization of package-level variables, fixtures and
+// tests:
+//
+//	var rootID = kid.MustFromString("06bqer9xnm79tfnl")
+func MustFromString(s string) ID {
+	id, err := FromString(s)
+	if err != nil {
+		panic(fmt.Errorf("%w: %q", err, s))
+	}
+	return id
+}
+
+// IsValid reports whether s is an encoded ID, one FromString would accept:
+// 16 characters over the kid alphabet. It does not allocate.
+func IsValid(s string) bool {
+	if len(s) != encodedLen {
+		return false
+	}
+	for i := range len(s) {
+		if dec[s[i]] == maxByte {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateASCII reports whether s is an encoded ID, exactly as IsValid does,
+// for extremely hot validation paths. The decoding-table entries of all 16
+// characters are combined, unrolled, with bitwise OR: alphabet entries are
+// below 32 and all others maxByte, so one test of the high bit validates the
+// lot, and the only branch is the length check. No canonical-form check is
+// needed, as any 16 characters of the alphabet encode exactly 80 bits.
+func ValidateASCII(s string) bool {
+	if len(s) != encodedLen {
+		return false
+	}
+	d := &dec
+	v := d[s[0]] | d[s[1]] | d[s[2]] | d[s[3]] |
+		d[s[4]] | d[s[5]] | d[s[6]] | d[s[7]] |
+		d[s[8]] | d[s[9]] | d[s[10]] | d[s[11]] |
+		d[s[12]] | d[s[13]] | d[s[14]] | d[s[15]]
+	return v&0x80 == 0
+}
+
 // UnmarshalText implements `encoding.TextUnmarshaler`. text must be a 16-byte
 // base32-encoded value over the kid alphabet; on error, id is set to the nil
-// ID and ErrInvalidID is returned.
+// ID and an *InvalidLengthError or *InvalidCharError, wrapping ErrInvalidID,
+// is returned.
 // https://pkg.go.dev/encoding#TextUnmarshaler
 func (id *ID) UnmarshalText(text []byte) error {
-	if len(text) != encodedLen {
-		*id = nilID
-		return ErrInvalidID
+	if len(text) != encodedLen || !decodePairs(id, text) {
+		*id = Nil
+		return textError(text, encodedLen, 0x1f)
 	}
-	for _, c := range text {
-		if dec[c] == maxByte {
-			*id = nilID
-			return ErrInvalidID
-		}
-	}
-	decode(id, text)
 	return nil
 }
 
@@ -245,7 +478,9 @@ func (id *ID) UnmarshalText(text []byte) error {
 // 10-byte ID, so every 16-character string over the kid alphabet is a valid
 // encoding. (Contrast xid, where 20 characters carry 100 bits against a
 // 96-bit ID and the final character must be range-checked.) Input length and
-// alphabet membership are enforced by UnmarshalText, the only caller.
+// alphabet membership are enforced by its callers, which decode input
+// already normalized to the alphabet; UnmarshalText uses decodePairs.
+// FuzzDecodeEncodeRoundTrip checks both against encode.
 func decode(id *ID, src []byte) {
 	_ = src[15] // bounds check hint
 
@@ -261,72 +496,61 @@ func decode(id *ID, src []byte) {
 	id[0] = dec[src[0]]<<3 | dec[src[1]]>>2
 }
 
-// Value implements package sql's driver.Valuer, returning the ID in its
-// 16-byte encoded string form, or nil for the nil ID.
-// https://pkg.go.dev/database/sql/driver#Valuer
-func (id ID) Value() (driver.Value, error) {
-	if id.IsNil() {
-		return nil, nil
-	}
-	return id.String(), nil
-}
-
-// Scan implements the sql.Scanner interface, accepting the 16-byte encoded
-// form as a string or []byte, the 10-byte binary form as a []byte, or nil,
-// which yields the nil ID.
-// https://pkg.go.dev/database/sql#Scanner
-func (id *ID) Scan(value any) error {
-	switch val := value.(type) {
-	case string:
-		return id.UnmarshalText([]byte(val))
-	case []byte:
-		if len(val) == rawLen {
-			copy(id[:], val)
-			return nil
-		}
-		return id.UnmarshalText(val)
-	case nil:
-		*id = nilID
-		return nil
-	default:
-		return fmt.Errorf("kid: scanning unsupported type: %T", value)
-	}
-}
-
 // MarshalJSON implements the json.Marshaler interface.
 //
-// A json value will always be returned; as a nilID or any other binary ID will
-// always encode, error will always be nil.
+// A json value will always be returned; as Nil or any other binary ID will
+// always encode, error will always be nil. It makes one allocation, the
+// returned slice, which encoding/json copies and discards; AppendJSON writes
+// into a reused buffer instead.
 //
 // https://golang.org/pkg/encoding/json/#Marshaler
 func (id ID) MarshalJSON() ([]byte, error) {
 	// endless loop if merely return json.Marshal(id)
-	if id == nilID {
-		return []byte("null"), nil
-	}
-	text := make([]byte, encodedLen+2) // +2 accounts for ""
-	encode(text[1:encodedLen+1], id[:])
-	text[0], text[encodedLen+1] = '"', '"'
+	return id.AppendJSON(make([]byte, 0, encodedLen+2)), nil // +2 accounts for ""
+}
 
-	return text, nil
+// AppendJSON appends the JSON form of id, as MarshalJSON returns it, to dst
+// and returns the extended buffer, for encoders that write JSON into pooled
+// or reused buffers:
+//
+//	buf = id.AppendJSON(buf[:0])
+func (id ID) AppendJSON(dst []byte) []byte {
+	if id == Nil {
+		return append(dst, "null"...)
+	}
+	dst = slices.Grow(dst, encodedLen+2)
+	n := len(dst)
+	dst = dst[:n+encodedLen+2]
+	dst[n] = '"'
+	encode(dst[n+1:], id[:])
+	dst[n+encodedLen+1] = '"'
+	return dst
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface, accepting only
-// null or a quoted 16-character kid encoding.
+// null or a quoted 16-character kid encoding. Errors are as for
+// UnmarshalText, or ErrInvalidID if b is not a JSON string.
 // https://golang.org/pkg/encoding/json/#Unmarshaler
+//
+// It does not allocate on success: b is compared and decoded in place.
 func (id *ID) UnmarshalJSON(b []byte) error {
-	if string(b) == "null" {
-		*id = nilID
+	switch {
+	case len(b) == encodedLen+2:
+		// Only a quoted string is acceptable. Without the quote check, a
+		// bare JSON number of the right length would be accepted, as digits
+		// are valid characters in the kid alphabet.
+		if b[0] == '"' && b[encodedLen+1] == '"' && decodePairs(id, b[1:encodedLen+1]) {
+			return nil
+		}
+	case string(b) == "null":
+		*id = Nil
 		return nil
 	}
-	// Only a quoted string is acceptable. Without the quote check, a bare
-	// JSON number of the right length would be accepted, as digits are valid
-	// characters in the kid alphabet.
-	if len(b) != encodedLen+2 || b[0] != '"' || b[len(b)-1] != '"' {
-		*id = nilID
-		return ErrInvalidID
+	*id = Nil
+	if len(b) >= 2 && b[0] == '"' && b[len(b)-1] == '"' {
+		return textError(b[1:len(b)-1], encodedLen, 0x1f)
 	}
-	return id.UnmarshalText(b[1 : len(b)-1])
+	return ErrInvalidID
 }
 
 // Bytes returns the binary representation of id, which is simply id[:].
@@ -335,7 +559,8 @@ func (id ID) Bytes() []byte {
 }
 
 // Timestamp returns the timestamp component of id as milliseconds since the
-// Unix epoch. Go timestamps are at location UTC.
+// Unix epoch, or since the custom epoch of the Generator that produced it
+// (see WithEpoch).
 func (id ID) Timestamp() int64 {
 	b := id[0:6]
 	// Big Endian, no overflow possible
@@ -348,19 +573,75 @@ func (id ID) Time() time.Time {
 	return time.UnixMilli(id.Timestamp()).UTC()
 }
 
-// Sequence returns the sequence component of id.
+// InRange reports whether id's timestamp, read as by Time, falls within the
+// half-open interval [from, to), as the query
+//
+//	WHERE id >= FirstForTime(from) AND id < FirstForTime(to)
+//
+// selects. Times are truncated to the millisecond.
+func (id ID) InRange(from, to time.Time) bool {
+	ts := id.Timestamp()
+	return ts >= from.UnixMilli() && ts < to.UnixMilli()
+}
+
+// TimeWithEpoch returns the timestamp of id as a Time with millisecond
+// resolution relative to epoch rather than the Unix epoch, for IDs from a
+// Generator configured WithEpoch. Location is set to UTC.
+func (id ID) TimeWithEpoch(epoch time.Time) time.Time {
+	// in milliseconds rather than a Duration, which spans only 292 years
+	sub := time.Duration(epoch.Nanosecond() % nanoPerMilli)
+	return time.UnixMilli(epoch.UnixMilli() + id.Timestamp()).Add(sub).UTC()
+}
+
+// Display returns a one-line breakdown of id for inspection, with its
+// timestamp presented in loc (UTC if loc is nil):
+//
+//	06bqer9xnm79tfnl ts:1741456227757 seq:3741 rnd:15027 2025-03-08 09:50:27.757 -0800 PST
 //
-// For IDs produced by New, the sequence is a 12-bit value (0-4095); if a
-// burst of calls would overflow the sequence within a single millisecond, the
-// overflow carries into the timestamp, preserving order (see getTS). The
-// field occupies two bytes, so IDs from other sources may carry larger
-// values.
+// The location affects presentation only; the embedded timestamp is always
+// milliseconds since the Unix epoch.
+func Display(id ID, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return fmt.Sprintf("%s ts:%d seq:%4d rnd:%5d %s", id,
+		id.Timestamp(), id.Sequence(), id.Random(), id.Time().In(loc))
+}
+
+// MaxSequence is the largest sequence a Generator issues: the sequence is a
+// 12-bit value held in the low bits of the 2-byte sequence field, whose top
+// 4 bits a Generator leaves zero.
+const MaxSequence = 1<<12 - 1
+
+// Sequence returns the whole 2-byte sequence field of id.
+//
+// For IDs produced by New, the sequence is a 12-bit value (0-MaxSequence);
+// if a burst of calls would overflow the sequence within a single
+// millisecond, the overflow carries into the timestamp, preserving order
+// (see getTS). The field occupies two bytes, so IDs from other sources may
+// carry larger values; Sequence12 and SequenceSpare split the field at the
+// 12 bits a Generator uses.
 func (id ID) Sequence() int32 {
 	b := id[6:8]
 	// Big Endian
 	return int32(uint32(b[0])<<8 | uint32(b[1])) //nolint:gosec
 }
 
+// Sequence12 returns the low 12 bits of id's sequence field, the sequence
+// as a Generator issues it (0-MaxSequence). It equals Sequence for every ID
+// whose SequenceSpare is zero.
+func (id ID) Sequence12() uint16 {
+	return uint16(id[6]&0x0f)<<8 | uint16(id[7])
+}
+
+// SequenceSpare returns the top 4 bits of id's sequence field, which are
+// zero in every ID a Generator issues. Other IDs, such as Max or results of
+// Next arithmetic, may set them; ID.UUID has no room for them, so such IDs
+// do not round-trip through FromUUIDv7.
+func (id ID) SequenceSpare() uint8 {
+	return id[6] >> 4
+}
+
 // Random returns the two-byte random component of the ID.
 func (id ID) Random() int32 {
 	b := id[8:]
@@ -377,58 +658,64 @@ func (id ID) Compare(other ID) int {
 	return bytes.Compare(id[:], other[:])
 }
 
-// Sort sorts a slice of IDs in place, in ascending order.
-func Sort(ids []ID) {
-	slices.SortFunc(ids, ID.Compare)
+// Less reports whether id sorts before other, Compare(other) < 0, for APIs
+// taking a less function such as sort.Slice or B-tree libraries;
+// slices.SortFunc takes Compare itself.
+func (id ID) Less(other ID) bool {
+	return id.Compare(other) < 0
 }
 
-// getTS provides the basis of ID timestamp uniqueness; the time encoding is
-// borrowed from getV7Time, converted from mutex protection to a lock-free
-// compare-and-swap:
-// https://github.com/google/uuid/blob/2d3c2a9cc518326daf99a383f07c4d3c44317e4d/version7.go#L88
-
-var (
-	// lastTime is the last ts+seq we returned stored as:
-	//
-	//	52 bits of time in milliseconds since epoch
-	//	12 bits of (fractional nanoseconds) >> 8
-	lastTime atomic.Int64
-	timeNow  = time.Now // for testing
-)
-
-const nanoPerMilli = 1000000
+// Equal reports whether id and other are the same ID, as id == other does;
+// it suits comparison helpers, such as go-cmp, that look for an Equal
+// method.
+func (id ID) Equal(other ID) bool {
+	return id == other
+}
 
-// getTS returns:
-// - the number of milliseconds elapsed since January 1, 1970 UTC, and,
-// - a sequence value
-//
-// The fast path claims a clock-derived value with a single compare-and-swap;
-// if the clock is not ahead of the last issued value, or the swap loses a
-// race, getTS instead claims the next sequence slot with a wait-free atomic
-// increment. Both operations strictly increase lastTime and return exactly
-// the value they installed, so every call — across all goroutines — returns
-// a (milli << 12 + seq) strictly greater than that of any previous call,
-// even if the wall clock steps backwards. There is no retry loop: under
-// contention every caller completes in a bounded number of atomic
-// operations, which avoids CAS retry storms on hardware where the shared
-// cache line is expensive to bounce (notably multi-cluster arm64 CPUs such
-// as Apple silicon). The clock path re-synchronizes the timestamp to real
-// time whenever the wall clock is ahead.
+// Next returns the smallest ID greater than id, treating the 10 bytes as a
+// big-endian integer, for turning inclusive bounds into exclusive ones in
+// keyset pagination: "id > cursor" selects the same rows as
+// "id >= cursor.Next()".
 //
-// Note: At time of writing, the available timer resolution provided by the Go
-// runtime, operating system and hardware can vary from < 1ms to several ms.
-// https://pkg.go.dev/time#hdr-Timer_Resolution
-func getTS() (milli, seq int64) {
-	nano := timeNow().UnixNano()
-	milli = nano / nanoPerMilli
-	// Sequence number is between 0 and 3906 (nanoPerMilli>>8)
-	seq = (nano - milli*nanoPerMilli) >> 8
-	now := milli<<12 + seq
-	if last := lastTime.Load(); now > last && lastTime.CompareAndSwap(last, now) {
-		return milli, seq
+// Like unsigned integer arithmetic, Max.Next() wraps around to Nil.
+func (id ID) Next() ID {
+	for i := rawLen - 1; i >= 0; i-- {
+		id[i]++
+		if id[i] != 0 {
+			break
+		}
 	}
-	// The wall clock is not ahead, or another goroutine won the race:
-	// claim the next slot wait-free.
-	now = lastTime.Add(1)
-	return now >> 12, now & 0xfff
+	return id
+}
+
+// Prev returns the largest ID less than id; see Next. Nil.Prev() wraps
+// around to Max.
+func (id ID) Prev() ID {
+	for i := rawLen - 1; i >= 0; i-- {
+		id[i]--
+		if id[i] != 0xff {
+			break
+		}
+	}
+	return id
+}
+
+// Sort sorts a slice of IDs in place, in ascending order. Equal IDs are
+// identical, so there is no need for a stable variant.
+func Sort(ids []ID) {
+	slices.SortFunc(ids, ID.Compare)
+}
+
+// IsSorted reports whether ids is in ascending order, as Sort leaves it;
+// duplicates are allowed. Use it to verify the k-ordering of a batch.
+func IsSorted(ids []ID) bool {
+	return slices.IsSortedFunc(ids, ID.Compare)
+}
+
+// SortStrings sorts encoded IDs in place, in ascending order, without
+// decoding them: the kid alphabet is in ASCII order, so encoded IDs sort
+// as the IDs do. Strings that are not valid IDs are sorted byte-wise
+// among them.
+func SortStrings(ids []string) {
+	slices.Sort(ids)
 }
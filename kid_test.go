@@ -3,10 +3,13 @@ package kid
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/gob"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -185,13 +188,26 @@ func TestInvalid(t *testing.T) {
 			if err == nil {
 				t.Errorf("invalid encoded %v, FromString() should be err", v.encoded)
 			}
-			if id != nilID {
-				t.Errorf("invalid encoded %v returned %v, FromString() should return nilID", v.encoded, v.id[:])
+			if id != Nil {
+				t.Errorf("invalid encoded %v returned %v, FromString() should return Nil", v.encoded, v.id[:])
 			}
 		})
 	}
 }
 
+func TestNilMax(t *testing.T) {
+	if got, want := Nil.String(), "0000000000000000"; got != want {
+		t.Errorf("Nil.String() = %s, want %s", got, want)
+	}
+	if got, want := Max.String(), "zzzzzzzzzzzzzzzz"; got != want {
+		t.Errorf("Max.String() = %s, want %s", got, want)
+	}
+	id := New()
+	if Nil.Compare(id) >= 0 || Max.Compare(id) <= 0 {
+		t.Errorf("%v not within (Nil, Max)", id)
+	}
+}
+
 func TestIDComponents(t *testing.T) {
 	for i, v := range tests {
 		if v.iskid {
@@ -222,6 +238,9 @@ func TestSequence(t *testing.T) {
 		check = append(check, New())
 	}
 	for _, id := range check {
+		if id.SequenceSpare() != 0 || int32(id.Sequence12()) != id.Sequence() || id.Sequence() > MaxSequence {
+			t.Fatalf("%v: sequence field %#04x beyond 12 bits", id, id.Sequence())
+		}
 		if lastTS != id.Timestamp() {
 			lastTS = id.Timestamp()
 			lastSeq = id.Sequence()
@@ -235,10 +254,110 @@ func TestSequence(t *testing.T) {
 	}
 }
 
+func TestSequenceFields(t *testing.T) {
+	for _, tt := range []struct {
+		id    ID
+		seq12 uint16
+		spare uint8
+	}{
+		{tests[6].id, 3741, 0},
+		{Nil, 0, 0},
+		{Max, MaxSequence, 0xf},
+		{ID{6: 0x1f, 7: 0xff}, MaxSequence, 1},
+		{ID{6: 0x10}, 0, 1},
+	} {
+		if got := tt.id.Sequence12(); got != tt.seq12 {
+			t.Errorf("%v.Sequence12() = %d, want %d", tt.id, got, tt.seq12)
+		}
+		if got := tt.id.SequenceSpare(); got != tt.spare {
+			t.Errorf("%v.SequenceSpare() = %d, want %d", tt.id, got, tt.spare)
+		}
+		if got := int32(tt.spare)<<12 | int32(tt.seq12); got != tt.id.Sequence() {
+			t.Errorf("%v: fields join to %d, Sequence() = %d", tt.id, got, tt.id.Sequence())
+		}
+	}
+}
+
 func TestIDTime(t *testing.T) {
 	nilTime := "1970-01-01 00:00:00 +0000 UTC"
-	if nilID.Time().String() != nilTime {
-		t.Errorf("got: %s, want:%s", nilID.Time(), nilTime)
+	if Nil.Time().String() != nilTime {
+		t.Errorf("got: %s, want:%s", Nil.Time(), nilTime)
+	}
+	if !Nil.Time().Equal(MinTime()) || !Max.Time().Equal(MaxTime()) {
+		t.Errorf("MinTime(), MaxTime() = %v, %v, want %v, %v", MinTime(), MaxTime(), Nil.Time(), Max.Time())
+	}
+}
+
+func TestIDInRange(t *testing.T) {
+	id := tests[6].id // 2025-03-08 17:50:27.757
+	at := id.Time()
+	for _, tt := range []struct {
+		from, to time.Time
+		want     bool
+	}{
+		{at, at.Add(time.Millisecond), true},
+		{at.Add(-time.Hour), at.Add(time.Hour), true},
+		{at.Add(999 * time.Microsecond), at.Add(time.Hour), true}, // truncated to the millisecond
+		{at.Add(-time.Hour), at, false},                           // to is exclusive
+		{at.Add(time.Millisecond), at.Add(time.Hour), false},
+		{at.Add(time.Hour), at.Add(-time.Hour), false},
+	} {
+		if got := id.InRange(tt.from, tt.to); got != tt.want {
+			t.Errorf("InRange(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+		// consistent with the FirstForTime bounds
+		if got := id.Compare(FirstForTime(tt.from)) >= 0 && id.Compare(FirstForTime(tt.to)) < 0; got != tt.want {
+			t.Errorf("FirstForTime bounds for [%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestForTime(t *testing.T) {
+	tm := time.Date(2025, 3, 8, 17, 50, 27, 757_400_000, time.UTC)
+	first, last := FirstForTime(tm), LastForTime(tm)
+	if got, want := first.String(), "06bqer9xnm000000"; got != want {
+		t.Errorf("FirstForTime() = %s, want %s", got, want)
+	}
+	if got, want := last.String(), "06bqer9xnqzzzzzz"; got != want {
+		t.Errorf("LastForTime() = %s, want %s", got, want)
+	}
+	// every ID generated in the millisecond falls within the bounds
+	g := NewGenerator(WithClock(func() time.Time { return tm }))
+	for range 100 {
+		if id := g.New(); id.Compare(first) < 0 || id.Compare(last) > 0 {
+			t.Fatalf("%v outside [%v, %v]", id, first, last)
+		}
+	}
+	// the next millisecond starts after
+	if next := FirstForTime(tm.Add(time.Millisecond)); next.Compare(last) <= 0 {
+		t.Errorf("FirstForTime(next ms) = %v, want after %v", next, last)
+	}
+	// out of range times clamp
+	if id := FirstForTime(time.Unix(-1, 0)); id != Nil {
+		t.Errorf("FirstForTime(before epoch) = %v, want %v", id, Nil)
+	}
+	if id := LastForTime(time.Date(20000, 1, 1, 0, 0, 0, 0, time.UTC)); id != Max {
+		t.Errorf("LastForTime(year 20000) = %v, want %v", id, Max)
+	}
+
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ge := NewGenerator(WithEpoch(epoch), WithClock(func() time.Time { return tm }))
+	if id := ge.New(); id.Compare(ge.FirstForTime(tm)) < 0 || id.Compare(ge.LastForTime(tm)) > 0 {
+		t.Errorf("%v outside Generator bounds [%v, %v]", id, ge.FirstForTime(tm), ge.LastForTime(tm))
+	}
+	if id := ge.FirstForTime(epoch.Add(-time.Hour)); id != Nil {
+		t.Errorf("Generator.FirstForTime(before epoch) = %v, want %v", id, Nil)
+	}
+}
+
+func TestDisplay(t *testing.T) {
+	id := tests[6].id // 06bqer9xnm79tfnl
+	if got, want := Display(id, nil), "06bqer9xnm79tfnl ts:1741456227757 seq:3741 rnd:15027 2025-03-08 17:50:27.757 +0000 UTC"; got != want {
+		t.Errorf("Display(nil) = %q, want %q", got, want)
+	}
+	pst := time.FixedZone("PST", -8*60*60)
+	if got, want := Display(id, pst), "06bqer9xnm79tfnl ts:1741456227757 seq:3741 rnd:15027 2025-03-08 09:50:27.757 -0800 PST"; got != want {
+		t.Errorf("Display(PST) = %q, want %q", got, want)
 	}
 }
 
@@ -260,6 +379,110 @@ func TestIDEncode(t *testing.T) {
 	}
 }
 
+func TestIDAppend(t *testing.T) {
+	id := tests[6].id
+	buf := []byte("id=")
+	if got := string(id.AppendEncode(buf)); got != "id=06bqer9xnm79tfnl" {
+		t.Errorf("AppendEncode() = %q, want %q", got, "id=06bqer9xnm79tfnl")
+	}
+	if got, err := id.AppendText(nil); err != nil || string(got) != id.String() {
+		t.Errorf("AppendText(nil) = %q, %v, want %q", got, err, id.String())
+	}
+	if got, err := id.AppendBinary([]byte{0xff}); err != nil || !bytes.Equal(got, append([]byte{0xff}, id[:]...)) {
+		t.Errorf("AppendBinary() = %x, %v", got, err)
+	}
+	buf = make([]byte, 0, 64)
+	if n := testing.AllocsPerRun(100, func() {
+		buf = id.AppendEncode(buf[:0])
+		buf, _ = id.AppendBinary(buf)
+	}); n != 0 {
+		t.Errorf("AppendEncode() into a reused buffer: %v allocs, want 0", n)
+	}
+}
+
+func TestIDAllocs(t *testing.T) {
+	id := tests[6].id
+	buf := make([]byte, 0, 64)
+	quoted, null := []byte(`"06bqer9xnm79tfnl"`), []byte("null")
+	for _, tt := range []struct {
+		name string
+		f    func()
+		max  float64
+	}{
+		{"String", func() { benchResultString = id.String() }, 1},
+		{"New().String", func() { benchResultString = New().String() }, 1},
+		{"AppendText", func() { buf, _ = id.AppendText(buf[:0]) }, 0},
+		{"MarshalJSON", func() { buf, _ = id.MarshalJSON() }, 1},
+		{"AppendJSON", func() { buf = id.AppendJSON(buf[:0]) }, 0},
+		{"UnmarshalJSON", func() { benchResultBool = new(ID).UnmarshalJSON(quoted) == nil }, 0},
+		{"UnmarshalJSON null", func() { benchResultBool = new(ID).UnmarshalJSON(null) == nil }, 0},
+		// the encoded ID, and the boxing of id as an interface value
+		{"Fprint", func() { fmt.Fprint(io.Discard, id) }, 2},
+		{"Fprintf %s", func() { fmt.Fprintf(io.Discard, "%s", id) }, 2},
+	} {
+		if n := testing.AllocsPerRun(100, tt.f); n > tt.max {
+			t.Errorf("%s: %v allocs, want at most %v", tt.name, n, tt.max)
+		}
+	}
+}
+
+func TestIDFormat(t *testing.T) {
+	id := tests[6].id
+	for _, tt := range []struct {
+		format, want string
+	}{
+		{"%s", "06bqer9xnm79tfnl"},
+		{"%v", "06bqer9xnm79tfnl"},
+		{"%20v|", "    06bqer9xnm79tfnl|"},
+		{"%-18s|", "06bqer9xnm79tfnl  |"},
+		{"%q", `"06bqer9xnm79tfnl"`},
+		{"%x", "019576e13dad0e9d3ab3"},
+		{"%X", "019576E13DAD0E9D3AB3"},
+		{"%#x", "0x019576e13dad0e9d3ab3"},
+		{"%+v", Display(id, nil)},
+		{"%#v", `kid.MustFromString("06bqer9xnm79tfnl")`},
+		{"%d", "%!d(kid.ID=06bqer9xnm79tfnl)"},
+	} {
+		if got := fmt.Sprintf(tt.format, id); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+	// IDs in slices format the same way
+	if got := fmt.Sprintf("%x", []ID{id, Max}); got != "[019576e13dad0e9d3ab3 ffffffffffffffffffff]" {
+		t.Errorf("Sprintf(%%x, []ID) = %s", got)
+	}
+}
+
+func TestIDMarshalBinary(t *testing.T) {
+	id := tests[6].id
+	b, err := id.MarshalBinary()
+	if err != nil || !bytes.Equal(b, id[:]) {
+		t.Errorf("MarshalBinary() = %x, %v, want %x", b, err, id[:])
+	}
+	var got ID
+	if err := got.UnmarshalBinary(b); err != nil || got != id {
+		t.Errorf("UnmarshalBinary() = %v, %v, want %v", got, err, id)
+	}
+	for _, b := range [][]byte{nil, id[:9], append(id[:], 0)} {
+		got := id
+		if err := got.UnmarshalBinary(b); err != ErrInvalidID || got != Nil {
+			t.Errorf("UnmarshalBinary(%x) = %v, %v, want ErrInvalidID", b, got, err)
+		}
+	}
+
+	// gob uses the binary form
+	type record struct{ ID, Parent ID }
+	in := record{New(), id}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil || out != in {
+		t.Errorf("gob round trip = %v, %v, want %v", out, err, in)
+	}
+}
+
 func TestFromString(t *testing.T) {
 	// 06bprdfln4x281hd ts:1741276959657 seq:14884 rnd: 1548 2025-03-06 16:02:39.657 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x31, 0xd3, 0xa9, 0x3a, 0x24,  0x6,  0xc }
 	got, err := FromString("06bprdfln4x281hd")
@@ -292,15 +515,89 @@ func TestFromString(t *testing.T) {
 
 func TestFromStringInvalid(t *testing.T) {
 	_, err := FromString("012345")
-	if err != ErrInvalidID {
+	if !errors.Is(err, ErrInvalidID) {
 		t.Errorf("FromString(invalid length) err=%v, want %v", err, ErrInvalidID)
 	}
 	id, err := FromString("062ez870acdtzd2y3qajilou") // i, l, o, u never in our IDs
-	if err != ErrInvalidID {
+	if !errors.Is(err, ErrInvalidID) {
 		t.Errorf("FromString(062ez870acdtzd2y3qajilou - invalid chars) err=%v, want %v", err, ErrInvalidID)
 	}
-	if id != nilID {
-		t.Errorf("FromString() =%v, there want %v", id, nilID)
+	if id != Nil {
+		t.Errorf("FromString() =%v, there want %v", id, Nil)
+	}
+}
+
+func TestFromStringLoose(t *testing.T) {
+	want := MustFromString("06bqer9x1m70tfnl")
+	for _, s := range []string{
+		"06bqer9x1m70tfnl",
+		"06BQER9X1M70TFNL",
+		"06bQeR9xIm7OtfnL",
+		"o6bq-er9x-im7o-tfnl",
+	} {
+		if got, err := FromStringLoose(s); err != nil || got != want {
+			t.Errorf("FromStringLoose(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "06bqer9x1m70tfn", "06bqer9x1m70tfnl0", "06bqer9x1m70tfna", "06bqer9x1m70tfnu", "06bqer9x 1m70tfnl"} {
+		if got, err := FromStringLoose(s); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("FromStringLoose(%q) = %v, %v, want ErrInvalidID", s, got, err)
+		}
+	}
+	// the strict path still rejects loose input
+	if _, err := FromString("06BQER9X1M70TFNL"); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("FromString(upper case) err = %v, want ErrInvalidID", err)
+	}
+}
+
+func TestValidateASCII(t *testing.T) {
+	// agrees with IsValid for every single-character substitution
+	valid := []byte("06bqer9xnm79tfnl")
+	for pos := range valid {
+		s := slices.Clone(valid)
+		for c := range 256 {
+			s[pos] = byte(c)
+			if got, want := ValidateASCII(string(s)), IsValid(string(s)); got != want {
+				t.Fatalf("ValidateASCII(%q) = %v, IsValid() = %v", s, got, want)
+			}
+		}
+	}
+	for _, s := range []string{"", "06bqer9xnm79tfn", "06bqer9xnm79tfnl0"} {
+		if ValidateASCII(s) {
+			t.Errorf("ValidateASCII(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestMustFromString(t *testing.T) {
+	if got, want := MustFromString("06bqer9xnm79tfnl"), tests[6].id; got != want {
+		t.Errorf("MustFromString() = %v, want %v", got, want)
+	}
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrInvalidID) {
+			t.Errorf("MustFromString(invalid): recover() = %v, want ErrInvalidID panic", r)
+		}
+	}()
+	MustFromString("06BQER9XNM79TFNL")
+}
+
+func TestIsValid(t *testing.T) {
+	for _, v := range tests {
+		if got := IsValid(v.encoded); got != v.iskid {
+			t.Errorf("IsValid(%q) = %v, want %v", v.encoded, got, v.iskid)
+		}
+	}
+	for _, s := range []string{"", "06bqer9xnm79tfn", "06bqer9xnm79tfnl0", "06BQER9XNM79TFNL", "06bqer9xnm79tfna", "06bqer9xnm79tfn\xff"} {
+		if IsValid(s) {
+			t.Errorf("IsValid(%q) = true, want false", s)
+		}
+		if _, err := FromString(s); err == nil {
+			t.Errorf("FromString(%q) accepted input IsValid rejects", s)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() { IsValid("06bqer9xnm79tfnl") }); n != 0 {
+		t.Errorf("IsValid allocates %v times, want 0", n)
 	}
 }
 
@@ -320,15 +617,15 @@ func TestID_UnmarshalText(t *testing.T) {
 		{ // zzzzzzzzzzzzzzzz ts:281474976710655 seq:65535 rnd:65535 10889-08-02 05:31:50.655 +0000 UTC ID{ 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff }
 			"valid", "zzzzzzzzzzzzzzzz", ID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, false,
 		},
-		{"invalid chars", "000000000000000u", nilID, true},
-		{"invalid length too long", "12345678901", nilID, true},
-		{"invalid length too short", "dfb7emm", nilID, true},
+		{"invalid chars", "000000000000000u", Nil, true},
+		{"invalid length too long", "12345678901", Nil, true},
+		{"invalid length too short", "dfb7emm", Nil, true},
 		{ // 06bprg666xzm7hpg ts:1741277677111 seq:32579 rnd:49871 2025-03-06 16:14:37.111 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf }
 			"valid id", "06bprg666xzm7hpg", ID{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// pre-fill so the error path's reset-to-nilID is actually exercised
+			// pre-fill so the error path's reset-to-Nil is actually exercised
 			id := ID{0xde, 0xca, 0xfb, 0xad, 0xde, 0xca, 0xfb, 0xad, 0xde, 0xca}
 			err := id.UnmarshalText([]byte(tt.encoded))
 			if (err != nil) != tt.wantErr {
@@ -336,8 +633,8 @@ func TestID_UnmarshalText(t *testing.T) {
 			}
 			if err != nil {
 				// on error, id must be reset to the nil ID
-				if id != nilID {
-					t.Errorf("ID.UnmarshalText(%s) got: %v, want nilID %v", tt.encoded, id, nilID)
+				if id != Nil {
+					t.Errorf("ID.UnmarshalText(%s) got: %v, want Nil %v", tt.encoded, id, Nil)
 				}
 				return
 			}
@@ -351,11 +648,11 @@ func TestID_UnmarshalText(t *testing.T) {
 		})
 	}
 	id := ID{}
-	if err := id.UnmarshalText([]byte("decafebad")); err != ErrInvalidID {
+	if err := id.UnmarshalText([]byte("decafebad")); !errors.Is(err, ErrInvalidID) {
 		t.Errorf("ID.UnmarshalText(\"foo\" got: %v, want err", err)
 	}
 	if err := id.UnmarshalText([]byte("decafebad")); err != nil && !id.IsNil() {
-		t.Errorf("ID.UnmarshalText(\"foo\") got: %v, want %v", id, nilID)
+		t.Errorf("ID.UnmarshalText(\"foo\") got: %v, want %v", id, Nil)
 	}
 }
 
@@ -370,8 +667,8 @@ func TestFromBytes_Invariant(t *testing.T) {
 	}
 	// invalid
 	got, err = FromBytes([]byte{0x1, 0x2})
-	if !bytes.Equal(got[:], nilID[:]) {
-		t.Error("FromBytes([]byte{0x1, 0x2}) - invalid - != nilID")
+	if !bytes.Equal(got[:], Nil[:]) {
+		t.Error("FromBytes([]byte{0x1, 0x2}) - invalid - != Nil")
 	}
 	if err == nil {
 		t.Fatal(err)
@@ -389,7 +686,7 @@ func TestIDMarshalJSON(t *testing.T) {
 	if err != nil {
 		t.Error("id.MarshalJSON()", err)
 	}
-	if id == nilID && !reflect.DeepEqual(string(got), "null") {
+	if id == Nil && !reflect.DeepEqual(string(got), "null") {
 		t.Errorf("got: %v, want: \"null\"", string(got))
 	}
 	// 06bprg666xzm7hpg ts:1741277677111 seq:32579 rnd:49871 2025-03-06 16:14:37.111 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf }
@@ -404,10 +701,30 @@ func TestIDMarshalJSON(t *testing.T) {
 	}
 }
 
+func TestIDAppendJSON(t *testing.T) {
+	id := tests[6].id
+	if got, want := string(id.AppendJSON([]byte("["))), `["06bqer9xnm79tfnl"`; got != want {
+		t.Errorf("AppendJSON() = %s, want %s", got, want)
+	}
+	if got, want := string(Nil.AppendJSON([]byte("["))), `[null`; got != want {
+		t.Errorf("Nil.AppendJSON() = %s, want %s", got, want)
+	}
+	for _, v := range []ID{id, Nil, Max} {
+		m, _ := v.MarshalJSON()
+		if a := v.AppendJSON(nil); !bytes.Equal(a, m) {
+			t.Errorf("AppendJSON(nil) = %s, MarshalJSON() = %s", a, m)
+		}
+		var back ID
+		if err := back.UnmarshalJSON(m); err != nil || back != v {
+			t.Errorf("UnmarshalJSON(%s) = %v, %v, want %v", m, back, err, v)
+		}
+	}
+}
+
 func TestIDUnmarshalJSON(t *testing.T) {
 	id := ID{}
-	if err := id.UnmarshalJSON([]byte("null")); err != nil || id != nilID {
-		t.Errorf("id.UnmarshalJSON(\"null\") returns %v, %v, want nilID, nil", id, err)
+	if err := id.UnmarshalJSON([]byte("null")); err != nil || id != Nil {
+		t.Errorf("id.UnmarshalJSON(\"null\") returns %v, %v, want Nil, nil", id, err)
 	}
 	// 06bprg666xzm7hpg ts:1741277677111 seq:32579 rnd:49871 2025-03-06 16:14:37.111 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf }
 	data := []byte(`{"ID":"06bprg666xzm7hpg","Str":"valid"}`)
@@ -427,17 +744,17 @@ func TestIDUnmarshalJSON_Error(t *testing.T) {
 	// callers are responsible for forcing lower case input for Base32
 	// otherwise valid id:
 	err := json.Unmarshal([]byte(`{"ID":"06BPRG666XZM7HPG"}`), &v)
-	if err != ErrInvalidID {
+	if !errors.Is(err, ErrInvalidID) {
 		t.Errorf("json.Unmarshal() err=%v, want %v", err, ErrInvalidID)
 	}
 	// too short
 	err = json.Unmarshal([]byte(`{"ID":"06bprg666xzm"}`), &v)
-	if err != ErrInvalidID {
+	if !errors.Is(err, ErrInvalidID) {
 		t.Errorf("json.Unmarshal() err=%v, want %v", err, ErrInvalidID)
 	}
 	// no 'a' in character set
 	err = json.Unmarshal([]byte(`{"ID":"0000000000000a"}`), &v)
-	if err != ErrInvalidID {
+	if !errors.Is(err, ErrInvalidID) {
 		t.Errorf("json.Unmarshal() err=%v, want %v", err, ErrInvalidID)
 	}
 	// invalid on multiple levels
@@ -447,84 +764,6 @@ func TestIDUnmarshalJSON_Error(t *testing.T) {
 	}
 }
 
-func TestIDDriverValue(t *testing.T) {
-	// 06bprg666xzm7hpg ts:1741277677111 seq:32579 rnd:49871 2025-03-06 16:14:37.111 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf }
-	id := ID{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf}
-	got, err := id.Value()
-	if err != nil {
-		t.Fatal(err)
-	}
-	if want := "06bprg666xzm7hpg"; got != want {
-		t.Errorf("Value() = %v, want %v", got, want)
-	}
-	got, err = nilID.Value()
-	if got != nil && err != nil {
-		t.Errorf("nilID.Value() should return nil, nil, got: %v, %v", got, err)
-	}
-}
-
-func TestIDDriverScan(t *testing.T) {
-	// 06bprg666xzm7hpg ts:1741277677111 seq:32579 rnd:49871 2025-03-06 16:14:37.111 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf }
-	id := ID{}
-	err := id.Scan("06bprg666xzm7hpg")
-	if err != nil {
-		t.Fatal(err)
-	}
-	want := ID{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf}
-	if !bytes.Equal(id[:], want[:]) {
-		t.Errorf("Scan() = %v, want %v", id, want)
-	}
-	id = ID{}
-	err = id.Scan(nil)
-	if err != nil || id != nilID {
-		t.Errorf("nilID.Scan(\"\") should return nil err, nilID. got: %v %v", err, id)
-	}
-}
-
-func TestIDDriverScanError(t *testing.T) {
-	id := ID{}
-
-	if got, want := id.Scan(0), errors.New("kid: scanning unsupported type: int"); got.Error() != want.Error() {
-		t.Errorf("Scan() err=%v, want %v", got, want)
-	}
-	if got, want := id.Scan("0"), ErrInvalidID; got != want {
-		t.Errorf("Scan() err=%v, want %v", got, want)
-	}
-	if id != nilID {
-		t.Errorf("Scan() id=%v, want %v", id, nilID)
-	}
-}
-
-func TestIDDriverScanByteFromDatabase(t *testing.T) {
-	// 06bprg666xzm7hpg ts:1741277677111 seq:32579 rnd:49871 2025-03-06 16:14:37.111 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf }
-	got := ID{}
-	bs := []byte("06bprg666xzm7hpg")
-	err := got.Scan(bs)
-	if err != nil {
-		t.Fatal(err)
-	}
-	want := ID{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf}
-	if !bytes.Equal(got[:], want[:]) {
-		t.Errorf("Scan() = %v, want %v", got, want)
-	}
-}
-
-func TestIDDriverScanBinary(t *testing.T) {
-	// Scan must also accept the 10-byte binary form, e.g. from a BLOB column
-	want := ID{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf}
-	got := ID{}
-	if err := got.Scan(want.Bytes()); err != nil {
-		t.Fatal(err)
-	}
-	if got != want {
-		t.Errorf("Scan(binary) = %v, want %v", got, want)
-	}
-	// a []byte of any other invalid length must fail
-	if err := got.Scan([]byte{0x1, 0x2, 0x3}); err != ErrInvalidID {
-		t.Errorf("Scan(3 bytes) err=%v, want %v", err, ErrInvalidID)
-	}
-}
-
 func TestIDUnmarshalJSON_RejectsNonString(t *testing.T) {
 	// A bare JSON number of length encodedLen+2 is composed entirely of
 	// valid alphabet characters once the delimiters are stripped; without
@@ -537,8 +776,8 @@ func TestIDUnmarshalJSON_RejectsNonString(t *testing.T) {
 	if err := id.UnmarshalJSON([]byte(`123456789012345678`)); err != ErrInvalidID {
 		t.Errorf("UnmarshalJSON(number) err=%v, want %v", err, ErrInvalidID)
 	}
-	if id != nilID {
-		t.Errorf("UnmarshalJSON(number) id=%v, want nilID", id)
+	if id != Nil {
+		t.Errorf("UnmarshalJSON(number) id=%v, want Nil", id)
 	}
 	// mismatched/absent quotes of the right total length must also fail
 	for _, b := range []string{
@@ -596,11 +835,40 @@ func TestCompare(t *testing.T) {
 		if -1*p.expected != p.right.Compare(p.left) {
 			t.Errorf("%s Compare to %s should return %d", p.right, p.left, -1*p.expected)
 		}
+		if got := p.left.Less(p.right); got != (p.expected < 0) {
+			t.Errorf("%s.Less(%s) = %v, want %v", p.left, p.right, got, p.expected < 0)
+		}
+		if got := p.left.Equal(p.right); got != (p.expected == 0) {
+			t.Errorf("%s.Equal(%s) = %v, want %v", p.left, p.right, got, p.expected == 0)
+		}
 	}
 }
 
 var sortTests = []ID{tests[0].id, tests[1].id, tests[2].id, tests[3].id, tests[4].id, tests[5].id}
 
+func TestNextPrev(t *testing.T) {
+	for _, tt := range []struct {
+		id, next ID
+	}{
+		{Nil, ID{0, 0, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{ID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff}, ID{0, 0, 0, 0, 0, 0, 0, 0, 1, 0}},
+		{ID{1, 2, 3, 4, 5, 6, 0xff, 0xff, 0xff, 0xff}, ID{1, 2, 3, 4, 5, 7, 0, 0, 0, 0}},
+		{Max.Prev(), Max},
+		{Max, Nil}, // wraps
+	} {
+		if got := tt.id.Next(); got != tt.next {
+			t.Errorf("%v.Next() = %v, want %v", tt.id, got, tt.next)
+		}
+		if got := tt.next.Prev(); got != tt.id {
+			t.Errorf("%v.Prev() = %v, want %v", tt.next, got, tt.id)
+		}
+	}
+	id := New()
+	if id.Next().Compare(id) <= 0 || id.Prev().Compare(id) >= 0 {
+		t.Errorf("Next/Prev of %v do not bracket it", id)
+	}
+}
+
 func TestSort(t *testing.T) {
 	ids := make([]ID, 0)
 	ids = append(ids, sortTests...)
@@ -609,6 +877,23 @@ func TestSort(t *testing.T) {
 	if got, want := ids, []ID{sortTests[2], sortTests[3], sortTests[0], sortTests[5], sortTests[4], sortTests[1]}; !reflect.DeepEqual(got, want) {
 		t.Errorf("\ngot %v\nwant %v\n", got, want)
 	}
+	if !IsSorted(ids) || IsSorted(sortTests) {
+		t.Errorf("IsSorted() = %v for sorted IDs, %v for unsorted", IsSorted(ids), IsSorted(sortTests))
+	}
+	if !IsSorted(nil) || !IsSorted([]ID{Max, Max}) {
+		t.Error("IsSorted() = false for an empty slice or duplicates")
+	}
+
+	strs := make([]string, len(sortTests))
+	for i, id := range sortTests {
+		strs[i] = id.String()
+	}
+	SortStrings(strs)
+	for i, id := range ids {
+		if strs[i] != id.String() {
+			t.Errorf("SortStrings()[%d] = %s, want %s", i, strs[i], id)
+		}
+	}
 }
 
 // Benchmarks
@@ -616,6 +901,8 @@ var (
 	// avoid compiler over-optimization and silly results
 	benchResultID     ID
 	benchResultString string
+	benchResultBool   bool
+	benchResultID64   ID64
 )
 
 // Create new ID
@@ -631,6 +918,7 @@ func BenchmarkNew(b *testing.B) {
 
 // common use case, generate an ID, encode as a string:
 func BenchmarkNewString(b *testing.B) {
+	b.ReportAllocs()
 	var r string
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
@@ -642,6 +930,7 @@ func BenchmarkNewString(b *testing.B) {
 
 // encoding performance only
 func BenchmarkString(b *testing.B) {
+	b.ReportAllocs()
 	id := New()
 	var r string
 	b.RunParallel(func(pb *testing.PB) {
@@ -652,6 +941,30 @@ func BenchmarkString(b *testing.B) {
 	})
 }
 
+// formatting with fmt, as loggers do
+func BenchmarkFormat(b *testing.B) {
+	b.ReportAllocs()
+	id := New()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			fmt.Fprint(io.Discard, id)
+		}
+	})
+}
+
+// encoding into a reused buffer
+func BenchmarkAppendEncode(b *testing.B) {
+	b.ReportAllocs()
+	id := New()
+	b.RunParallel(func(pb *testing.PB) {
+		buf := make([]byte, 0, encodedLen)
+		for pb.Next() {
+			buf = id.AppendEncode(buf[:0])
+		}
+		benchResultString = string(buf)
+	})
+}
+
 // decoding performance only
 func BenchmarkFromString(b *testing.B) {
 	var r ID
@@ -664,6 +977,28 @@ func BenchmarkFromString(b *testing.B) {
 	})
 }
 
+func BenchmarkIsValid(b *testing.B) {
+	var r bool
+	str := "06bprlcm7q4z16vh"
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r = IsValid(str)
+		}
+		benchResultBool = r
+	})
+}
+
+func BenchmarkValidateASCII(b *testing.B) {
+	var r bool
+	str := "06bprlcm7q4z16vh"
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r = ValidateASCII(str)
+		}
+		benchResultBool = r
+	})
+}
+
 // examples
 func ExampleNew() {
 	id := New()
@@ -686,16 +1021,16 @@ func ExampleFromString() {
 	// Output: 946684799999 41439
 }
 
-// resetClock saves and restores the getTS globals so clock-manipulating
-// tests leave the package in its original state. Tests using this must not
-// run in parallel.
+// resetClock saves and restores the default generator's clock and state so
+// clock-manipulating tests leave the package in its original state. Tests
+// using this must not run in parallel.
 func resetClock(t *testing.T) {
 	t.Helper()
-	savedNow := timeNow
-	savedLast := lastTime.Load()
+	savedNow := std.now
+	savedLast := std.lastTime.Load()
 	t.Cleanup(func() {
-		timeNow = savedNow
-		lastTime.Store(savedLast)
+		std.now = savedNow
+		std.lastTime.Store(savedLast)
 	})
 }
 
@@ -705,11 +1040,11 @@ func TestGetTSClockRegression(t *testing.T) {
 	resetClock(t)
 
 	base := time.Date(2026, 7, 6, 12, 0, 0, 500_000, time.UTC)
-	timeNow = func() time.Time { return base }
+	std.now = func() time.Time { return base }
 	a := New()
 
 	// step the clock back one hour
-	timeNow = func() time.Time { return base.Add(-time.Hour) }
+	std.now = func() time.Time { return base.Add(-time.Hour) }
 	b := New()
 	if b.Compare(a) <= 0 {
 		t.Errorf("ID generated after clock regression does not sort after predecessor: %v <= %v", b, a)
@@ -725,13 +1060,13 @@ func TestGetTSSequenceBorrow(t *testing.T) {
 	resetClock(t)
 
 	fixed := time.Date(2026, 7, 6, 12, 0, 0, 250_000, time.UTC)
-	timeNow = func() time.Time { return fixed }
+	std.now = func() time.Time { return fixed }
 
-	milli0, _ := getTS()
+	milli0, _ := std.getTS()
 	// force the sequence to its 12-bit maximum for the current millisecond
-	lastTime.Store(milli0<<12 | 0xfff)
+	std.lastTime.Store(milli0<<12 | 0xfff)
 
-	milli1, seq1 := getTS()
+	milli1, seq1 := std.getTS()
 	if milli1 != milli0+1 || seq1 != 0 {
 		t.Errorf("sequence overflow: got milli=%d seq=%d, want milli=%d seq=0", milli1, seq1, milli0+1)
 	}
@@ -743,11 +1078,11 @@ func TestGetTSBurstMonotonic(t *testing.T) {
 	resetClock(t)
 
 	fixed := time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
-	timeNow = func() time.Time { return fixed }
+	std.now = func() time.Time { return fixed }
 
 	prev := int64(-1)
 	for i := range 10000 {
-		m, s := getTS()
+		m, s := std.getTS()
 		if s < 0 || s > 0xfff {
 			t.Fatalf("call %d: sequence %d out of 12-bit range", i, s)
 		}
@@ -814,3 +1149,86 @@ func TestNewUniqueParallel(t *testing.T) {
 		}
 	}
 }
+
+// TestDecodingTable verifies the generated dec table (tables.go) against the
+// encoding alphabet; run `go generate` if it fails after an alphabet change.
+func TestDecodingTable(t *testing.T) {
+	for c := range len(dec) {
+		want := byte(maxByte)
+		if i := strings.IndexByte(encoding, byte(c)); i >= 0 {
+			want = byte(i)
+		}
+		if dec[c] != want {
+			t.Errorf("dec[%#02x] = %#02x, want %#02x", c, dec[c], want)
+		}
+	}
+}
+
+func TestDecodePairs(t *testing.T) {
+	for range 1000 {
+		id := New()
+		text := id.Encode(make([]byte, encodedLen))
+		var got, want ID
+		decode(&want, text)
+		if !decodePairs(&got, text) || got != want || got != id {
+			t.Fatalf("decodePairs(%s) = %v, want %v", text, got, id)
+		}
+		// a bad character anywhere is caught, and leaves id unchanged
+		for i, c := range []byte{'a', 'u', '-', 0x80, 0xff} {
+			bad := slices.Clone(text)
+			bad[(i*7)%encodedLen] = c
+			if decodePairs(&got, bad) || got != id {
+				t.Fatalf("decodePairs(%q) accepted, or changed id", bad)
+			}
+		}
+	}
+}
+
+// TestCanonicalEncoding sweeps every byte value through the first and last
+// characters of an encoded ID, the ones that would carry spare bits were
+// the encoding not an exact fit: each decoder accepts exactly the alphabet,
+// and what it accepts re-encodes unchanged, so no two strings decode to one
+// ID. ID64 and ID128, whose first characters do carry spare bits, must
+// reject any first character above their range.
+func TestCanonicalEncoding(t *testing.T) {
+	const base = "06bqer9xnm79tfnl"
+	decoders := map[string]func(string) (ID, error){
+		"FromString": FromString,
+		"UnmarshalText": func(s string) (id ID, err error) {
+			err = id.UnmarshalText([]byte(s))
+			return id, err
+		},
+		"UnmarshalJSON": func(s string) (id ID, err error) {
+			err = id.UnmarshalJSON([]byte(`"` + s + `"`))
+			return id, err
+		},
+	}
+	for _, pos := range []int{0, encodedLen - 1} {
+		for c := range 256 {
+			s := base[:pos] + string(byte(c)) + base[pos+1:]
+			valid := strings.IndexByte(encoding, byte(c)) >= 0
+			for name, decode := range decoders {
+				id, err := decode(s)
+				switch {
+				case !valid && (!errors.Is(err, ErrInvalidID) || id != Nil):
+					t.Errorf("%s(%q) = %v, %v, want ErrInvalidID", name, s, id, err)
+				case valid && (err != nil || id.String() != s):
+					t.Errorf("%s(%q) = %v, %v, want it back", name, s, id, err)
+				}
+			}
+		}
+	}
+
+	for c := range 256 {
+		s64 := string(byte(c)) + "000000000000"
+		id64, err := FromString64(s64)
+		if want := dec[c] <= 0x0f; (err == nil) != want || err == nil && id64.String() != s64 {
+			t.Errorf("FromString64(%q) = %v, %v", s64, id64, err)
+		}
+		s128 := string(byte(c)) + strings.Repeat("0", encodedLen128-1)
+		id128, err := FromString128(s128)
+		if want := dec[c] <= 0x07; (err == nil) != want || err == nil && id128.String() != s128 {
+			t.Errorf("FromString128(%q) = %v, %v", s128, id128, err)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/mwyvr/kid/kidbson
+
+go 1.23.0
+
+require (
+	github.com/mwyvr/kid v0.0.0
+	go.mongodb.org/mongo-driver v1.17.6
+)
+
+// kidbson is developed alongside kid; builds use the enclosing tree.
+replace github.com/mwyvr/kid => ../
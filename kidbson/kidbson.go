@@ -0,0 +1,112 @@
+// Package kidbson stores kid.IDs in MongoDB documents as BSON binary (or,
+// optionally, as strings) rather than as the BSON array of ten integers the
+// driver would otherwise write for a [10]byte.
+//
+// Register the codec on the registry a client or collection uses:
+//
+//	reg := kidbson.NewRegistry(kidbson.Binary)
+//	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetRegistry(reg))
+//
+// After that, kid.ID fields, including pointers and slices of them, encode
+// and decode transparently. Decoding accepts both representations, so a
+// collection can move from one to the other.
+package kidbson
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mwyvr/kid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Mode selects how IDs are stored.
+type Mode int
+
+const (
+	// Binary stores an ID as BSON binary, generic subtype 0x00, holding
+	// the 10 raw bytes. Binary values compare, and so index, like IDs.
+	Binary Mode = iota
+
+	// String stores an ID as its 16-character encoded string, readable in
+	// the shell and by clients without the codec.
+	String
+)
+
+// The nil ID is stored as BSON null in either mode, as it is JSON null.
+
+var idType = reflect.TypeOf(kid.ID{})
+
+// NewRegistry returns the driver's default registry with the kid.ID codec
+// registered in mode.
+func NewRegistry(mode Mode) *bsoncodec.Registry {
+	r := bson.NewRegistry()
+	Register(r, mode)
+	return r
+}
+
+// Register registers the kid.ID codec, in mode, on r.
+func Register(r *bsoncodec.Registry, mode Mode) {
+	c := codec{mode}
+	r.RegisterTypeEncoder(idType, bsoncodec.ValueEncoderFunc(c.encode))
+	r.RegisterTypeDecoder(idType, bsoncodec.ValueDecoderFunc(c.decode))
+}
+
+type codec struct {
+	mode Mode
+}
+
+func (c codec) encode(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != idType {
+		return bsoncodec.ValueEncoderError{Name: "kidbson.encode", Types: []reflect.Type{idType}, Received: val}
+	}
+	id := val.Interface().(kid.ID)
+	switch {
+	case id.IsNil():
+		return vw.WriteNull()
+	case c.mode == String:
+		return vw.WriteString(id.String())
+	default:
+		return vw.WriteBinaryWithSubtype(id.Bytes(), bsontype.BinaryGeneric)
+	}
+}
+
+func (c codec) decode(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != idType {
+		return bsoncodec.ValueDecoderError{Name: "kidbson.decode", Types: []reflect.Type{idType}, Received: val}
+	}
+	var id kid.ID
+	switch t := vr.Type(); t {
+	case bsontype.Binary:
+		data, _, err := vr.ReadBinary()
+		if err != nil {
+			return err
+		}
+		if id, err = kid.FromBytes(data); err != nil {
+			return fmt.Errorf("kidbson: %d-byte binary: %w", len(data), err)
+		}
+	case bsontype.String:
+		s, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		if id, err = kid.FromString(s); err != nil {
+			return fmt.Errorf("kidbson: %q: %w", s, err)
+		}
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+	case bsontype.Undefined:
+		if err := vr.ReadUndefined(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("kidbson: cannot decode BSON %v into a kid.ID", t)
+	}
+	val.Set(reflect.ValueOf(id))
+	return nil
+}
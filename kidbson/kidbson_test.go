@@ -0,0 +1,116 @@
+package kidbson
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mwyvr/kid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type order struct {
+	ID       kid.ID   `bson:"_id"`
+	Customer *kid.ID  `bson:"customer"`
+	Parent   kid.ID   `bson:"parent"`
+	Items    []kid.ID `bson:"items"`
+}
+
+func marshal(t *testing.T, r *bsoncodec.Registry, v any) bson.Raw {
+	t.Helper()
+	var buf bytes.Buffer
+	vw, err := bsonrw.NewBSONValueWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := bson.NewEncoder(vw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.SetRegistry(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func unmarshal(r *bsoncodec.Registry, raw bson.Raw, v any) error {
+	dec, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(raw))
+	if err != nil {
+		return err
+	}
+	if err := dec.SetRegistry(r); err != nil {
+		return err
+	}
+	return dec.Decode(v)
+}
+
+func TestRoundTrip(t *testing.T) {
+	customer := kid.New()
+	in := order{ID: kid.New(), Customer: &customer, Items: []kid.ID{kid.New(), kid.New()}}
+	for _, mode := range []Mode{Binary, String} {
+		r := NewRegistry(mode)
+		raw := marshal(t, r, in)
+
+		id := raw.Lookup("_id")
+		switch mode {
+		case Binary:
+			if sub, data, ok := id.BinaryOK(); !ok || sub != bsontype.BinaryGeneric || !bytes.Equal(data, in.ID.Bytes()) {
+				t.Errorf("Binary: _id = %v, want binary %x", id, in.ID.Bytes())
+			}
+		case String:
+			if s, ok := id.StringValueOK(); !ok || s != in.ID.String() {
+				t.Errorf("String: _id = %v, want %q", id, in.ID.String())
+			}
+		}
+		if p := raw.Lookup("parent"); p.Type != bsontype.Null {
+			t.Errorf("mode %d: nil parent stored as %v, want null", mode, p.Type)
+		}
+
+		var out order
+		if err := unmarshal(r, raw, &out); err != nil {
+			t.Fatalf("mode %d: %v", mode, err)
+		}
+		if out.ID != in.ID || *out.Customer != customer || out.Parent != kid.Nil ||
+			len(out.Items) != 2 || out.Items[0] != in.Items[0] || out.Items[1] != in.Items[1] {
+			t.Errorf("mode %d: round trip = %+v, want %+v", mode, out, in)
+		}
+	}
+}
+
+func TestDecodeEitherForm(t *testing.T) {
+	id := kid.New()
+	r := NewRegistry(Binary)
+	for _, doc := range []bson.D{
+		{{Key: "_id", Value: id.String()}},
+		{{Key: "_id", Value: primitive.Binary{Data: id.Bytes()}}},
+	} {
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out order
+		if err := unmarshal(r, raw, &out); err != nil || out.ID != id {
+			t.Errorf("decode %v = %v, %v, want %v", doc, out.ID, err, id)
+		}
+	}
+	for _, doc := range []bson.D{
+		{{Key: "_id", Value: "not an id"}},
+		{{Key: "_id", Value: primitive.Binary{Data: []byte{1, 2, 3}}}},
+		{{Key: "_id", Value: int32(7)}},
+	} {
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out order
+		if err := unmarshal(r, raw, &out); err == nil {
+			t.Errorf("decode %v succeeded, want error", doc)
+		}
+	}
+}
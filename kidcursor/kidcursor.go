@@ -0,0 +1,119 @@
+// Package kidcursor turns keyset pagination positions, a kid.ID with a
+// direction and page size, into opaque, signed, URL-safe cursor tokens and
+// back, for APIs whose primary keys are kid IDs:
+//
+//	codec := kidcursor.New(key)
+//	next := codec.Encode(kidcursor.Cursor{ID: last.ID, Limit: 50})
+//
+//	cur, err := codec.Decode(r.URL.Query().Get("cursor"))
+//	// After:  SELECT ... WHERE id > $1 ORDER BY id LIMIT $2
+//	// Before: SELECT ... WHERE id < $1 ORDER BY id DESC LIMIT $2
+//
+// Tokens are signed with HMAC-SHA256, so clients cannot forge or alter
+// them; they are not encrypted, and reveal the ID to anyone who decodes
+// them.
+package kidcursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/mwyvr/kid"
+)
+
+// ErrInvalid is returned by Decode for a token that is malformed or not
+// signed by any of the Codec's keys.
+var ErrInvalid = errors.New("kidcursor: invalid cursor")
+
+// Direction is the direction a page extends from its cursor's ID.
+type Direction uint8
+
+const (
+	// After pages forward, through IDs greater than the cursor's.
+	After Direction = iota
+	// Before pages backward, through IDs less than the cursor's.
+	Before
+)
+
+// Cursor is a position in a listing ordered by ID.
+type Cursor struct {
+	ID        kid.ID
+	Direction Direction
+	// Limit is the page size, 0 if the cursor leaves it to the server.
+	Limit uint16
+}
+
+const (
+	version    = 1
+	payloadLen = 1 + 10 + 1 + 2 // version, ID, direction, limit
+	tagLen     = 16             // HMAC-SHA256, truncated to 128 bits
+	tokenLen   = 40             // base64 of payload and tag, unpadded
+)
+
+// Codec encodes and decodes cursor tokens under a secret key. A Codec is
+// goroutine-safe.
+type Codec struct {
+	keys [][]byte
+}
+
+// New returns a Codec signing tokens with key, which should be at least 32
+// random bytes and kept secret. Tokens signed with any of old are still
+// accepted by Decode, so keys can be rotated without breaking cursors
+// clients hold.
+func New(key []byte, old ...[]byte) *Codec {
+	return &Codec{keys: append([][]byte{key}, old...)}
+}
+
+// Encode returns the token for cur: 40 characters of the URL-safe base64
+// alphabet, needing no escaping in query strings.
+func (c *Codec) Encode(cur Cursor) string {
+	var b [payloadLen + tagLen]byte
+	b[0] = version
+	copy(b[1:11], cur.ID[:])
+	b[11] = byte(cur.Direction)
+	binary.BigEndian.PutUint16(b[12:], cur.Limit)
+	copy(b[payloadLen:], sign(c.keys[0], b[:payloadLen]))
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+// Decode returns the cursor of token, a token from Encode, returning an
+// error wrapping ErrInvalid if it is malformed or its signature does not
+// match.
+func (c *Codec) Decode(token string) (Cursor, error) {
+	var b [payloadLen + tagLen]byte
+	if len(token) != tokenLen {
+		return Cursor{}, fmt.Errorf("%w: length %d", ErrInvalid, len(token))
+	}
+	if _, err := base64.RawURLEncoding.Decode(b[:], []byte(token)); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	payload, tag := b[:payloadLen], b[payloadLen:]
+	valid := false
+	for _, key := range c.keys {
+		if hmac.Equal(tag, sign(key, payload)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return Cursor{}, fmt.Errorf("%w: bad signature", ErrInvalid)
+	}
+	if payload[0] != version || Direction(payload[11]) > Before {
+		return Cursor{}, fmt.Errorf("%w: unknown version or direction", ErrInvalid)
+	}
+	return Cursor{
+		ID:        kid.ID(payload[1:11]),
+		Direction: Direction(payload[11]),
+		Limit:     binary.BigEndian.Uint16(payload[12:]),
+	}, nil
+}
+
+func sign(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:tagLen]
+}
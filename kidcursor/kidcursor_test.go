@@ -0,0 +1,67 @@
+package kidcursor
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mwyvr/kid"
+)
+
+var key = []byte("0123456789abcdef0123456789abcdef")
+
+func TestRoundTrip(t *testing.T) {
+	c := New(key)
+	for _, cur := range []Cursor{
+		{},
+		{ID: kid.MustFromString("06bqer9xnm79tfnl"), Limit: 50},
+		{ID: kid.Max, Direction: Before, Limit: 65535},
+		{ID: kid.New(), Direction: After},
+	} {
+		token := c.Encode(cur)
+		if len(token) != tokenLen || url.QueryEscape(token) != token {
+			t.Errorf("Encode(%+v) = %q, want %d URL-safe characters", cur, token, tokenLen)
+		}
+		got, err := c.Decode(token)
+		if err != nil || got != cur {
+			t.Errorf("Decode(Encode(%+v)) = %+v, %v", cur, got, err)
+		}
+	}
+}
+
+func TestRotation(t *testing.T) {
+	old := []byte("old key, retired but still trusted")
+	cur := Cursor{ID: kid.New(), Limit: 10}
+	token := New(old).Encode(cur)
+	if got, err := New(key, old).Decode(token); err != nil || got != cur {
+		t.Errorf("Decode() of a token signed with an old key = %+v, %v", got, err)
+	}
+	if _, err := New(key).Decode(token); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Decode() of a token signed with an unknown key = %v, want ErrInvalid", err)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	c := New(key)
+	token := c.Encode(Cursor{ID: kid.New(), Limit: 20})
+	tamper := func(i int) string {
+		b := []byte(token)
+		b[i] = map[bool]byte{true: 'A', false: 'B'}[b[i] != 'A']
+		return string(b)
+	}
+	for _, s := range []string{
+		"",
+		token[1:],
+		token + "A",
+		tamper(0),
+		tamper(15),
+		tamper(tokenLen - 1),
+		strings.Replace(token, token[:1], "*", 1),
+		New([]byte("another key")).Encode(Cursor{ID: kid.New()}),
+	} {
+		if got, err := c.Decode(s); !errors.Is(err, ErrInvalid) || got != (Cursor{}) {
+			t.Errorf("Decode(%q) = %+v, %v, want ErrInvalid", s, got, err)
+		}
+	}
+}
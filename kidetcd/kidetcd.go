@@ -0,0 +1,138 @@
+// Package kidetcd coordinates kid node values (kid.WithNode) through etcd,
+// so clusters can guarantee each Generator a unique node:
+//
+//	leaser := kidetcd.NewNodeLeaser(cli, "/kid/node/")
+//	l, err := kid.LeaseNode(ctx, leaser, 30*time.Second)
+//
+// Each leased value is a key, prefix plus the decimal node, attached to an
+// etcd lease of its own, so it is deleted by etcd if the holder stops
+// renewing.
+package kidetcd
+
+import (
+	"context"
+	"errors"
+	mrand "math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mwyvr/kid"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// NodeLeaser is a kid.NodeLeaser storing leases in etcd.
+type NodeLeaser struct {
+	cli    *clientv3.Client
+	prefix string
+
+	mu     sync.Mutex
+	leases map[uint16]clientv3.LeaseID
+}
+
+var _ kid.NodeLeaser = (*NodeLeaser)(nil)
+
+// NewNodeLeaser returns a NodeLeaser keeping leases under keys beginning
+// with prefix in cli. Leases are held by the NodeLeaser: another cannot
+// renew or release them.
+func NewNodeLeaser(cli *clientv3.Client, prefix string) *NodeLeaser {
+	return &NodeLeaser{cli: cli, prefix: prefix, leases: map[uint16]clientv3.LeaseID{}}
+}
+
+func (l *NodeLeaser) key(node uint16) string {
+	return l.prefix + strconv.Itoa(int(node))
+}
+
+// Lease grants an etcd lease of ttl, rounded up to whole seconds, and
+// attaches to it the key of the first free node value after a random
+// start, creating the key in a transaction that fails if another holder
+// created it first.
+func (l *NodeLeaser) Lease(ctx context.Context, ttl time.Duration) (uint16, error) {
+	grant, err := l.cli.Grant(ctx, int64((ttl+time.Second-1)/time.Second))
+	if err != nil {
+		return 0, err
+	}
+	resp, err := l.cli.Get(ctx, l.prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return 0, errors.Join(err, l.revoke(grant.ID))
+	}
+	held := map[uint16]bool{}
+	for _, kv := range resp.Kvs {
+		if n, err := strconv.ParseUint(strings.TrimPrefix(string(kv.Key), l.prefix), 10, 16); err == nil {
+			held[uint16(n)] = true
+		}
+	}
+	start := uint16(mrand.Uint32()) //nolint:gosec
+	for i := range 1 << 16 {
+		node := start + uint16(i) //nolint:gosec
+		if held[node] {
+			continue
+		}
+		key := l.key(node)
+		txn, err := l.cli.Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+			Then(clientv3.OpPut(key, "", clientv3.WithLease(grant.ID))).
+			Commit()
+		if err != nil {
+			return 0, errors.Join(err, l.revoke(grant.ID))
+		}
+		if txn.Succeeded {
+			l.mu.Lock()
+			l.leases[node] = grant.ID
+			l.mu.Unlock()
+			return node, nil
+		}
+	}
+	return 0, errors.Join(kid.ErrNoFreeNode, l.revoke(grant.ID))
+}
+
+// revoke revokes the unused lease of a failed Lease.
+func (l *NodeLeaser) revoke(id clientv3.LeaseID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := l.cli.Revoke(ctx, id)
+	return err
+}
+
+// Renew refreshes the etcd lease of node, returning kid.ErrNodeLost if it
+// has expired. The lease's time to live was fixed by Lease; ttl is
+// ignored.
+func (l *NodeLeaser) Renew(ctx context.Context, node uint16, _ time.Duration) error {
+	l.mu.Lock()
+	id, ok := l.leases[node]
+	l.mu.Unlock()
+	if !ok {
+		return kid.ErrNodeLost
+	}
+	if _, err := l.cli.KeepAliveOnce(ctx, id); err != nil {
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			l.forget(node)
+			return kid.ErrNodeLost
+		}
+		return err
+	}
+	return nil
+}
+
+// Release revokes the etcd lease of node, deleting its key.
+func (l *NodeLeaser) Release(ctx context.Context, node uint16) error {
+	l.mu.Lock()
+	id, ok := l.leases[node]
+	l.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	if _, err := l.cli.Revoke(ctx, id); err != nil && !errors.Is(err, rpctypes.ErrLeaseNotFound) {
+		return err
+	}
+	l.forget(node)
+	return nil
+}
+
+func (l *NodeLeaser) forget(node uint16) {
+	l.mu.Lock()
+	delete(l.leases, node)
+	l.mu.Unlock()
+}
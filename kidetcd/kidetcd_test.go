@@ -0,0 +1,60 @@
+package kidetcd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mwyvr/kid"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// TestEtcd runs against the etcd cluster in KIDETCD_TEST_ENDPOINTS, a
+// comma-separated list such as "localhost:2379".
+func TestEtcd(t *testing.T) {
+	endpoints := os.Getenv("KIDETCD_TEST_ENDPOINTS")
+	if endpoints == "" {
+		t.Skip("KIDETCD_TEST_ENDPOINTS not set")
+	}
+	cli, err := clientv3.New(clientv3.Config{Endpoints: strings.Split(endpoints, ","), DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	ctx := context.Background()
+	prefix := "/kidetcd-test/" + kid.New().String() + "/"
+	defer cli.Delete(ctx, prefix, clientv3.WithPrefix()) //nolint:errcheck
+
+	a, b := NewNodeLeaser(cli, prefix), NewNodeLeaser(cli, prefix)
+	na, err := a.Lease(ctx, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 20 {
+		nb, err := b.Lease(ctx, 5*time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nb == na {
+			t.Fatalf("node %d leased twice", na)
+		}
+	}
+	if err := b.Renew(ctx, na, 5*time.Second); !errors.Is(err, kid.ErrNodeLost) {
+		t.Errorf("Renew() by another leaser = %v, want ErrNodeLost", err)
+	}
+	if err := a.Renew(ctx, na, 5*time.Second); err != nil {
+		t.Errorf("Renew() = %v", err)
+	}
+	if err := a.Release(ctx, na); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := cli.Get(ctx, a.key(na)); err != nil || len(resp.Kvs) != 0 {
+		t.Errorf("key after Release() = %v, %v, want none", resp.Kvs, err)
+	}
+	if err := a.Renew(ctx, na, 5*time.Second); !errors.Is(err, kid.ErrNodeLost) {
+		t.Errorf("Renew() after Release() = %v, want ErrNodeLost", err)
+	}
+}
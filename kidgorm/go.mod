@@ -0,0 +1,19 @@
+module github.com/mwyvr/kid/kidgorm
+
+go 1.23.0
+
+require (
+	github.com/mwyvr/kid v0.0.0
+	gorm.io/driver/sqlite v1.6.0
+	gorm.io/gorm v1.31.2
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/mattn/go-sqlite3 v1.14.22 // indirect
+	golang.org/x/text v0.24.0 // indirect
+)
+
+// kidgorm is developed alongside kid; builds use the enclosing tree.
+replace github.com/mwyvr/kid => ../
@@ -0,0 +1,176 @@
+// Package kidgorm gives kid IDs proper column types in GORM.
+//
+// GORM sees a kid.ID as a byte array and, migrating, creates a bytes column
+// (bytea, blob) for it, which then receives the 16-character string that
+// kid.ID's driver.Valuer produces. kidgorm offers two remedies.
+//
+// Field types, which carry their column type:
+//
+//	type Order struct {
+//		ID       kidgorm.ID     `gorm:"primaryKey"` // char(16)
+//		Customer kidgorm.Binary // 10 bytes: bytea, blob or binary(10)
+//	}
+//
+// convert to and from kid.ID: kid.ID(order.ID), kidgorm.ID(kid.New()).
+//
+// Or serializers, registered when kidgorm is imported, for plain kid.ID and
+// *kid.ID fields:
+//
+//	ID       kid.ID  `gorm:"primaryKey;serializer:kid;size:16"`
+//	Customer *kid.ID `gorm:"serializer:kidbinary;type:bytes;size:10"`
+//
+// The serializer "kid" stores the 16-character string and "kidbinary" the
+// 10 bytes; the nil ID, like a nil pointer, is NULL.
+package kidgorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/mwyvr/kid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func init() {
+	schema.RegisterSerializer("kid", Serializer{})
+	schema.RegisterSerializer("kidbinary", Serializer{Binary: true})
+}
+
+// ID is a kid.ID stored as its 16-character string in a char(16) column.
+type ID kid.ID
+
+// GormDataType implements schema.GormDataTypeInterface.
+func (ID) GormDataType() string {
+	return string(schema.String)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface.
+func (ID) GormDBDataType(*gorm.DB, *schema.Field) string {
+	return "char(16)"
+}
+
+// Value implements package sql's driver.Valuer, as kid.ID does.
+func (id ID) Value() (driver.Value, error) {
+	return kid.ID(id).Value()
+}
+
+// Scan implements the sql.Scanner interface, as kid.ID does.
+func (id *ID) Scan(value any) error {
+	return (*kid.ID)(id).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler interface, as kid.ID does.
+func (id ID) MarshalJSON() ([]byte, error) {
+	return kid.ID(id).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, as kid.ID does.
+func (id *ID) UnmarshalJSON(b []byte) error {
+	return (*kid.ID)(id).UnmarshalJSON(b)
+}
+
+// String returns id encoded as kid.ID.String does.
+func (id ID) String() string {
+	return kid.ID(id).String()
+}
+
+// Binary is a kid.ID stored as its 10 bytes, as kid.Binary is, in a bytea
+// (Postgres), blob (SQLite) or binary(10) column.
+type Binary kid.ID
+
+// GormDataType implements schema.GormDataTypeInterface.
+func (Binary) GormDataType() string {
+	return string(schema.Bytes)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface.
+func (Binary) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "bytea"
+	case "sqlite":
+		return "blob"
+	default:
+		return "binary(10)"
+	}
+}
+
+// Value implements package sql's driver.Valuer, as kid.Binary does.
+func (b Binary) Value() (driver.Value, error) {
+	return kid.Binary(b).Value()
+}
+
+// Scan implements the sql.Scanner interface, accepting whatever kid.ID's
+// Scan accepts.
+func (b *Binary) Scan(value any) error {
+	return (*kid.ID)(b).Scan(value)
+}
+
+// MarshalJSON implements the json.Marshaler interface, as kid.ID does.
+func (b Binary) MarshalJSON() ([]byte, error) {
+	return kid.ID(b).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, as kid.ID does.
+func (b *Binary) UnmarshalJSON(data []byte) error {
+	return (*kid.ID)(b).UnmarshalJSON(data)
+}
+
+// String returns b encoded as kid.ID.String does.
+func (b Binary) String() string {
+	return kid.ID(b).String()
+}
+
+// Serializer is the GORM serializer for kid.ID and *kid.ID fields,
+// registered as "kid" and, with Binary set, "kidbinary".
+type Serializer struct {
+	Binary bool // store 10 bytes rather than the 16-character string
+}
+
+var (
+	idType    = reflect.TypeOf(kid.ID{})
+	idPtrType = reflect.TypeOf((*kid.ID)(nil))
+)
+
+// Scan implements schema.SerializerInterface.
+func (s Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	if field.FieldType != idType && field.FieldType != idPtrType {
+		return fmt.Errorf("kidgorm: serializer on %s, a %v, want kid.ID or *kid.ID", field.Name, field.FieldType)
+	}
+	v := reflect.New(field.FieldType).Elem()
+	if dbValue != nil {
+		var id kid.ID
+		if err := id.Scan(dbValue); err != nil {
+			return fmt.Errorf("kidgorm: scanning %s: %w", field.Name, err)
+		}
+		if v.Kind() == reflect.Pointer {
+			v.Set(reflect.ValueOf(&id))
+		} else {
+			v.Set(reflect.ValueOf(id))
+		}
+	}
+	field.ReflectValueOf(ctx, dst).Set(v)
+	return nil
+}
+
+// Value implements schema.SerializerValuerInterface.
+func (s Serializer) Value(_ context.Context, field *schema.Field, _ reflect.Value, fieldValue any) (any, error) {
+	var id kid.ID
+	switch v := fieldValue.(type) {
+	case kid.ID:
+		id = v
+	case *kid.ID:
+		if v != nil {
+			id = *v
+		}
+	default:
+		return nil, fmt.Errorf("kidgorm: serializer on %s, a %T, want kid.ID or *kid.ID", field.Name, fieldValue)
+	}
+	if s.Binary {
+		return kid.Binary(id).Value()
+	}
+	return id.Value()
+}
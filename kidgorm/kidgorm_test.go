@@ -0,0 +1,94 @@
+package kidgorm
+
+import (
+	"testing"
+
+	"github.com/mwyvr/kid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type order struct {
+	ID       ID `gorm:"primaryKey"`
+	Customer Binary
+	Parent   kid.ID  `gorm:"serializer:kid;size:16"`
+	Shipment *kid.ID `gorm:"serializer:kidbinary;type:bytes;size:10"`
+}
+
+func open(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&order{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestColumnTypes(t *testing.T) {
+	db := open(t)
+	cols, err := db.Migrator().ColumnTypes(&order{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"id":       "char(16)",
+		"customer": "blob",
+		"parent":   "text",
+		"shipment": "blob",
+	}
+	for _, c := range cols {
+		if got, _ := c.ColumnType(); got != want[c.Name()] {
+			t.Errorf("column %s is %s, want %s", c.Name(), got, want[c.Name()])
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	db := open(t)
+	shipment := kid.New()
+	in := order{ID: ID(kid.New()), Customer: Binary(kid.New()), Parent: kid.New(), Shipment: &shipment}
+	if err := db.Create(&in).Error; err != nil {
+		t.Fatal(err)
+	}
+	var out order
+	if err := db.First(&out, "id = ?", in.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != in.ID || out.Customer != in.Customer || out.Parent != in.Parent || out.Shipment == nil || *out.Shipment != shipment {
+		t.Errorf("read back %+v, want %+v", out, in)
+	}
+
+	// stored as the string and the 10 bytes, respectively
+	var row struct {
+		ID, Parent         string
+		Customer, Shipment int
+	}
+	db.Raw("SELECT typeof(id) || length(id) AS id, typeof(parent) || length(parent) AS parent, length(customer) AS customer, length(shipment) AS shipment FROM orders").Scan(&row)
+	if row.ID != "text16" || row.Parent != "text16" || row.Customer != 10 || row.Shipment != 10 {
+		t.Errorf("stored as %+v, want 16-character text and 10-byte blobs", row)
+	}
+}
+
+func TestNull(t *testing.T) {
+	db := open(t)
+	in := order{ID: ID(kid.New())}
+	if err := db.Create(&in).Error; err != nil {
+		t.Fatal(err)
+	}
+	var nulls int64
+	db.Model(&order{}).Where("customer IS NULL AND parent IS NULL AND shipment IS NULL").Count(&nulls)
+	if nulls != 1 {
+		t.Errorf("nil IDs and pointers not stored as NULL")
+	}
+	out := order{Parent: kid.New(), Shipment: &kid.Max}
+	if err := db.First(&out, "id = ?", in.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if out.Parent != kid.Nil || out.Shipment != nil || out.Customer != (Binary{}) {
+		t.Errorf("read back %+v, want nil IDs and pointer", out)
+	}
+}
@@ -0,0 +1,80 @@
+package kidgrpc
+
+import (
+	"github.com/mwyvr/kid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo detail Error
+// attaches, whose reason is the kid.Code.
+const ErrorDomain = "kid.mwyvr.github.com"
+
+// Code returns the gRPC status code answering an error with code c, as
+// kidhttp.Status does for HTTP.
+func Code(c kid.Code) codes.Code {
+	switch c {
+	case kid.CodeInvalidID, kid.CodeInvalidArgument, kid.CodeBadSignature:
+		return codes.InvalidArgument
+	case kid.CodeTimeRange:
+		return codes.OutOfRange
+	case kid.CodeBackpressure, kid.CodeQuotaExceeded:
+		return codes.ResourceExhausted
+	case kid.CodeClockRegression, kid.CodeNodeLost, kid.CodeNoFreeNode, kid.CodeUnavailable:
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
+
+// Error returns err as a gRPC status error, for returning from a handler:
+// its status code is that of its kid.Code (see Code), and the code itself
+// travels in a google.rpc.ErrorInfo detail, for FromError to restore.
+// Messages of kid.CodeInternal errors are replaced, so server internals do
+// not leak to clients. A nil err returns nil.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	e := kid.NewError(err)
+	if e.Code == kid.CodeInternal {
+		e.Message = "internal error"
+	}
+	st := status.New(Code(e.Code), e.Message)
+	if d, derr := st.WithDetails(&errdetails.ErrorInfo{Reason: string(e.Code), Domain: ErrorDomain}); derr == nil {
+		st = d
+	}
+	return st.Err()
+}
+
+// FromError returns the error a client call reported: nil for nil, the
+// *kid.Error sent by an Error reply, or for any other status a *kid.Error
+// with its message and a code from its status code: CodeInvalidArgument
+// for InvalidArgument, CodeTimeRange for OutOfRange, CodeUnavailable,
+// which is Temporary, for Unavailable and ResourceExhausted, otherwise
+// CodeInternal. Canceled and DeadlineExceeded statuses, which report the
+// caller's own context, and errors that are not statuses are returned
+// unchanged.
+func FromError(err error) error {
+	st, ok := status.FromError(err)
+	if err == nil || !ok {
+		return err
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == ErrorDomain {
+			return &kid.Error{Code: kid.Code(info.GetReason()), Message: st.Message()}
+		}
+	}
+	e := &kid.Error{Code: kid.CodeInternal, Message: st.Message()}
+	switch st.Code() {
+	case codes.InvalidArgument:
+		e.Code = kid.CodeInvalidArgument
+	case codes.OutOfRange:
+		e.Code = kid.CodeTimeRange
+	case codes.Unavailable, codes.ResourceExhausted:
+		e.Code = kid.CodeUnavailable
+	case codes.Canceled, codes.DeadlineExceeded:
+		return err // the caller's own context
+	}
+	return e
+}
@@ -0,0 +1,91 @@
+package kidgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidgrpc/issuerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorRoundTrip(t *testing.T) {
+	tests := []struct {
+		err       error
+		code      codes.Code
+		kcode     kid.Code
+		is        error
+		msg       string
+		temporary bool
+	}{
+		{&kid.InvalidLengthError{Got: 7, Want: 16}, codes.InvalidArgument, kid.CodeInvalidID, kid.ErrInvalidID, "kid: invalid id: length 7, want 16", false},
+		{kid.ErrTimeRange, codes.OutOfRange, kid.CodeTimeRange, kid.ErrTimeRange, "kid: time outside the timestamp range", false},
+		{fmt.Errorf("%w: 3ms ahead", kid.ErrBackpressure), codes.ResourceExhausted, kid.CodeBackpressure, kid.ErrBackpressure, "kid: generator too far ahead of the clock: 3ms ahead", true},
+		{kid.ErrNodeLost, codes.Unavailable, kid.CodeNodeLost, kid.ErrNodeLost, "kid: node lease lost", true},
+		{errors.New("disk on fire"), codes.Internal, kid.CodeInternal, nil, "internal error", false},
+	}
+	for _, tt := range tests {
+		sent := Error(tt.err)
+		if status.Code(sent) != tt.code {
+			t.Errorf("Error(%v) status = %v, want %v", tt.err, status.Code(sent), tt.code)
+		}
+		err := FromError(sent)
+		var e *kid.Error
+		if !errors.As(err, &e) || e.Code != tt.kcode || e.Message != tt.msg {
+			t.Errorf("FromError(Error(%v)) = %#v, want code %q, message %q", tt.err, err, tt.kcode, tt.msg)
+			continue
+		}
+		if tt.is != nil && !errors.Is(err, tt.is) {
+			t.Errorf("errors.Is(%v, %v) = false", err, tt.is)
+		}
+		if kid.IsTemporary(err) != tt.temporary {
+			t.Errorf("IsTemporary(%v) = %t, want %t", err, !tt.temporary, tt.temporary)
+		}
+	}
+	if Error(nil) != nil || FromError(nil) != nil {
+		t.Error("Error(nil) or FromError(nil) not nil")
+	}
+}
+
+func TestFromErrorOther(t *testing.T) {
+	tests := []struct {
+		err  error
+		code kid.Code // "" if returned unchanged
+	}{
+		{status.Error(codes.Unavailable, "connection refused"), kid.CodeUnavailable},
+		{status.Error(codes.InvalidArgument, "bad"), kid.CodeInvalidArgument},
+		{status.Error(codes.PermissionDenied, "no"), kid.CodeInternal},
+		{status.Error(codes.DeadlineExceeded, "too slow"), ""},
+		{context.Canceled, ""},
+	}
+	for _, tt := range tests {
+		err := FromError(tt.err)
+		if tt.code == "" {
+			if err != tt.err {
+				t.Errorf("FromError(%v) = %v, want it unchanged", tt.err, err)
+			}
+			continue
+		}
+		if kid.CodeOf(err) != tt.code || err.Error() != status.Convert(tt.err).Message() {
+			t.Errorf("FromError(%v) = %#v, want code %q", tt.err, err, tt.code)
+		}
+	}
+}
+
+func TestIssuerErrorCodes(t *testing.T) {
+	client := issuer(t, nil)
+	_, err := client.Decode(context.Background(), &issuerpb.DecodeRequest{Id: "06bqer9"})
+	if err = FromError(err); !errors.Is(err, kid.ErrInvalidID) || kid.CodeOf(err) != kid.CodeInvalidID {
+		t.Errorf("Decode(short) error = %#v, want CodeInvalidID", err)
+	}
+	stream, err := client.GenerateBatch(context.Background(), &issuerpb.GenerateBatchRequest{Count: 0})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if kid.CodeOf(FromError(err)) != kid.CodeInvalidArgument {
+		t.Errorf("GenerateBatch(0) error = %v, want CodeInvalidArgument", err)
+	}
+}
@@ -0,0 +1,19 @@
+module github.com/mwyvr/kid/kidgrpc
+
+go 1.23.0
+
+require (
+	github.com/mwyvr/kid v0.0.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7
+	google.golang.org/grpc v1.75.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+)
+
+// kidgrpc is developed alongside kid; builds use the enclosing tree.
+replace github.com/mwyvr/kid => ../
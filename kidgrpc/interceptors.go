@@ -0,0 +1,178 @@
+// Package kidgrpc provides kid over gRPC: interceptors carrying request
+// IDs across calls, and an ID-issuing service.
+//
+// The interceptors do for gRPC what kidhttp does for HTTP: client
+// interceptors send the ID from the caller's context in the x-request-id
+// metadata, and server interceptors extract and validate it, issuing a new
+// ID where there is none, and put it in the handler's context. The context
+// is kidhttp's, so an ID received over HTTP flows on through gRPC calls:
+//
+//	srv := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(kidgrpc.UnaryServerInterceptor()),
+//		grpc.ChainStreamInterceptor(kidgrpc.StreamServerInterceptor()),
+//	)
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithChainUnaryInterceptor(kidgrpc.UnaryClientInterceptor()),
+//		grpc.WithChainStreamInterceptor(kidgrpc.StreamClientInterceptor()),
+//	)
+//
+// Handlers read the ID with kidhttp.FromContext.
+//
+// Issuer serves the IssuerService of kid/issuer/v1/issuer.proto, whose
+// generated Go code is package issuerpb, so platforms in any language can
+// centralize ID issuance:
+//
+//	kidgrpc.RegisterIssuer(srv, nil)
+//
+// Its errors, and those of other services built on kid, carry a kid.Code
+// in their status details (see Error); clients restore it with FromError
+// and decide on retries with kid.IsTemporary.
+package kidgrpc
+
+import (
+	"context"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the metadata key carrying the request ID.
+const MetadataKey = "x-request-id"
+
+// RequestID configures the interceptors; its zero value is ready to use.
+type RequestID struct {
+	// Generator issues new request IDs; nil uses kid.New.
+	Generator *kid.Generator
+
+	// Key names the metadata key read and written, MetadataKey if empty.
+	Key string
+
+	// IgnoreIncoming, if set, has servers always issue a new ID, for
+	// services whose callers' request IDs should not be trusted.
+	IgnoreIncoming bool
+}
+
+// UnaryServerInterceptor returns the default RequestID's unary server
+// interceptor.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return RequestID{}.UnaryServerInterceptor()
+}
+
+// StreamServerInterceptor returns the default RequestID's stream server
+// interceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return RequestID{}.StreamServerInterceptor()
+}
+
+// UnaryClientInterceptor returns the default RequestID's unary client
+// interceptor.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return RequestID{}.UnaryClientInterceptor()
+}
+
+// StreamClientInterceptor returns the default RequestID's stream client
+// interceptor.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return RequestID{}.StreamClientInterceptor()
+}
+
+// UnaryServerInterceptor returns an interceptor taking the request ID from
+// the incoming metadata if it holds a valid ID, else issuing a new one. The
+// ID is sent back in the response header metadata and carried in the
+// handler's context.
+func (m RequestID) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := m.accept(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns the stream counterpart of
+// UnaryServerInterceptor.
+func (m RequestID) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := m.accept(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, serverStream{ss, ctx})
+	}
+}
+
+// UnaryClientInterceptor returns an interceptor sending the request ID of
+// the call's context (see kidhttp.FromContext), or a new ID if it has none,
+// in the outgoing metadata. An ID already set in the outgoing metadata is
+// left alone.
+func (m RequestID) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(m.send(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor returns the stream counterpart of
+// UnaryClientInterceptor.
+func (m RequestID) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(m.send(ctx), desc, cc, method, opts...)
+	}
+}
+
+func (m RequestID) key() string {
+	if m.Key == "" {
+		return MetadataKey
+	}
+	return m.Key
+}
+
+func (m RequestID) newID() kid.ID {
+	if m.Generator != nil {
+		return m.Generator.New()
+	}
+	return kid.New()
+}
+
+// accept establishes the request ID of an incoming call, returning ctx
+// carrying it.
+func (m RequestID) accept(ctx context.Context) (context.Context, error) {
+	var id kid.ID
+	err := kid.ErrInvalidID
+	if md, ok := metadata.FromIncomingContext(ctx); ok && !m.IgnoreIncoming {
+		if v := md.Get(m.key()); len(v) == 1 {
+			id, err = kid.FromString(v[0])
+		}
+	}
+	if err != nil {
+		id = m.newID()
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(m.key(), id.String())); err != nil {
+		return nil, err
+	}
+	return kidhttp.NewContext(ctx, id), nil
+}
+
+// send returns ctx with the request ID in its outgoing metadata.
+func (m RequestID) send(ctx context.Context) context.Context {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(m.key())) > 0 {
+		return ctx
+	}
+	id, ok := kidhttp.FromContext(ctx)
+	if !ok {
+		id = m.newID()
+	}
+	return metadata.AppendToOutgoingContext(ctx, m.key(), id.String())
+}
+
+// serverStream replaces a stream's context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,138 @@
+package kidgrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// health records the request ID each call's context carries.
+type health struct {
+	healthpb.UnimplementedHealthServer
+	seen chan kid.ID
+}
+
+func (h *health) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	id, _ := kidhttp.FromContext(ctx)
+	h.seen <- id
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (h *health) Watch(_ *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	id, _ := kidhttp.FromContext(stream.Context())
+	h.seen <- id
+	return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+}
+
+// start serves h behind the server interceptors of m, returning a client
+// connection optionally using the client interceptors.
+func start(t *testing.T, m RequestID, intercept bool) (healthpb.HealthClient, *health) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 16)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(m.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(m.StreamServerInterceptor()),
+	)
+	h := &health{seen: make(chan kid.ID, 1)}
+	healthpb.RegisterHealthServer(srv, h)
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+	}
+	if intercept {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+			grpc.WithChainStreamInterceptor(StreamClientInterceptor()),
+		)
+	}
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn), h
+}
+
+func TestPropagation(t *testing.T) {
+	client, h := start(t, RequestID{}, true)
+	id := kid.New()
+	ctx := kidhttp.NewContext(context.Background(), id)
+
+	var header metadata.MD
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatal(err)
+	}
+	if seen := <-h.seen; seen != id {
+		t.Errorf("unary handler saw %v, want %v", seen, id)
+	}
+	if got := header.Get(MetadataKey); len(got) != 1 || got[0] != id.String() {
+		t.Errorf("response header %v, want %v", got, id)
+	}
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	if seen := <-h.seen; seen != id {
+		t.Errorf("stream handler saw %v, want %v", seen, id)
+	}
+
+	// without an ID in the context, the client issues one
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatal(err)
+	}
+	if seen := <-h.seen; seen.IsNil() || header.Get(MetadataKey)[0] != seen.String() {
+		t.Errorf("handler saw %v, response header %v; want the same new ID", seen, header.Get(MetadataKey))
+	}
+}
+
+func TestServerValidates(t *testing.T) {
+	client, h := start(t, RequestID{}, false)
+	for _, incoming := range []string{"", "not-an-id", "06BQER9XNM79TFNL"} {
+		ctx := context.Background()
+		if incoming != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, incoming)
+		}
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+			t.Fatal(err)
+		}
+		if seen := <-h.seen; seen.IsNil() || seen.String() == incoming {
+			t.Errorf("incoming %q: handler saw %v, want a new ID", incoming, seen)
+		}
+	}
+
+	// an ID the caller set itself is kept
+	id := kid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), MetadataKey, id.String())
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if seen := <-h.seen; seen != id {
+		t.Errorf("handler saw %v, want %v", seen, id)
+	}
+}
+
+func TestIgnoreIncoming(t *testing.T) {
+	client, h := start(t, RequestID{IgnoreIncoming: true}, true)
+	id := kid.New()
+	if _, err := client.Check(kidhttp.NewContext(context.Background(), id), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if seen := <-h.seen; seen.IsNil() || seen == id {
+		t.Errorf("handler saw %v, want a new ID", seen)
+	}
+}
@@ -0,0 +1,96 @@
+package kidgrpc
+
+//go:generate protoc -I . --go_out=. --go_opt=module=github.com/mwyvr/kid/kidgrpc --go-grpc_out=. --go-grpc_opt=module=github.com/mwyvr/kid/kidgrpc kid/issuer/v1/issuer.proto
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidgrpc/issuerpb"
+	"google.golang.org/grpc"
+)
+
+const (
+	// MaxBatch is the largest count a GenerateBatch call may ask for.
+	MaxBatch = 1000000
+
+	// batchChunk is the most IDs sent in one GenerateBatch message.
+	batchChunk = 1000
+)
+
+// Issuer implements the kid.issuer.v1.IssuerService of
+// kid/issuer/v1/issuer.proto, issuing IDs from one Generator; its zero value
+// uses kid.New.
+type Issuer struct {
+	issuerpb.UnimplementedIssuerServiceServer
+
+	// Generator issues the IDs; nil uses the package-level New.
+	Generator *kid.Generator
+}
+
+// RegisterIssuer registers an Issuer drawing from g, or the package-level
+// New if g is nil, with s.
+func RegisterIssuer(s grpc.ServiceRegistrar, g *kid.Generator) {
+	issuerpb.RegisterIssuerServiceServer(s, &Issuer{Generator: g})
+}
+
+// newID issues an ID, returning the Generator's errors (a clock regression
+// under ErrorOnRegression, say) rather than panicking as New would: grpc-go
+// does not recover handler panics.
+func (s *Issuer) newID() (kid.ID, error) {
+	if s.Generator != nil {
+		return s.Generator.Generate()
+	}
+	return kid.New(), nil
+}
+
+// Generate issues one new ID. Generator errors are returned with their
+// kid.Code (see Error).
+func (s *Issuer) Generate(context.Context, *issuerpb.GenerateRequest) (*issuerpb.GenerateResponse, error) {
+	id, err := s.newID()
+	if err != nil {
+		return nil, Error(err)
+	}
+	return &issuerpb.GenerateResponse{Id: id.String()}, nil
+}
+
+// GenerateBatch streams req.Count new IDs in chunks of at most 1000, issuing
+// each chunk as it is sent. A Generator error ends the stream, after the
+// chunks already sent.
+func (s *Issuer) GenerateBatch(req *issuerpb.GenerateBatchRequest, stream grpc.ServerStreamingServer[issuerpb.GenerateBatchResponse]) error {
+	n := int(req.GetCount())
+	if n < 1 || n > MaxBatch {
+		return Error(&kid.Error{Code: kid.CodeInvalidArgument, Message: fmt.Sprintf("count must be 1-%d", MaxBatch)})
+	}
+	for n > 0 {
+		ids := make([]string, min(n, batchChunk))
+		for i := range ids {
+			id, err := s.newID()
+			if err != nil {
+				return Error(err)
+			}
+			ids[i] = id.String()
+		}
+		if err := stream.Send(&issuerpb.GenerateBatchResponse{Ids: ids}); err != nil {
+			return err
+		}
+		n -= len(ids)
+	}
+	return nil
+}
+
+// Decode breaks req.Id into its components.
+func (s *Issuer) Decode(_ context.Context, req *issuerpb.DecodeRequest) (*issuerpb.DecodeResponse, error) {
+	id, err := kid.FromString(req.GetId())
+	if err != nil {
+		return nil, Error(err)
+	}
+	return &issuerpb.DecodeResponse{
+		Id:        id.String(),
+		Raw:       id.Bytes(),
+		Timestamp: id.Timestamp(),
+		Sequence:  id.Sequence(),
+		Random:    id.Random(),
+	}, nil
+}
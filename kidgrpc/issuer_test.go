@@ -0,0 +1,143 @@
+package kidgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidgrpc/issuerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+func issuer(t *testing.T, g *kid.Generator) issuerpb.IssuerServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 16)
+	srv := grpc.NewServer()
+	RegisterIssuer(srv, g)
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return issuerpb.NewIssuerServiceClient(conn)
+}
+
+func TestIssuerGenerate(t *testing.T) {
+	at := time.UnixMilli(1741456227757)
+	client := issuer(t, kid.NewGenerator(kid.WithClock(func() time.Time { return at })))
+	resp, err := client.Generate(context.Background(), &issuerpb.GenerateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := kid.FromString(resp.GetId())
+	if err != nil {
+		t.Fatalf("Generate() = %q: %v", resp.GetId(), err)
+	}
+	if !id.Time().Equal(at) {
+		t.Errorf("Generate() time = %v, want %v, from the Generator", id.Time(), at)
+	}
+}
+
+func TestIssuerGenerateBatch(t *testing.T) {
+	client := issuer(t, nil)
+	for _, n := range []uint32{1, batchChunk, 2*batchChunk + 1} {
+		stream, err := client.GenerateBatch(context.Background(), &issuerpb.GenerateBatchRequest{Count: n})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.GetIds()) > batchChunk {
+				t.Errorf("GenerateBatch(%d) sent %d IDs in one message", n, len(resp.GetIds()))
+			}
+			ids = append(ids, resp.GetIds()...)
+		}
+		if len(ids) != int(n) {
+			t.Fatalf("GenerateBatch(%d) = %d IDs", n, len(ids))
+		}
+		for i := 1; i < len(ids); i++ {
+			if ids[i] <= ids[i-1] {
+				t.Fatalf("GenerateBatch(%d): %s does not sort after %s", n, ids[i], ids[i-1])
+			}
+		}
+	}
+
+	for _, n := range []uint32{0, MaxBatch + 1} {
+		stream, err := client.GenerateBatch(context.Background(), &issuerpb.GenerateBatchRequest{Count: n})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GenerateBatch(%d) error = %v, want InvalidArgument", n, err)
+		}
+	}
+}
+
+func TestIssuerDecode(t *testing.T) {
+	client := issuer(t, nil)
+	resp, err := client.Decode(context.Background(), &issuerpb.DecodeRequest{Id: "06bqer9xnm79tfnl"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &issuerpb.DecodeResponse{
+		Id:        "06bqer9xnm79tfnl",
+		Raw:       []byte{0x01, 0x95, 0x76, 0xe1, 0x3d, 0xad, 0x0e, 0x9d, 0x3a, 0xb3},
+		Timestamp: 1741456227757,
+		Sequence:  3741,
+		Random:    15027,
+	}
+	if !proto.Equal(resp, want) {
+		t.Errorf("Decode() = %v, want %v", resp, want)
+	}
+
+	if _, err := client.Decode(context.Background(), &issuerpb.DecodeRequest{Id: "06bqer9xnm79tfna"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Decode(invalid) error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestIssuerGeneratorErrors(t *testing.T) {
+	at := time.UnixMilli(1741456227757)
+	g := kid.NewGenerator(kid.WithClock(func() time.Time { return at }), kid.WithRegressionPolicy(kid.ErrorOnRegression))
+	client := issuer(t, g)
+	if _, err := client.Generate(context.Background(), &issuerpb.GenerateRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	at = at.Add(-time.Hour)
+
+	_, err := client.Generate(context.Background(), &issuerpb.GenerateRequest{})
+	if status.Code(err) != codes.Unavailable || !errors.Is(FromError(err), kid.ErrClockRegression) {
+		t.Errorf("Generate() after a regression error = %v, want Unavailable, ErrClockRegression", err)
+	}
+	stream, err := client.GenerateBatch(context.Background(), &issuerpb.GenerateBatchRequest{Count: 10})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if !errors.Is(FromError(err), kid.ErrClockRegression) {
+		t.Errorf("GenerateBatch() after a regression error = %v, want ErrClockRegression", err)
+	}
+	// the server survived to answer
+	if _, err := client.Decode(context.Background(), &issuerpb.DecodeRequest{Id: "06bqer9xnm79tfnl"}); err != nil {
+		t.Errorf("Decode() after the errors: %v", err)
+	}
+}
@@ -0,0 +1,401 @@
+// The kid ID-issuing service; see package
+// github.com/mwyvr/kid/kidgrpc for the Go server.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: kid/issuer/v1/issuer.proto
+
+package issuerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GenerateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_kid_issuer_v1_issuer_proto_rawDescGZIP(), []int{0}
+}
+
+type GenerateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_kid_issuer_v1_issuer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GenerateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GenerateBatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count is the number of IDs wanted, 1 to 1000000.
+	Count         uint32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateBatchRequest) Reset() {
+	*x = GenerateBatchRequest{}
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateBatchRequest) ProtoMessage() {}
+
+func (x *GenerateBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateBatchRequest.ProtoReflect.Descriptor instead.
+func (*GenerateBatchRequest) Descriptor() ([]byte, []int) {
+	return file_kid_issuer_v1_issuer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GenerateBatchRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GenerateBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateBatchResponse) Reset() {
+	*x = GenerateBatchResponse{}
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateBatchResponse) ProtoMessage() {}
+
+func (x *GenerateBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateBatchResponse.ProtoReflect.Descriptor instead.
+func (*GenerateBatchResponse) Descriptor() ([]byte, []int) {
+	return file_kid_issuer_v1_issuer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GenerateBatchResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type DecodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecodeRequest) Reset() {
+	*x = DecodeRequest{}
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodeRequest) ProtoMessage() {}
+
+func (x *DecodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodeRequest.ProtoReflect.Descriptor instead.
+func (*DecodeRequest) Descriptor() ([]byte, []int) {
+	return file_kid_issuer_v1_issuer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DecodeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DecodeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// raw is the ID's 10 bytes: 6-byte timestamp, 2-byte sequence and 2-byte
+	// random value, big-endian.
+	Raw []byte `protobuf:"bytes,2,opt,name=raw,proto3" json:"raw,omitempty"`
+	// timestamp is in milliseconds since the Unix epoch.
+	Timestamp     int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Sequence      int32 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Random        int32 `protobuf:"varint,5,opt,name=random,proto3" json:"random,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecodeResponse) Reset() {
+	*x = DecodeResponse{}
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodeResponse) ProtoMessage() {}
+
+func (x *DecodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kid_issuer_v1_issuer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodeResponse.ProtoReflect.Descriptor instead.
+func (*DecodeResponse) Descriptor() ([]byte, []int) {
+	return file_kid_issuer_v1_issuer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DecodeResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DecodeResponse) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *DecodeResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *DecodeResponse) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *DecodeResponse) GetRandom() int32 {
+	if x != nil {
+		return x.Random
+	}
+	return 0
+}
+
+var File_kid_issuer_v1_issuer_proto protoreflect.FileDescriptor
+
+const file_kid_issuer_v1_issuer_proto_rawDesc = "" +
+	"\n" +
+	"\x1akid/issuer/v1/issuer.proto\x12\rkid.issuer.v1\"\x11\n" +
+	"\x0fGenerateRequest\"\"\n" +
+	"\x10GenerateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x14GenerateBatchRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\rR\x05count\")\n" +
+	"\x15GenerateBatchResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"\x1f\n" +
+	"\rDecodeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x84\x01\n" +
+	"\x0eDecodeResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03raw\x18\x02 \x01(\fR\x03raw\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x05R\bsequence\x12\x16\n" +
+	"\x06random\x18\x05 \x01(\x05R\x06random2\x81\x02\n" +
+	"\rIssuerService\x12K\n" +
+	"\bGenerate\x12\x1e.kid.issuer.v1.GenerateRequest\x1a\x1f.kid.issuer.v1.GenerateResponse\x12\\\n" +
+	"\rGenerateBatch\x12#.kid.issuer.v1.GenerateBatchRequest\x1a$.kid.issuer.v1.GenerateBatchResponse0\x01\x12E\n" +
+	"\x06Decode\x12\x1c.kid.issuer.v1.DecodeRequest\x1a\x1d.kid.issuer.v1.DecodeResponseB'Z%github.com/mwyvr/kid/kidgrpc/issuerpbb\x06proto3"
+
+var (
+	file_kid_issuer_v1_issuer_proto_rawDescOnce sync.Once
+	file_kid_issuer_v1_issuer_proto_rawDescData []byte
+)
+
+func file_kid_issuer_v1_issuer_proto_rawDescGZIP() []byte {
+	file_kid_issuer_v1_issuer_proto_rawDescOnce.Do(func() {
+		file_kid_issuer_v1_issuer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kid_issuer_v1_issuer_proto_rawDesc), len(file_kid_issuer_v1_issuer_proto_rawDesc)))
+	})
+	return file_kid_issuer_v1_issuer_proto_rawDescData
+}
+
+var file_kid_issuer_v1_issuer_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_kid_issuer_v1_issuer_proto_goTypes = []any{
+	(*GenerateRequest)(nil),       // 0: kid.issuer.v1.GenerateRequest
+	(*GenerateResponse)(nil),      // 1: kid.issuer.v1.GenerateResponse
+	(*GenerateBatchRequest)(nil),  // 2: kid.issuer.v1.GenerateBatchRequest
+	(*GenerateBatchResponse)(nil), // 3: kid.issuer.v1.GenerateBatchResponse
+	(*DecodeRequest)(nil),         // 4: kid.issuer.v1.DecodeRequest
+	(*DecodeResponse)(nil),        // 5: kid.issuer.v1.DecodeResponse
+}
+var file_kid_issuer_v1_issuer_proto_depIdxs = []int32{
+	0, // 0: kid.issuer.v1.IssuerService.Generate:input_type -> kid.issuer.v1.GenerateRequest
+	2, // 1: kid.issuer.v1.IssuerService.GenerateBatch:input_type -> kid.issuer.v1.GenerateBatchRequest
+	4, // 2: kid.issuer.v1.IssuerService.Decode:input_type -> kid.issuer.v1.DecodeRequest
+	1, // 3: kid.issuer.v1.IssuerService.Generate:output_type -> kid.issuer.v1.GenerateResponse
+	3, // 4: kid.issuer.v1.IssuerService.GenerateBatch:output_type -> kid.issuer.v1.GenerateBatchResponse
+	5, // 5: kid.issuer.v1.IssuerService.Decode:output_type -> kid.issuer.v1.DecodeResponse
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_kid_issuer_v1_issuer_proto_init() }
+func file_kid_issuer_v1_issuer_proto_init() {
+	if File_kid_issuer_v1_issuer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kid_issuer_v1_issuer_proto_rawDesc), len(file_kid_issuer_v1_issuer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kid_issuer_v1_issuer_proto_goTypes,
+		DependencyIndexes: file_kid_issuer_v1_issuer_proto_depIdxs,
+		MessageInfos:      file_kid_issuer_v1_issuer_proto_msgTypes,
+	}.Build()
+	File_kid_issuer_v1_issuer_proto = out.File
+	file_kid_issuer_v1_issuer_proto_goTypes = nil
+	file_kid_issuer_v1_issuer_proto_depIdxs = nil
+}
@@ -0,0 +1,222 @@
+// The kid ID-issuing service; see package
+// github.com/mwyvr/kid/kidgrpc for the Go server.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: kid/issuer/v1/issuer.proto
+
+package issuerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	IssuerService_Generate_FullMethodName      = "/kid.issuer.v1.IssuerService/Generate"
+	IssuerService_GenerateBatch_FullMethodName = "/kid.issuer.v1.IssuerService/GenerateBatch"
+	IssuerService_Decode_FullMethodName        = "/kid.issuer.v1.IssuerService/Decode"
+)
+
+// IssuerServiceClient is the client API for IssuerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// IssuerService issues kid IDs from a central Generator, for platforms that
+// cannot link the library. IDs are carried in their 16-character encoded
+// form.
+type IssuerServiceClient interface {
+	// Generate issues one new ID.
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+	// GenerateBatch issues count new IDs, in ascending order, streamed in
+	// chunks of at most 1000.
+	GenerateBatch(ctx context.Context, in *GenerateBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateBatchResponse], error)
+	// Decode breaks an encoded ID into its components. An invalid ID is
+	// answered with INVALID_ARGUMENT.
+	Decode(ctx context.Context, in *DecodeRequest, opts ...grpc.CallOption) (*DecodeResponse, error)
+}
+
+type issuerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIssuerServiceClient(cc grpc.ClientConnInterface) IssuerServiceClient {
+	return &issuerServiceClient{cc}
+}
+
+func (c *issuerServiceClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, IssuerService_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *issuerServiceClient) GenerateBatch(ctx context.Context, in *GenerateBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateBatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IssuerService_ServiceDesc.Streams[0], IssuerService_GenerateBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GenerateBatchRequest, GenerateBatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IssuerService_GenerateBatchClient = grpc.ServerStreamingClient[GenerateBatchResponse]
+
+func (c *issuerServiceClient) Decode(ctx context.Context, in *DecodeRequest, opts ...grpc.CallOption) (*DecodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecodeResponse)
+	err := c.cc.Invoke(ctx, IssuerService_Decode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IssuerServiceServer is the server API for IssuerService service.
+// All implementations must embed UnimplementedIssuerServiceServer
+// for forward compatibility.
+//
+// IssuerService issues kid IDs from a central Generator, for platforms that
+// cannot link the library. IDs are carried in their 16-character encoded
+// form.
+type IssuerServiceServer interface {
+	// Generate issues one new ID.
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	// GenerateBatch issues count new IDs, in ascending order, streamed in
+	// chunks of at most 1000.
+	GenerateBatch(*GenerateBatchRequest, grpc.ServerStreamingServer[GenerateBatchResponse]) error
+	// Decode breaks an encoded ID into its components. An invalid ID is
+	// answered with INVALID_ARGUMENT.
+	Decode(context.Context, *DecodeRequest) (*DecodeResponse, error)
+	mustEmbedUnimplementedIssuerServiceServer()
+}
+
+// UnimplementedIssuerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedIssuerServiceServer struct{}
+
+func (UnimplementedIssuerServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedIssuerServiceServer) GenerateBatch(*GenerateBatchRequest, grpc.ServerStreamingServer[GenerateBatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateBatch not implemented")
+}
+func (UnimplementedIssuerServiceServer) Decode(context.Context, *DecodeRequest) (*DecodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decode not implemented")
+}
+func (UnimplementedIssuerServiceServer) mustEmbedUnimplementedIssuerServiceServer() {}
+func (UnimplementedIssuerServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeIssuerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IssuerServiceServer will
+// result in compilation errors.
+type UnsafeIssuerServiceServer interface {
+	mustEmbedUnimplementedIssuerServiceServer()
+}
+
+func RegisterIssuerServiceServer(s grpc.ServiceRegistrar, srv IssuerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedIssuerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&IssuerService_ServiceDesc, srv)
+}
+
+func _IssuerService_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuerServiceServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssuerService_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuerServiceServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IssuerService_GenerateBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IssuerServiceServer).GenerateBatch(m, &grpc.GenericServerStream[GenerateBatchRequest, GenerateBatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IssuerService_GenerateBatchServer = grpc.ServerStreamingServer[GenerateBatchResponse]
+
+func _IssuerService_Decode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuerServiceServer).Decode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IssuerService_Decode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuerServiceServer).Decode(ctx, req.(*DecodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IssuerService_ServiceDesc is the grpc.ServiceDesc for IssuerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IssuerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kid.issuer.v1.IssuerService",
+	HandlerType: (*IssuerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Generate",
+			Handler:    _IssuerService_Generate_Handler,
+		},
+		{
+			MethodName: "Decode",
+			Handler:    _IssuerService_Decode_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateBatch",
+			Handler:       _IssuerService_GenerateBatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "kid/issuer/v1/issuer.proto",
+}
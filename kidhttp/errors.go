@@ -0,0 +1,88 @@
+package kidhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/mwyvr/kid"
+)
+
+// Status returns the HTTP status code answering an error with code c:
+// 400 for a bad request, 429 for backpressure and exhausted quotas, 503
+// for the other temporary errors and 500 for the rest.
+func Status(c kid.Code) int {
+	switch c {
+	case kid.CodeInvalidID, kid.CodeInvalidArgument, kid.CodeTimeRange, kid.CodeBadSignature:
+		return http.StatusBadRequest
+	case kid.CodeBackpressure, kid.CodeQuotaExceeded:
+		return http.StatusTooManyRequests
+	case kid.CodeClockRegression, kid.CodeNodeLost, kid.CodeNoFreeNode, kid.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// Error replies to a request with err as a JSON kid.Error, with the status
+// code of its code (see Status):
+//
+//	{"code":"invalid_id","message":"kid: invalid id: length 7, want 16"}
+//
+// Messages of CodeInternal errors are replaced by the status text, so
+// server internals do not leak to clients. Clients decode the reply with
+// ReadError.
+func Error(w http.ResponseWriter, err error) {
+	e := kid.NewError(err)
+	code := Status(e.Code)
+	if e.Code == kid.CodeInternal {
+		e.Message = http.StatusText(code)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(e) //nolint:errcheck
+}
+
+// ReadError returns nil if resp succeeded, with a 2xx status code, and
+// otherwise the error it reports: the *kid.Error of an Error reply, or for
+// any other body, such as a proxy's, a *kid.Error with the body's first
+// line as message and a code from the status: CodeInvalidArgument for 400,
+// CodeUnavailable, which is Temporary, for 429 and 503, otherwise
+// CodeInternal. It reads, but does not close, resp.Body.
+func ReadError(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return fmt.Errorf("kidhttp: reading error reply: %w", err)
+	}
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mt == "application/json" {
+		var e kid.Error
+		if json.Unmarshal(body, &e) == nil && e.Code != "" {
+			return &e
+		}
+	}
+	e := &kid.Error{Code: kid.CodeInternal, Message: firstLine(body)}
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		e.Code = kid.CodeInvalidArgument
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		e.Code = kid.CodeUnavailable
+	}
+	if e.Message == "" {
+		e.Message = resp.Status
+	}
+	return e
+}
+
+func firstLine(b []byte) string {
+	for i, c := range b {
+		if c == '\n' || c == '\r' {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
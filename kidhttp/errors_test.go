@@ -0,0 +1,83 @@
+package kidhttp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mwyvr/kid"
+)
+
+func TestErrorRoundTrip(t *testing.T) {
+	tests := []struct {
+		err       error
+		status    int
+		code      kid.Code
+		is        error
+		msg       string
+		temporary bool
+	}{
+		{&kid.InvalidLengthError{Got: 7, Want: 16}, 400, kid.CodeInvalidID, kid.ErrInvalidID, "kid: invalid id: length 7, want 16", false},
+		{&kid.Error{Code: kid.CodeInvalidArgument, Message: "n must be 1-10"}, 400, kid.CodeInvalidArgument, nil, "n must be 1-10", false},
+		{fmt.Errorf("%w: 3ms ahead", kid.ErrBackpressure), 429, kid.CodeBackpressure, kid.ErrBackpressure, "kid: generator too far ahead of the clock: 3ms ahead", true},
+		{kid.ErrNoFreeNode, 503, kid.CodeNoFreeNode, kid.ErrNoFreeNode, "kid: no free node value", true},
+		{errors.New("disk on fire"), 500, kid.CodeInternal, nil, "Internal Server Error", false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		Error(rec, tt.err)
+		resp := rec.Result()
+		if resp.StatusCode != tt.status {
+			t.Errorf("Error(%v) status = %d, want %d", tt.err, resp.StatusCode, tt.status)
+		}
+		err := ReadError(resp)
+		var e *kid.Error
+		if !errors.As(err, &e) || e.Code != tt.code || e.Message != tt.msg {
+			t.Errorf("ReadError() after Error(%v) = %#v, want code %q, message %q", tt.err, err, tt.code, tt.msg)
+			continue
+		}
+		if tt.is != nil && !errors.Is(err, tt.is) {
+			t.Errorf("errors.Is(%v, %v) = false", err, tt.is)
+		}
+		if kid.IsTemporary(err) != tt.temporary {
+			t.Errorf("IsTemporary(%v) = %t, want %t", err, !tt.temporary, tt.temporary)
+		}
+	}
+}
+
+func TestReadErrorOther(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		code   kid.Code
+		msg    string
+	}{
+		{200, "06bqer9xnm79tfnl\n", "", ""},
+		{400, "n must be 1-10000\n", kid.CodeInvalidArgument, "n must be 1-10000"},
+		{503, "", kid.CodeUnavailable, "503 Service Unavailable"},
+		{502, "<html>bad gateway</html>", kid.CodeInternal, "<html>bad gateway</html>"},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{
+			StatusCode: tt.status,
+			Status:     fmt.Sprintf("%d %s", tt.status, http.StatusText(tt.status)),
+			Header:     http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+			Body:       io.NopCloser(strings.NewReader(tt.body)),
+		}
+		err := ReadError(resp)
+		if tt.code == "" {
+			if err != nil {
+				t.Errorf("ReadError(%d) = %v, want nil", tt.status, err)
+			}
+			continue
+		}
+		var e *kid.Error
+		if !errors.As(err, &e) || e.Code != tt.code || e.Message != tt.msg {
+			t.Errorf("ReadError(%d %q) = %#v, want code %q, message %q", tt.status, tt.body, err, tt.code, tt.msg)
+		}
+	}
+}
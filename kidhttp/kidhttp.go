@@ -0,0 +1,81 @@
+// Package kidhttp provides net/http middleware giving each request a kid.ID
+// as its request ID: taken from the incoming X-Request-ID header if that
+// holds a valid, non-nil ID, otherwise newly generated, then set on the response
+// header and carried in the request's context.
+//
+//	http.ListenAndServe(addr, kidhttp.Middleware(mux))
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//		rid, _ := kidhttp.FromContext(r.Context())
+//		slog.Info("handling", "request_id", rid)
+//	}
+//
+// Error and ReadError carry kid's error codes (kid.Code) across HTTP, for
+// servers issuing or checking IDs and their clients.
+package kidhttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mwyvr/kid"
+)
+
+// Header is the request and response header carrying the request ID.
+const Header = "X-Request-ID"
+
+// RequestID is the request ID middleware, configurable; its zero value is
+// ready to use.
+type RequestID struct {
+	// Generator issues new request IDs; nil uses kid.New.
+	Generator *kid.Generator
+
+	// Header names the header read and written, Header if empty.
+	Header string
+
+	// IgnoreIncoming, if set, always issues a new ID, for servers facing
+	// clients whose request IDs should not be trusted.
+	IgnoreIncoming bool
+}
+
+// Middleware wraps next with the default RequestID middleware.
+func Middleware(next http.Handler) http.Handler {
+	return RequestID{}.Wrap(next)
+}
+
+// Wrap returns next wrapped with the middleware m.
+func (m RequestID) Wrap(next http.Handler) http.Handler {
+	header := m.Header
+	if header == "" {
+		header = Header
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := kid.FromString(r.Header.Get(header))
+		if err != nil || id.IsNil() || m.IgnoreIncoming {
+			id = m.newID()
+		}
+		w.Header().Set(header, id.String())
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+func (m RequestID) newID() kid.ID {
+	if m.Generator != nil {
+		return m.Generator.New()
+	}
+	return kid.New()
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying id as its request ID.
+func NewContext(ctx context.Context, id kid.ID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, as set by the
+// middleware or NewContext, and whether there was one.
+func FromContext(ctx context.Context) (kid.ID, bool) {
+	id, ok := ctx.Value(contextKey{}).(kid.ID)
+	return id, ok
+}
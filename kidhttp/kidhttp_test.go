@@ -0,0 +1,77 @@
+package kidhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mwyvr/kid"
+)
+
+// serve runs h on a request carrying the header value incoming, if not
+// empty, returning the response header value and the ID h saw.
+func serve(t *testing.T, h func(http.Handler) http.Handler, header, incoming string) (string, kid.ID) {
+	t.Helper()
+	var seen kid.ID
+	var ok bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, ok = FromContext(r.Context())
+	})
+	r := httptest.NewRequest("GET", "/", nil)
+	if incoming != "" {
+		r.Header.Set(header, incoming)
+	}
+	w := httptest.NewRecorder()
+	h(next).ServeHTTP(w, r)
+	if !ok {
+		t.Fatal("no request ID in the handler's context")
+	}
+	return w.Header().Get(header), seen
+}
+
+func TestMiddleware(t *testing.T) {
+	// a new ID without a header
+	got, seen := serve(t, Middleware, Header, "")
+	if seen.IsNil() || got != seen.String() {
+		t.Errorf("response header %q, context %v; want a new ID in both", got, seen)
+	}
+
+	// a valid incoming ID is kept
+	incoming := kid.New().String()
+	if got, seen := serve(t, Middleware, Header, incoming); got != incoming || seen.String() != incoming {
+		t.Errorf("response header %q, context %v; want %s", got, seen, incoming)
+	}
+
+	// an invalid or nil one is replaced
+	for _, bad := range []string{"not-an-id", "06BQER9XNM79TFNL", incoming + "x", kid.Nil.String()} {
+		if got, seen := serve(t, Middleware, Header, bad); got == bad || seen.IsNil() || got != seen.String() {
+			t.Errorf("incoming %q: response header %q, context %v; want a new ID", bad, got, seen)
+		}
+	}
+}
+
+func TestRequestIDOptions(t *testing.T) {
+	at := time.Date(2025, 3, 8, 17, 50, 27, 0, time.UTC)
+	m := RequestID{
+		Generator:      kid.NewGenerator(kid.WithClock(func() time.Time { return at })),
+		Header:         "X-Correlation-ID",
+		IgnoreIncoming: true,
+	}
+	incoming := kid.New().String()
+	got, seen := serve(t, m.Wrap, "X-Correlation-ID", incoming)
+	if got == incoming || got != seen.String() || !seen.Time().Equal(at) {
+		t.Errorf("response header %q, context %v; want a new ID from the Generator", got, seen)
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("FromContext(Background) reported an ID")
+	}
+	id := kid.New()
+	if got, ok := FromContext(NewContext(context.Background(), id)); !ok || got != id {
+		t.Errorf("FromContext(NewContext(id)) = %v, %v, want %v", got, ok, id)
+	}
+}
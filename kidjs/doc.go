@@ -0,0 +1,28 @@
+// Package kidjs exposes kid to JavaScript when built for js/wasm, so web
+// frontends can generate IDs matching the backend's, e.g. to create records
+// offline or optimistically. Register installs a global kid object:
+//
+//	kid.newId()      // "06bqer9xnm79tfnl"
+//	kid.decode(s)    // {id, hex, timestamp, time, sequence, random}, or null
+//
+// decode answers null for a string that is not a valid ID; timestamp is in
+// Unix milliseconds and time a Date.
+//
+// cmd/kidwasm is a ready-made module registering the bindings:
+//
+//	$ GOOS=js GOARCH=wasm go build -o kid.wasm ./cmd/kidwasm
+//	$ cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .  # misc/wasm before Go 1.24
+//
+//	<script src="wasm_exec.js"></script>
+//	<script>
+//	  const go = new Go();
+//	  WebAssembly.instantiateStreaming(fetch("kid.wasm"), go.importObject)
+//	    .then((r) => { go.run(r.instance); console.log(kid.newId()); });
+//	</script>
+//
+// The tests run in a browser with wasmbrowsertest, or under Node.js with
+// the toolchain's go_js_wasm_exec on PATH:
+//
+//	$ go install github.com/agnivade/wasmbrowsertest@latest
+//	$ GOOS=js GOARCH=wasm go test -exec wasmbrowsertest ./kidjs
+package kidjs
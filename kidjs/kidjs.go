@@ -0,0 +1,42 @@
+//go:build js && wasm
+
+package kidjs
+
+import (
+	"syscall/js"
+
+	"github.com/mwyvr/kid"
+)
+
+// Register installs the kid object, with the functions described in the
+// package documentation, as a global of the JavaScript environment.
+func Register() {
+	js.Global().Set("kid", js.ValueOf(map[string]any{
+		"newId":  js.FuncOf(newID),
+		"decode": js.FuncOf(decode),
+	}))
+}
+
+// newID implements kid.newId().
+func newID(js.Value, []js.Value) any {
+	return kid.New().String()
+}
+
+// decode implements kid.decode(s).
+func decode(_ js.Value, args []js.Value) any {
+	if len(args) != 1 || args[0].Type() != js.TypeString {
+		return nil
+	}
+	id, err := kid.FromString(args[0].String())
+	if err != nil {
+		return nil
+	}
+	return map[string]any{
+		"id":        id.String(),
+		"hex":       id.Hex(),
+		"timestamp": id.Timestamp(),
+		"time":      js.Global().Get("Date").New(id.Timestamp()),
+		"sequence":  id.Sequence(),
+		"random":    id.Random(),
+	}
+}
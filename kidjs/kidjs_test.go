@@ -0,0 +1,47 @@
+//go:build js && wasm
+
+package kidjs
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/mwyvr/kid"
+)
+
+func TestBindings(t *testing.T) {
+	Register()
+	k := js.Global().Get("kid")
+
+	s := k.Call("newId").String()
+	id, err := kid.FromString(s)
+	if err != nil || id.IsNil() {
+		t.Fatalf("newId() = %q: %v", s, err)
+	}
+	if next := k.Call("newId").String(); next <= s {
+		t.Errorf("newId() = %q after %q, want ascending", next, s)
+	}
+
+	d := k.Call("decode", "06bqer9xnm79tfnl")
+	if got := d.Get("id").String(); got != "06bqer9xnm79tfnl" {
+		t.Errorf("decode().id = %q", got)
+	}
+	if got := d.Get("hex").String(); got != "019576e13dad0e9d3ab3" {
+		t.Errorf("decode().hex = %q", got)
+	}
+	if got := d.Get("timestamp").Int(); got != 1741456227757 {
+		t.Errorf("decode().timestamp = %d", got)
+	}
+	if got := d.Get("time").Call("toISOString").String(); got != "2025-03-08T17:50:27.757Z" {
+		t.Errorf("decode().time = %s", got)
+	}
+	if seq, rnd := d.Get("sequence").Int(), d.Get("random").Int(); seq != 3741 || rnd != 15027 {
+		t.Errorf("decode() sequence, random = %d, %d, want 3741, 15027", seq, rnd)
+	}
+
+	for _, arg := range []any{"06bqer9xnm79tfna", "", 42, nil} {
+		if got := k.Call("decode", arg); !got.IsNull() {
+			t.Errorf("decode(%v) = %v, want null", arg, got)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Package kidotel bridges kid IDs and OpenTelemetry trace and span IDs, so
+// request IDs and traces can be correlated, or unified:
+//
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithIDGenerator(kidotel.IDGenerator{FromRequest: true}))
+//
+// makes the trace of each request handled behind kidhttp's middleware carry
+// the request ID, recoverable with FromTraceID.
+//
+// A 16-byte trace ID holds an ID losslessly: its 10 bytes followed by 6
+// check bytes derived from them, which keep the trace ID's right half
+// well mixed for ratio-based samplers and let FromTraceID tell derived
+// trace IDs from others. An 8-byte span ID cannot hold an ID, so SpanID is
+// one-way.
+package kidotel
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidhttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceID returns the trace ID holding id; see the package documentation
+// for the layout. The nil ID yields the invalid, all-zero trace ID.
+func TraceID(id kid.ID) trace.TraceID {
+	var t trace.TraceID
+	if id.IsNil() {
+		return t
+	}
+	copy(t[:], id[:])
+	c := check(id)
+	copy(t[10:], c[:])
+	return t
+}
+
+// FromTraceID returns the ID held by t, a trace ID from TraceID. Any other
+// trace ID, including one from OpenTelemetry's random generator, wraps
+// kid.ErrInvalidID.
+func FromTraceID(t trace.TraceID) (kid.ID, error) {
+	id := kid.ID(t[:10])
+	if c := check(id); id.IsNil() || [6]byte(t[10:]) != c {
+		return kid.Nil, fmt.Errorf("%w: trace ID %s does not hold an ID", kid.ErrInvalidID, t)
+	}
+	return id, nil
+}
+
+// SpanID returns a span ID derived from id: its last 8 bytes, the low 32
+// bits of the timestamp, the sequence and the random value. Span IDs need
+// only be unique within a trace; those of IDs from one Generator repeat
+// only after 2^32 milliseconds (about 49 days). The ID cannot be recovered.
+func SpanID(id kid.ID) trace.SpanID {
+	return trace.SpanID(id[2:])
+}
+
+// check returns 6 bytes mixed from all 10 of id, by FNV-1a and the
+// SplitMix64 finalizer.
+func check(id kid.ID) [6]byte {
+	h := uint64(14695981039346656037)
+	for _, b := range id {
+		h = (h ^ uint64(b)) * 1099511628211
+	}
+	h = (h ^ h>>30) * 0xbf58476d1ce4e5b9
+	h = (h ^ h>>27) * 0x94d049bb133111eb
+	h ^= h >> 31
+	var c [8]byte
+	binary.BigEndian.PutUint64(c[:], h)
+	return [6]byte(c[:6])
+}
+
+// IDGenerator is an OpenTelemetry SDK ID generator issuing trace and span
+// IDs from kid IDs. A root span's trace ID holds a new ID, or with
+// FromRequest the request ID of its context; span IDs come from new IDs.
+// The zero value draws from the package-level kid.New.
+type IDGenerator struct {
+	// Generator issues new IDs; nil uses kid.New.
+	Generator *kid.Generator
+
+	// FromRequest, if set, makes a root span's trace ID hold the non-nil
+	// request ID of its context (see kidhttp.FromContext), if there is one.
+	// Every root span under a request then joins one trace, as do those of
+	// retries resending the request's X-Request-ID, so set it only where a
+	// request starts a single root span and incoming request IDs are not
+	// trusted (kidhttp.RequestID.IgnoreIncoming).
+	FromRequest bool
+}
+
+var _ sdktrace.IDGenerator = IDGenerator{}
+
+func (g IDGenerator) newID() kid.ID {
+	if g.Generator != nil {
+		return g.Generator.New()
+	}
+	return kid.New()
+}
+
+// NewIDs returns the trace ID and span ID of a new root span.
+func (g IDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	var id kid.ID
+	if g.FromRequest {
+		id, _ = kidhttp.FromContext(ctx)
+	}
+	if id.IsNil() {
+		id = g.newID()
+	}
+	return TraceID(id), SpanID(g.newID())
+}
+
+// NewSpanID returns the span ID of a new child span.
+func (g IDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	return SpanID(g.newID())
+}
@@ -0,0 +1,87 @@
+package kidotel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidhttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceID(t *testing.T) {
+	for _, id := range []kid.ID{kid.MustFromString("06bqer9xnm79tfnl"), kid.Max, kid.New()} {
+		tid := TraceID(id)
+		if !tid.IsValid() {
+			t.Errorf("TraceID(%v) = %v, not valid", id, tid)
+		}
+		if [10]byte(tid[:10]) != id {
+			t.Errorf("TraceID(%v) = %v, want the ID's bytes first", id, tid)
+		}
+		got, err := FromTraceID(tid)
+		if err != nil || got != id {
+			t.Errorf("FromTraceID(%v) = %v, %v, want %v", tid, got, err, id)
+		}
+	}
+	if tid := TraceID(kid.Nil); tid.IsValid() {
+		t.Errorf("TraceID(Nil) = %v, want the invalid trace ID", tid)
+	}
+
+	tid := TraceID(kid.New())
+	tid[15] ^= 1
+	_, random := sdktrace.NewTracerProvider().Tracer("").Start(context.Background(), "x")
+	for _, tid := range []trace.TraceID{{}, tid, random.SpanContext().TraceID()} {
+		if id, err := FromTraceID(tid); !errors.Is(err, kid.ErrInvalidID) || id != kid.Nil {
+			t.Errorf("FromTraceID(%v) = %v, %v, want ErrInvalidID", tid, id, err)
+		}
+	}
+}
+
+func TestSpanID(t *testing.T) {
+	id := kid.MustFromString("06bqer9xnm79tfnl")
+	want := trace.SpanID{0x76, 0xe1, 0x3d, 0xad, 0x0e, 0x9d, 0x3a, 0xb3}
+	if got := SpanID(id); got != want {
+		t.Errorf("SpanID(%v) = %v, want %v", id, got, want)
+	}
+}
+
+func TestIDGenerator(t *testing.T) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithIDGenerator(IDGenerator{FromRequest: true}))
+	tracer := tp.Tracer("kidotel")
+
+	// a root span's trace holds the request ID
+	id := kid.New()
+	ctx, root := tracer.Start(kidhttp.NewContext(context.Background(), id), "root")
+	if got, err := FromTraceID(root.SpanContext().TraceID()); err != nil || got != id {
+		t.Errorf("root trace holds %v, %v, want %v", got, err, id)
+	}
+	_, child := tracer.Start(ctx, "child")
+	if child.SpanContext().TraceID() != root.SpanContext().TraceID() {
+		t.Errorf("child trace %v, want the root's", child.SpanContext().TraceID())
+	}
+	if !child.SpanContext().SpanID().IsValid() || child.SpanContext().SpanID() == root.SpanContext().SpanID() {
+		t.Errorf("child span ID %v, root %v; want distinct valid IDs", child.SpanContext().SpanID(), root.SpanContext().SpanID())
+	}
+
+	// without a request ID, a new one
+	_, other := tracer.Start(context.Background(), "other")
+	if got, err := FromTraceID(other.SpanContext().TraceID()); err != nil || got.IsNil() || got == id {
+		t.Errorf("trace without a request ID holds %v, %v, want a new ID", got, err)
+	}
+	// nor from a nil request ID, which would give the invalid trace ID
+	_, other = tracer.Start(kidhttp.NewContext(context.Background(), kid.Nil), "nil")
+	if got, err := FromTraceID(other.SpanContext().TraceID()); err != nil || got.IsNil() {
+		t.Errorf("trace with a nil request ID holds %v, %v, want a new ID", got, err)
+	}
+
+	// by default, root spans under a request start traces of their own
+	tracer = sdktrace.NewTracerProvider(sdktrace.WithIDGenerator(IDGenerator{})).Tracer("kidotel")
+	ctx = kidhttp.NewContext(context.Background(), id)
+	_, a := tracer.Start(ctx, "a")
+	_, b := tracer.Start(ctx, "b")
+	if a.SpanContext().TraceID() == b.SpanContext().TraceID() || a.SpanContext().TraceID() == TraceID(id) {
+		t.Errorf("root traces %v and %v under request %v, want two new traces", a.SpanContext().TraceID(), b.SpanContext().TraceID(), id)
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/mwyvr/kid/kidpb
+
+go 1.23.0
+
+require (
+	github.com/mwyvr/kid v0.0.0
+	google.golang.org/protobuf v1.36.11
+)
+
+// kidpb is developed alongside kid; builds use the enclosing tree.
+replace github.com/mwyvr/kid => ../
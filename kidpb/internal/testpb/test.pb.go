@@ -0,0 +1,181 @@
+// Messages exercising kidpb in tests.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: internal/testpb/test.proto
+
+package testpb
+
+import (
+	kidpb "github.com/mwyvr/kid/kidpb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *kidpb.ID              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Customer      []byte                 `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
+	Items         [][]byte               `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Note          []byte                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	Parent        *Order                 `protobuf:"bytes,5,opt,name=parent,proto3" json:"parent,omitempty"`
+	Refs          map[string]*kidpb.ID   `protobuf:"bytes,6,rep,name=refs,proto3" json:"refs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_internal_testpb_test_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_testpb_test_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_internal_testpb_test_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetId() *kidpb.ID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Order) GetCustomer() []byte {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *Order) GetItems() [][]byte {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetNote() []byte {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *Order) GetParent() *Order {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *Order) GetRefs() map[string]*kidpb.ID {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+var File_internal_testpb_test_proto protoreflect.FileDescriptor
+
+const file_internal_testpb_test_proto_rawDesc = "" +
+	"\n" +
+	"\x1ainternal/testpb/test.proto\x12\bkid.test\x1a\x10kid/v1/kid.proto\"\x92\x02\n" +
+	"\x05Order\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\v2\n" +
+	".kid.v1.IDR\x02id\x12 \n" +
+	"\bcustomer\x18\x02 \x01(\fB\x04\xf0\x9f\x19\x01R\bcustomer\x12\x1a\n" +
+	"\x05items\x18\x03 \x03(\fB\x04\xf0\x9f\x19\x01R\x05items\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\fR\x04note\x12'\n" +
+	"\x06parent\x18\x05 \x01(\v2\x0f.kid.test.OrderR\x06parent\x12-\n" +
+	"\x04refs\x18\x06 \x03(\v2\x19.kid.test.Order.RefsEntryR\x04refs\x1aC\n" +
+	"\tRefsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\x05value\x18\x02 \x01(\v2\n" +
+	".kid.v1.IDR\x05value:\x028\x01B,Z*github.com/mwyvr/kid/kidpb/internal/testpbb\x06proto3"
+
+var (
+	file_internal_testpb_test_proto_rawDescOnce sync.Once
+	file_internal_testpb_test_proto_rawDescData []byte
+)
+
+func file_internal_testpb_test_proto_rawDescGZIP() []byte {
+	file_internal_testpb_test_proto_rawDescOnce.Do(func() {
+		file_internal_testpb_test_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_testpb_test_proto_rawDesc), len(file_internal_testpb_test_proto_rawDesc)))
+	})
+	return file_internal_testpb_test_proto_rawDescData
+}
+
+var file_internal_testpb_test_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_testpb_test_proto_goTypes = []any{
+	(*Order)(nil),    // 0: kid.test.Order
+	nil,              // 1: kid.test.Order.RefsEntry
+	(*kidpb.ID)(nil), // 2: kid.v1.ID
+}
+var file_internal_testpb_test_proto_depIdxs = []int32{
+	2, // 0: kid.test.Order.id:type_name -> kid.v1.ID
+	0, // 1: kid.test.Order.parent:type_name -> kid.test.Order
+	1, // 2: kid.test.Order.refs:type_name -> kid.test.Order.RefsEntry
+	2, // 3: kid.test.Order.RefsEntry.value:type_name -> kid.v1.ID
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_internal_testpb_test_proto_init() }
+func file_internal_testpb_test_proto_init() {
+	if File_internal_testpb_test_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_testpb_test_proto_rawDesc), len(file_internal_testpb_test_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_internal_testpb_test_proto_goTypes,
+		DependencyIndexes: file_internal_testpb_test_proto_depIdxs,
+		MessageInfos:      file_internal_testpb_test_proto_msgTypes,
+	}.Build()
+	File_internal_testpb_test_proto = out.File
+	file_internal_testpb_test_proto_goTypes = nil
+	file_internal_testpb_test_proto_depIdxs = nil
+}
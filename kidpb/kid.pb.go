@@ -0,0 +1,158 @@
+// Protocol buffer definitions for kid IDs; see package
+// github.com/mwyvr/kid/kidpb for the Go helpers.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: kid/v1/kid.proto
+
+package kidpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ID is a kid ID carried as its 10 raw bytes: 6-byte timestamp, 2-byte
+// sequence and 2-byte random value, big-endian. An empty value, like an
+// unset ID field, is the nil ID.
+type ID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         []byte                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ID) Reset() {
+	*x = ID{}
+	mi := &file_kid_v1_kid_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ID) ProtoMessage() {}
+
+func (x *ID) ProtoReflect() protoreflect.Message {
+	mi := &file_kid_v1_kid_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ID.ProtoReflect.Descriptor instead.
+func (*ID) Descriptor() ([]byte, []int) {
+	return file_kid_v1_kid_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ID) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+var file_kid_v1_kid_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51710,
+		Name:          "kid.v1.raw",
+		Tag:           "varint,51710,opt,name=raw",
+		Filename:      "kid/v1/kid.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// raw marks a bytes field, singular or repeated, as holding kid IDs
+	// directly, without the ID wrapper message:
+	//
+	//   bytes customer_id = 2 [(kid.v1.raw) = true];
+	//
+	// Such fields are ordinary bytes to protoc-gen-go; kidpb.Validate checks
+	// that each holds 0 or 10 bytes.
+	//
+	// optional bool raw = 51710;
+	E_Raw = &file_kid_v1_kid_proto_extTypes[0]
+)
+
+var File_kid_v1_kid_proto protoreflect.FileDescriptor
+
+const file_kid_v1_kid_proto_rawDesc = "" +
+	"\n" +
+	"\x10kid/v1/kid.proto\x12\x06kid.v1\x1a google/protobuf/descriptor.proto\"\x1a\n" +
+	"\x02ID\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value:1\n" +
+	"\x03raw\x12\x1d.google.protobuf.FieldOptions\x18\xfe\x93\x03 \x01(\bR\x03rawB\x1cZ\x1agithub.com/mwyvr/kid/kidpbb\x06proto3"
+
+var (
+	file_kid_v1_kid_proto_rawDescOnce sync.Once
+	file_kid_v1_kid_proto_rawDescData []byte
+)
+
+func file_kid_v1_kid_proto_rawDescGZIP() []byte {
+	file_kid_v1_kid_proto_rawDescOnce.Do(func() {
+		file_kid_v1_kid_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kid_v1_kid_proto_rawDesc), len(file_kid_v1_kid_proto_rawDesc)))
+	})
+	return file_kid_v1_kid_proto_rawDescData
+}
+
+var file_kid_v1_kid_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_kid_v1_kid_proto_goTypes = []any{
+	(*ID)(nil),                        // 0: kid.v1.ID
+	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+}
+var file_kid_v1_kid_proto_depIdxs = []int32{
+	1, // 0: kid.v1.raw:extendee -> google.protobuf.FieldOptions
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_kid_v1_kid_proto_init() }
+func file_kid_v1_kid_proto_init() {
+	if File_kid_v1_kid_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kid_v1_kid_proto_rawDesc), len(file_kid_v1_kid_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_kid_v1_kid_proto_goTypes,
+		DependencyIndexes: file_kid_v1_kid_proto_depIdxs,
+		MessageInfos:      file_kid_v1_kid_proto_msgTypes,
+		ExtensionInfos:    file_kid_v1_kid_proto_extTypes,
+	}.Build()
+	File_kid_v1_kid_proto = out.File
+	file_kid_v1_kid_proto_goTypes = nil
+	file_kid_v1_kid_proto_depIdxs = nil
+}
@@ -0,0 +1,138 @@
+// Package kidpb carries kid IDs in protocol buffers as 10 raw bytes rather
+// than 16-character strings.
+//
+// kid/v1/kid.proto defines the message kid.v1.ID, for use as a field type:
+//
+//	import "kid/v1/kid.proto";
+//
+//	message Order {
+//	  kid.v1.ID id = 1;
+//	}
+//
+// and converted with ToProto and FromProto. Where a wrapper message is
+// unwanted, a plain bytes field marked with the kid.v1.raw option works with
+// stock protoc-gen-go; convert it with Raw and FromRaw, and check whole
+// messages with Validate:
+//
+//	bytes customer_id = 2 [(kid.v1.raw) = true];
+//
+// Add this directory to protoc's import path (-I) to use the definitions.
+package kidpb
+
+//go:generate protoc -I . --go_out=. --go_opt=module=github.com/mwyvr/kid/kidpb kid/v1/kid.proto internal/testpb/test.proto
+
+import (
+	"fmt"
+
+	"github.com/mwyvr/kid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ToProto returns id as an ID message, or nil for kid.Nil, leaving the
+// field unset.
+func ToProto(id kid.ID) *ID {
+	if id.IsNil() {
+		return nil
+	}
+	return &ID{Value: Raw(id)}
+}
+
+// FromProto returns the kid.ID carried by x. A nil x, or an empty value,
+// yields kid.Nil; a value of any length but 0 or 10 is an error wrapping
+// kid.ErrInvalidID.
+func FromProto(x *ID) (kid.ID, error) {
+	return FromRaw(x.GetValue())
+}
+
+// AsID returns the kid.ID carried by x, or kid.Nil if x is nil or invalid;
+// see CheckValid.
+func (x *ID) AsID() kid.ID {
+	id, _ := FromProto(x)
+	return id
+}
+
+// CheckValid returns an error if x does not carry a valid kid.ID. A nil x
+// is valid, as kid.Nil.
+func (x *ID) CheckValid() error {
+	_, err := FromProto(x)
+	return err
+}
+
+// Raw returns id as the contents of a kid.v1.raw bytes field: its 10 bytes,
+// or nil for kid.Nil, leaving the field unset.
+func Raw(id kid.ID) []byte {
+	if id.IsNil() {
+		return nil
+	}
+	return id.Bytes()
+}
+
+// FromRaw returns the kid.ID held by a kid.v1.raw bytes field. An empty b
+// yields kid.Nil.
+func FromRaw(b []byte) (kid.ID, error) {
+	if len(b) == 0 {
+		return kid.Nil, nil
+	}
+	id, err := kid.FromBytes(b)
+	if err != nil {
+		return kid.Nil, fmt.Errorf("%w: %d bytes", kid.ErrInvalidID, len(b))
+	}
+	return id, nil
+}
+
+// Validate checks every ID message and kid.v1.raw field in m, including
+// those in nested messages, lists and maps, returning an error naming the
+// first field that does not hold a valid kid.ID.
+func Validate(m proto.Message) error {
+	return validate(m.ProtoReflect())
+}
+
+const idName protoreflect.FullName = "kid.v1.ID"
+
+func validate(m protoreflect.Message) error {
+	if m.Descriptor().FullName() == idName {
+		_, err := FromRaw(m.Get(m.Descriptor().Fields().ByNumber(1)).Bytes())
+		return err
+	}
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				err = check(fd, list.Get(i))
+			}
+		case fd.IsMap():
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				err = check(fd.MapValue(), v)
+				return err == nil
+			})
+		default:
+			err = check(fd, v)
+		}
+		if err != nil {
+			err = fmt.Errorf("kidpb: %s: %w", fd.FullName(), err)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// check validates v, a value of the field fd.
+func check(fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	switch {
+	case fd.Message() != nil:
+		return validate(v.Message())
+	case fd.Kind() == protoreflect.BytesKind && isRaw(fd):
+		_, err := FromRaw(v.Bytes())
+		return err
+	}
+	return nil
+}
+
+// isRaw reports whether fd carries the kid.v1.raw option.
+func isRaw(fd protoreflect.FieldDescriptor) bool {
+	opts := fd.Options()
+	return opts != nil && proto.GetExtension(opts, E_Raw).(bool)
+}
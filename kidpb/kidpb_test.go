@@ -0,0 +1,86 @@
+package kidpb_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mwyvr/kid"
+	"github.com/mwyvr/kid/kidpb"
+	"github.com/mwyvr/kid/kidpb/internal/testpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	id, customer := kid.New(), kid.New()
+	in := &testpb.Order{
+		Id:       kidpb.ToProto(id),
+		Customer: kidpb.Raw(customer),
+		Items:    [][]byte{kidpb.Raw(kid.New()), kidpb.Raw(kid.New())},
+		Refs:     map[string]*kidpb.ID{"parent": kidpb.ToProto(kid.New())},
+	}
+	b, err := proto.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out testpb.Order
+	if err := proto.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := kidpb.FromProto(out.Id); err != nil || got != id {
+		t.Errorf("FromProto = %v, %v, want %v", got, err, id)
+	}
+	if got, err := kidpb.FromRaw(out.Customer); err != nil || got != customer {
+		t.Errorf("FromRaw = %v, %v, want %v", got, err, customer)
+	}
+	if err := kidpb.Validate(&out); err != nil {
+		t.Errorf("Validate = %v", err)
+	}
+
+	// 10 bytes plus tag and length, against 16 for the string form
+	if n := proto.Size(kidpb.ToProto(id)); n != 12 {
+		t.Errorf("encoded ID message is %d bytes, want 12", n)
+	}
+}
+
+func TestNil(t *testing.T) {
+	if x := kidpb.ToProto(kid.Nil); x != nil {
+		t.Errorf("kidpb.ToProto(Nil) = %v, want nil", x)
+	}
+	if b := kidpb.Raw(kid.Nil); b != nil {
+		t.Errorf("kidpb.Raw(Nil) = %v, want nil", b)
+	}
+	for _, x := range []*kidpb.ID{nil, {}} {
+		if id, err := kidpb.FromProto(x); err != nil || id != kid.Nil {
+			t.Errorf("kidpb.FromProto(%v) = %v, %v, want Nil", x, id, err)
+		}
+	}
+	if id := (&kidpb.ID{Value: []byte{1}}).AsID(); id != kid.Nil {
+		t.Errorf("AsID of an invalid message = %v, want Nil", id)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	bad := []byte{1, 2, 3}
+	tests := []struct {
+		m     *testpb.Order
+		field string // "": valid
+	}{
+		{&testpb.Order{}, ""},
+		{&testpb.Order{Note: bad}, ""}, // not marked raw
+		{&testpb.Order{Id: &kidpb.ID{Value: bad}}, "kid.test.Order.id"},
+		{&testpb.Order{Customer: bad}, "kid.test.Order.customer"},
+		{&testpb.Order{Items: [][]byte{kidpb.Raw(kid.New()), bad}}, "kid.test.Order.items"},
+		{&testpb.Order{Parent: &testpb.Order{Customer: bad}}, "kid.test.Order.customer"},
+		{&testpb.Order{Refs: map[string]*kidpb.ID{"x": {Value: bad}}}, "kid.test.Order.refs"},
+	}
+	for _, tt := range tests {
+		err := kidpb.Validate(tt.m)
+		switch {
+		case tt.field == "" && err != nil:
+			t.Errorf("kidpb.Validate(%v) = %v, want nil", tt.m, err)
+		case tt.field != "" && (!errors.Is(err, kid.ErrInvalidID) || !strings.Contains(err.Error(), tt.field)):
+			t.Errorf("kidpb.Validate(%v) = %v, want ErrInvalidID naming %s", tt.m, err, tt.field)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/mwyvr/kid/kidpgx
+
+go 1.23.0
+
+require (
+	github.com/jackc/pgx/v5 v5.7.6
+	github.com/mwyvr/kid v0.0.0
+)
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/text v0.24.0 // indirect
+)
+
+// kidpgx is developed alongside kid; builds use the enclosing tree.
+replace github.com/mwyvr/kid => ../
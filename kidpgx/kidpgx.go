@@ -0,0 +1,165 @@
+// Package kidpgx teaches pgx v5 to store kid.IDs natively in Postgres bytea
+// and uuid columns, without going through database/sql.
+//
+// Without it, pgx falls back to kid.ID's driver.Valuer, which yields the
+// 16-character string: right for text columns, but bytea and uuid
+// parameters fail to encode, and a bytea column written through the simple
+// protocol receives those 16 characters rather than the ID's 10 bytes.
+// Register the codecs on each connection's type map:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		kidpgx.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+// After that, a kid.ID or *kid.ID encodes and scans as:
+//
+//   - bytea: the 10 raw bytes, which sort like the IDs
+//   - uuid: the ID's UUIDv7 form (kid.ID.UUID); scanning a uuid that
+//     kid.FromUUIDv7 rejects is an error
+//   - text, varchar: the 16-character string, as before
+//
+// The nil ID is NULL. Arrays of the first two work too, so []kid.ID can be
+// bound to bytea[] or uuid[] parameters:
+//
+//	rows, err := conn.Query(ctx, "SELECT * FROM orders WHERE id = ANY($1)", ids)
+package kidpgx
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mwyvr/kid"
+)
+
+// Register registers the kid.ID codecs for bytea, uuid and their arrays on
+// m, replacing pgx's own codecs for those types; values of other types pass
+// through to them unchanged. Where the parameter type is not known, as with
+// the simple protocol, kid.ID and []kid.ID default to bytea and bytea[].
+func Register(m *pgtype.Map) {
+	bytea := &pgtype.Type{Name: "bytea", OID: pgtype.ByteaOID, Codec: byteaCodec{}}
+	uuid := &pgtype.Type{Name: "uuid", OID: pgtype.UUIDOID, Codec: uuidCodec{}}
+	m.RegisterType(bytea)
+	m.RegisterType(uuid)
+	m.RegisterType(&pgtype.Type{Name: "_bytea", OID: pgtype.ByteaArrayOID, Codec: &pgtype.ArrayCodec{ElementType: bytea}})
+	m.RegisterType(&pgtype.Type{Name: "_uuid", OID: pgtype.UUIDArrayOID, Codec: &pgtype.ArrayCodec{ElementType: uuid}})
+	m.RegisterDefaultPgType(kid.ID{}, "bytea")
+	m.RegisterDefaultPgType([]kid.ID{}, "_bytea")
+}
+
+// byteaCodec is pgx's bytea codec, extended to kid.IDs.
+type byteaCodec struct {
+	pgtype.ByteaCodec
+}
+
+func (c byteaCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	return planEncode(c.ByteaCodec, m, oid, format, value)
+}
+
+func (c byteaCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	return planScan(c.ByteaCodec, m, oid, format, target)
+}
+
+// uuidCodec is pgx's uuid codec, extended to kid.IDs.
+type uuidCodec struct {
+	pgtype.UUIDCodec
+}
+
+func (c uuidCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	return planEncode(c.UUIDCodec, m, oid, format, value)
+}
+
+func (c uuidCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	return planScan(c.UUIDCodec, m, oid, format, target)
+}
+
+// planEncode plans the encoding of value by inner, handing it a kid.ID as
+// an id.
+func planEncode(inner pgtype.Codec, m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case kid.ID, *kid.ID:
+		if next := inner.PlanEncode(m, oid, format, id{}); next != nil {
+			return encodePlan{next}
+		}
+	}
+	return inner.PlanEncode(m, oid, format, value)
+}
+
+type encodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	switch val := value.(type) {
+	case kid.ID:
+		return p.next.Encode(id(val), buf)
+	case *kid.ID:
+		if val == nil {
+			return nil, nil
+		}
+		return p.next.Encode(id(*val), buf)
+	}
+	return nil, fmt.Errorf("kidpgx: cannot encode %T", value)
+}
+
+// planScan plans scanning into target by inner, handing it a *kid.ID as an
+// *id.
+func planScan(inner pgtype.Codec, m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if _, ok := target.(*kid.ID); ok {
+		if next := inner.PlanScan(m, oid, format, (*id)(nil)); next != nil {
+			return scanPlan{next}
+		}
+	}
+	return inner.PlanScan(m, oid, format, target)
+}
+
+type scanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (p scanPlan) Scan(src []byte, target any) error {
+	return p.next.Scan(src, (*id)(target.(*kid.ID)))
+}
+
+// id adapts a kid.ID to the valuer and scanner interfaces of pgx's bytea
+// and uuid codecs. It deliberately lacks kid.ID's driver.Valuer.
+type id kid.ID
+
+func (v id) BytesValue() ([]byte, error) {
+	if kid.ID(v).IsNil() {
+		return nil, nil
+	}
+	return v[:], nil
+}
+
+func (v *id) ScanBytes(b []byte) error {
+	if b == nil {
+		*v = id{}
+		return nil
+	}
+	x, err := kid.FromBytes(b)
+	if err != nil {
+		*v = id{}
+		return fmt.Errorf("kidpgx: scanning %d-byte bytea: %w", len(b), err)
+	}
+	*v = id(x)
+	return nil
+}
+
+func (v id) UUIDValue() (pgtype.UUID, error) {
+	return pgtype.UUID{Bytes: kid.ID(v).UUID(), Valid: !kid.ID(v).IsNil()}, nil
+}
+
+func (v *id) ScanUUID(u pgtype.UUID) error {
+	if !u.Valid {
+		*v = id{}
+		return nil
+	}
+	x, err := kid.FromUUIDv7(u.Bytes)
+	if err != nil {
+		*v = id{}
+		return fmt.Errorf("kidpgx: scanning uuid %x: %w", u.Bytes, err)
+	}
+	*v = id(x)
+	return nil
+}
@@ -0,0 +1,176 @@
+package kidpgx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mwyvr/kid"
+)
+
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	Register(m)
+	return m
+}
+
+func TestBytea(t *testing.T) {
+	m, id := newMap(), kid.New()
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		for _, v := range []any{id, &id} {
+			buf, err := m.Encode(pgtype.ByteaOID, format, v, nil)
+			if err != nil {
+				t.Fatalf("format %d: Encode(%T) = %v", format, v, err)
+			}
+			if format == pgtype.BinaryFormatCode && !bytes.Equal(buf, id[:]) {
+				t.Errorf("Encode(%T) = %x, want the 10 bytes %x", v, buf, id[:])
+			}
+			var got kid.ID
+			if err := m.Scan(pgtype.ByteaOID, format, buf, &got); err != nil || got != id {
+				t.Errorf("format %d: Scan(%q) = %v, %v, want %v", format, buf, got, err, id)
+			}
+		}
+	}
+
+	// ordinary []byte values are untouched
+	buf, err := m.Encode(pgtype.ByteaOID, pgtype.BinaryFormatCode, []byte("abc"), nil)
+	if err != nil || string(buf) != "abc" {
+		t.Errorf("Encode([]byte) = %q, %v, want abc", buf, err)
+	}
+
+	var got kid.ID
+	if err := m.Scan(pgtype.ByteaOID, pgtype.BinaryFormatCode, []byte("06bqer9xnm79tfnl"), &got); !errors.Is(err, kid.ErrInvalidID) {
+		t.Errorf("Scan of 16 bytes = %v, want ErrInvalidID", err)
+	}
+}
+
+func TestUUID(t *testing.T) {
+	m, id := newMap(), kid.New()
+	u := id.UUID()
+	buf, err := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, id, nil)
+	if err != nil || !bytes.Equal(buf, u[:]) {
+		t.Errorf("Encode = %x, %v, want %x", buf, err, u)
+	}
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.UUIDOID, format, id, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got kid.ID
+		if err := m.Scan(pgtype.UUIDOID, format, buf, &got); err != nil || got != id {
+			t.Errorf("format %d: Scan(%q) = %v, %v, want %v", format, buf, got, err, id)
+		}
+	}
+
+	// a random (version 4) UUID is not an ID
+	v4 := []byte("9c5b94b1-35ad-49bb-b118-8e8fc24abf80")
+	var got kid.ID
+	if err := m.Scan(pgtype.UUIDOID, pgtype.TextFormatCode, v4, &got); err == nil {
+		t.Errorf("Scan(%s) = %v, want error", v4, got)
+	}
+}
+
+func TestNull(t *testing.T) {
+	m := newMap()
+	for _, oid := range []uint32{pgtype.ByteaOID, pgtype.UUIDOID} {
+		var nilPtr *kid.ID
+		for _, v := range []any{kid.Nil, nilPtr} {
+			if buf, err := m.Encode(oid, pgtype.BinaryFormatCode, v, nil); buf != nil || err != nil {
+				t.Errorf("oid %d: Encode(%#v) = %x, %v, want NULL", oid, v, buf, err)
+			}
+		}
+		got := kid.New()
+		if err := m.Scan(oid, pgtype.BinaryFormatCode, nil, &got); err != nil || got != kid.Nil {
+			t.Errorf("oid %d: Scan(NULL) = %v, %v, want Nil", oid, got, err)
+		}
+		ptr := &got
+		if err := m.Scan(oid, pgtype.BinaryFormatCode, nil, &ptr); err != nil || ptr != nil {
+			t.Errorf("oid %d: Scan(NULL) into **kid.ID = %v, %v, want nil", oid, ptr, err)
+		}
+	}
+}
+
+func TestArrays(t *testing.T) {
+	m := newMap()
+	ids := []kid.ID{kid.New(), kid.New(), kid.New()}
+	for _, oid := range []uint32{pgtype.ByteaArrayOID, pgtype.UUIDArrayOID} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(oid, format, ids, nil)
+			if err != nil {
+				t.Fatalf("oid %d format %d: Encode = %v", oid, format, err)
+			}
+			var got []kid.ID
+			if err := m.Scan(oid, format, buf, &got); err != nil || len(got) != len(ids) {
+				t.Fatalf("oid %d format %d: Scan = %v, %v", oid, format, got, err)
+			}
+			for i := range ids {
+				if got[i] != ids[i] {
+					t.Errorf("oid %d format %d: element %d = %v, want %v", oid, format, i, got[i], ids[i])
+				}
+			}
+		}
+	}
+}
+
+func TestDefaultType(t *testing.T) {
+	m := newMap()
+	if typ, ok := m.TypeForValue(kid.ID{}); !ok || typ.OID != pgtype.ByteaOID {
+		t.Errorf("TypeForValue(kid.ID) = %v, want bytea", typ)
+	}
+	if typ, ok := m.TypeForValue([]kid.ID{}); !ok || typ.OID != pgtype.ByteaArrayOID {
+		t.Errorf("TypeForValue([]kid.ID) = %v, want bytea[]", typ)
+	}
+}
+
+// TestPostgres round-trips IDs through a live server, given a connection
+// string in KIDPGX_TEST_DATABASE.
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv("KIDPGX_TEST_DATABASE")
+	if dsn == "" {
+		t.Skip("KIDPGX_TEST_DATABASE not set")
+	}
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close(ctx)
+	Register(conn.TypeMap())
+
+	_, err = conn.Exec(ctx, `CREATE TEMPORARY TABLE kidpgx (id bytea PRIMARY KEY, u uuid, s text)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []kid.ID{kid.New(), kid.New(), kid.New()}
+	for _, id := range ids {
+		if _, err := conn.Exec(ctx, `INSERT INTO kidpgx VALUES ($1, $1, $1)`, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rows, err := conn.Query(ctx, `SELECT id, u, s, length(id) FROM kidpgx WHERE id = ANY($1) ORDER BY id`, ids[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []kid.ID
+	for rows.Next() {
+		var id, u, s kid.ID
+		var n int
+		if err := rows.Scan(&id, &u, &s, &n); err != nil {
+			t.Fatal(err)
+		}
+		if u != id || s != id || n != 10 {
+			t.Errorf("row %v: uuid %v, text %v, %d bytes; want the same ID in 10 bytes", id, u, s, n)
+		}
+		got = append(got, id)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != ids[1] || got[1] != ids[2] {
+		t.Errorf("= ANY($1) selected %v, want %v", got, ids[1:])
+	}
+}
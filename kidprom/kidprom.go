@@ -0,0 +1,89 @@
+// Package kidprom exports the activity of kid Generators as Prometheus
+// metrics, so operators can watch ID pressure in high-throughput services:
+//
+//	c := kidprom.NewCollector(nil)
+//	prometheus.MustRegister(c)
+//	g := kid.NewGenerator(kid.WithObserver(c))
+//
+// The metrics, all counters, are:
+//
+//	kid_ids_issued_total                IDs issued
+//	kid_sequence_rollovers_total        milliseconds whose sequence was exhausted
+//	kid_clock_regressions_total         clock regressions detected
+//	kid_clock_regression_seconds_total  total size of those regressions
+//	kid_wait_seconds_total              time generation spent blocked
+//
+// A rising rollover rate means generation is borrowing ahead of the clock;
+// see kid.SustainedRateLimit.
+package kidprom
+
+import (
+	"time"
+
+	"github.com/mwyvr/kid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a kid.BatchObserver counting a Generator's events, and a
+// prometheus.Collector exporting them. One Collector may observe several
+// Generators, summing their activity; to tell them apart, give each its own
+// Collector with distinguishing constant labels.
+type Collector struct {
+	issued      prometheus.Counter
+	rollovers   prometheus.Counter
+	regressions prometheus.Counter
+	regressed   prometheus.Counter
+	waited      prometheus.Counter
+}
+
+var _ kid.BatchObserver = (*Collector)(nil)
+
+// NewCollector returns a Collector whose metrics carry constLabels, which
+// may be nil.
+func NewCollector(constLabels prometheus.Labels) *Collector {
+	counter := func(name, help string) prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "kid",
+			Name:        name,
+			Help:        help,
+			ConstLabels: constLabels,
+		})
+	}
+	return &Collector{
+		issued:      counter("ids_issued_total", "IDs issued."),
+		rollovers:   counter("sequence_rollovers_total", "Milliseconds whose sequence was exhausted, carrying generation into the next."),
+		regressions: counter("clock_regressions_total", "Clock regressions detected."),
+		regressed:   counter("clock_regression_seconds_total", "Total size of the clock regressions detected."),
+		waited:      counter("wait_seconds_total", "Time generation spent blocked waiting for the clock."),
+	}
+}
+
+// Issued implements kid.Observer.
+func (c *Collector) Issued() { c.issued.Inc() }
+
+// IssuedN implements kid.BatchObserver.
+func (c *Collector) IssuedN(n int) { c.issued.Add(float64(n)) }
+
+// Rollover implements kid.Observer.
+func (c *Collector) Rollover() { c.rollovers.Inc() }
+
+// Regression implements kid.Observer.
+func (c *Collector) Regression(behind time.Duration) {
+	c.regressions.Inc()
+	c.regressed.Add(behind.Seconds())
+}
+
+// Waited implements kid.Observer.
+func (c *Collector) Waited(d time.Duration) { c.waited.Add(d.Seconds()) }
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range []prometheus.Counter{c.issued, c.rollovers, c.regressions, c.regressed, c.waited} {
+		ch <- m
+	}
+}
@@ -0,0 +1,52 @@
+package kidprom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mwyvr/kid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCollector(t *testing.T) {
+	at := time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return at }
+	c := NewCollector(prometheus.Labels{"generator": "orders"})
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(c)
+
+	g := kid.NewGenerator(kid.WithClock(clock), kid.WithObserver(c))
+	for range kid.MaxPerMillisecond + 1 { // the clock stands still: one rollover
+		g.New()
+	}
+	at = at.Add(-time.Second)
+	g.New()
+	g.ReserveSequence(100) // one IssuedN
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]float64{}
+	for _, mf := range mfs {
+		m := mf.GetMetric()[0]
+		if l := m.GetLabel(); len(l) != 1 || l[0].GetName() != "generator" || l[0].GetValue() != "orders" {
+			t.Errorf("%s labels = %v, want generator=orders", mf.GetName(), l)
+		}
+		got[mf.GetName()] = m.GetCounter().GetValue()
+	}
+	for name, want := range map[string]float64{
+		"kid_ids_issued_total":               kid.MaxPerMillisecond + 102,
+		"kid_sequence_rollovers_total":       1,
+		"kid_clock_regressions_total":        1,
+		"kid_clock_regression_seconds_total": 1,
+		"kid_wait_seconds_total":             0,
+	} {
+		if v, ok := got[name]; !ok || v != want {
+			t.Errorf("%s = %v (present %v), want %v", name, v, ok, want)
+		}
+	}
+	if len(got) != 5 {
+		t.Errorf("gathered %d metrics, want 5: %v", len(got), got)
+	}
+}
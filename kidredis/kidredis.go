@@ -0,0 +1,93 @@
+// Package kidredis coordinates kid node values (kid.WithNode) through
+// Redis, so clusters can guarantee each Generator a unique node:
+//
+//	leaser := kidredis.NewNodeLeaser(rdb, "kid:node:")
+//	l, err := kid.LeaseNode(ctx, leaser, 30*time.Second)
+//
+// Each leased value is a key, prefix plus the decimal node, holding a token
+// identifying the leaser and expiring with the lease. Every key is
+// addressed singly, so Redis Cluster is supported.
+package kidredis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	mrand "math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/mwyvr/kid"
+	"github.com/redis/go-redis/v9"
+)
+
+// NodeLeaser is a kid.NodeLeaser storing leases in Redis.
+type NodeLeaser struct {
+	rdb    redis.Cmdable
+	prefix string
+	token  string
+}
+
+var _ kid.NodeLeaser = (*NodeLeaser)(nil)
+
+// NewNodeLeaser returns a NodeLeaser keeping leases under keys beginning
+// with prefix in rdb. Leases are held by the NodeLeaser: another, even on
+// the same keys, cannot renew or release them.
+func NewNodeLeaser(rdb redis.Cmdable, prefix string) *NodeLeaser {
+	var b [16]byte
+	rand.Read(b[:]) //nolint:errcheck // never fails
+	return &NodeLeaser{rdb: rdb, prefix: prefix, token: hex.EncodeToString(b[:])}
+}
+
+func (l *NodeLeaser) key(node uint16) string {
+	return l.prefix + strconv.Itoa(int(node))
+}
+
+// Lease claims the first free node value after a random start, trying each
+// with SET NX; with few holders the first try succeeds.
+func (l *NodeLeaser) Lease(ctx context.Context, ttl time.Duration) (uint16, error) {
+	start := uint16(mrand.Uint32()) //nolint:gosec
+	for i := range 1 << 16 {
+		node := start + uint16(i) //nolint:gosec
+		ok, err := l.rdb.SetNX(ctx, l.key(node), l.token, ttl).Result()
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return node, nil
+		}
+	}
+	return 0, kid.ErrNoFreeNode
+}
+
+// renew extends a key's expiry if it holds the token.
+var renew = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// release deletes a key if it holds the token.
+var release = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Renew extends the lease on node, returning kid.ErrNodeLost if its key has
+// expired or is held by another.
+func (l *NodeLeaser) Renew(ctx context.Context, node uint16, ttl time.Duration) error {
+	n, err := renew.Run(ctx, l.rdb, []string{l.key(node)}, l.token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return kid.ErrNodeLost
+	}
+	return nil
+}
+
+// Release deletes node's key if the lease is still held.
+func (l *NodeLeaser) Release(ctx context.Context, node uint16) error {
+	return release.Run(ctx, l.rdb, []string{l.key(node)}, l.token).Err()
+}
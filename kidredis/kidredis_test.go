@@ -0,0 +1,95 @@
+package kidredis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mwyvr/kid"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestNodeLeaser(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer rdb.Close()
+	ctx := context.Background()
+
+	a, b := NewNodeLeaser(rdb, "kid:node:"), NewNodeLeaser(rdb, "kid:node:")
+	na, err := a.Lease(ctx, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// b cannot take, renew or release a's node
+	for range 100 {
+		nb, err := b.Lease(ctx, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nb == na {
+			t.Fatalf("node %d leased twice", na)
+		}
+	}
+	if err := b.Renew(ctx, na, time.Minute); !errors.Is(err, kid.ErrNodeLost) {
+		t.Errorf("Renew() by another leaser = %v, want ErrNodeLost", err)
+	}
+	if err := b.Release(ctx, na); err != nil || !s.Exists("kid:node:"+strconv.Itoa(int(na))) {
+		t.Errorf("Release() by another leaser = %v, removed the key", err)
+	}
+
+	s.FastForward(50 * time.Second)
+	if err := a.Renew(ctx, na, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	s.FastForward(50 * time.Second) // past the original expiry
+	if err := a.Renew(ctx, na, time.Minute); err != nil {
+		t.Errorf("Renew() within the renewed TTL = %v", err)
+	}
+	s.FastForward(2 * time.Minute)
+	if err := a.Renew(ctx, na, time.Minute); !errors.Is(err, kid.ErrNodeLost) {
+		t.Errorf("Renew() after expiry = %v, want ErrNodeLost", err)
+	}
+
+	na, err = a.Lease(ctx, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Release(ctx, na); err != nil {
+		t.Fatal(err)
+	}
+	if s.Exists("kid:node:" + strconv.Itoa(int(na))) {
+		t.Errorf("node %d still held after Release()", na)
+	}
+}
+
+func TestLeaseNode(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer rdb.Close()
+
+	l, err := kid.LeaseNode(context.Background(), NewNodeLeaser(rdb, "kid:node:"), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := kid.NewGenerator(kid.WithNode(l.Node()))
+	if id := g.New(); id.Node() != l.Node() {
+		t.Errorf("Node() = %d, want %d", id.Node(), l.Node())
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if keys := s.Keys(); len(keys) != 0 {
+		t.Errorf("keys after Close() = %v, want none", keys)
+	}
+}
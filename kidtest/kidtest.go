@@ -0,0 +1,42 @@
+// Package kidtest provides helpers for tests of code that generates kid
+// IDs, so they can assert on exact values. Each returns or configures a
+// Generator of its own; the package-level generator behind kid.New is
+// never touched, so tests using kidtest may run in parallel with code
+// calling kid.New.
+//
+//	g := kid.NewGenerator(kid.WithClock(kidtest.FixedClock(t0)))
+//	id := g.New() // id.Time() == t0
+//
+// or, for IDs identical from run to run:
+//
+//	g := kidtest.NewGenerator(t0)
+package kidtest
+
+import (
+	mrand "math/rand/v2"
+	"time"
+
+	"github.com/mwyvr/kid"
+)
+
+// FixedClock returns a clock for kid.WithClock that always reads t. The
+// Generator's sequence advances with each ID, as it does when the wall
+// clock stands still, so IDs stay unique and ordered, embedding t's
+// millisecond until its sequence values run out; later IDs borrow the
+// following milliseconds.
+func FixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+// NewGenerator returns a Generator whose IDs are the same on every run:
+// its clock is FixedClock(t) and its random bytes come from a ChaCha8
+// generator with a fixed seed. opts are applied after these, and may
+// replace either.
+func NewGenerator(t time.Time, opts ...kid.Option[kid.Generator]) *kid.Generator {
+	var seed [32]byte
+	opts = append([]kid.Option[kid.Generator]{
+		kid.WithClock(FixedClock(t)),
+		kid.WithRandom(mrand.NewChaCha8(seed)),
+	}, opts...)
+	return kid.NewGenerator(opts...)
+}
@@ -0,0 +1,49 @@
+package kidtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mwyvr/kid"
+)
+
+var t0 = time.Date(2025, 3, 8, 17, 50, 27, 757_000_000, time.UTC)
+
+func TestFixedClock(t *testing.T) {
+	g := kid.NewGenerator(kid.WithClock(FixedClock(t0)))
+	prev := kid.Nil
+	for range 100 {
+		id := g.New()
+		if !id.Time().Equal(t0) {
+			t.Fatalf("Time() = %v, want %v", id.Time(), t0)
+		}
+		if id.Compare(prev) <= 0 {
+			t.Fatalf("%v does not sort after %v", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	a, b := NewGenerator(t0), NewGenerator(t0)
+	for range 100 {
+		if x, y := a.New(), b.New(); x != y {
+			t.Fatalf("New() = %v and %v from two Generators, want equal", x, y)
+		}
+	}
+	if id := NewGenerator(t0, kid.WithNoRandom()).New(); id.Random() != 0 {
+		t.Errorf("Random() = %d with WithNoRandom, want 0", id.Random())
+	}
+}
+
+func ExampleNewGenerator() {
+	g := NewGenerator(time.Date(2025, 3, 8, 17, 50, 27, 757_000_000, time.UTC))
+	for range 3 {
+		fmt.Println(g.New())
+	}
+	// Output:
+	// 06bqer9xnm001pd7
+	// 06bqer9xnm002zpf
+	// 06bqer9xnm004v9p
+}
@@ -0,0 +1,70 @@
+package kid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Link is a parent-child relationship between two IDs, such as an event and
+// the event it caused.
+type Link struct {
+	Parent, Child ID
+}
+
+// lineageLayout formats node timestamps, in UTC.
+const lineageLayout = "2006-01-02 15:04:05.000"
+
+// WriteDOT writes links as a Graphviz DOT digraph, each ID a node labelled
+// with its encoded form and embedded timestamp, for rendering the causality
+// recorded in a set of keys:
+//
+//	kid lineage dot pairs.txt | dot -Tsvg > lineage.svg
+//
+// Links whose child sorts before its parent cannot reflect creation order
+// and are drawn dashed in red.
+func WriteDOT(w io.Writer, links []Link) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph lineage {\n\trankdir=LR;\n\tnode [shape=box, fontname=monospace];\n")
+	for _, id := range lineageNodes(links) {
+		fmt.Fprintf(bw, "\t%q [label=\"%s\\n%s\"];\n", id.String(), id, id.Time().Format(lineageLayout))
+	}
+	for _, l := range links {
+		fmt.Fprintf(bw, "\t%q -> %q", l.Parent.String(), l.Child.String())
+		if l.Child.Compare(l.Parent) < 0 {
+			bw.WriteString(" [style=dashed, color=red]")
+		}
+		bw.WriteString(";\n")
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// WriteMermaid writes links as a Mermaid flowchart, as WriteDOT does for
+// Graphviz. Links whose child sorts before its parent are drawn dotted.
+func WriteMermaid(w io.Writer, links []Link) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("flowchart LR\n")
+	for _, id := range lineageNodes(links) {
+		fmt.Fprintf(bw, "\tk%s[\"%s<br/>%s\"]\n", id, id, id.Time().Format(lineageLayout))
+	}
+	for _, l := range links {
+		arrow := "-->"
+		if l.Child.Compare(l.Parent) < 0 {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(bw, "\tk%s %s k%s\n", l.Parent, arrow, l.Child)
+	}
+	return bw.Flush()
+}
+
+// lineageNodes returns the distinct IDs in links, in ascending order.
+func lineageNodes(links []Link) []ID {
+	ids := make([]ID, 0, 2*len(links))
+	for _, l := range links {
+		ids = append(ids, l.Parent, l.Child)
+	}
+	Sort(ids)
+	return slices.Compact(ids)
+}
@@ -0,0 +1,53 @@
+package kid
+
+import (
+	"bytes"
+	"testing"
+)
+
+var lineageLinks = []Link{
+	{Parent: MustFromString("06bqer9xnm79tfnl"), Child: MustFromString("06bqer9xnr020xdv")},
+	{Parent: MustFromString("06bqer9xnm79tfnl"), Child: MustFromString("06bqer9xnr02v3dn")},
+	{Parent: MustFromString("06bqer9xnr03qmzq"), Child: MustFromString("06bqer9xnr02v3dn")}, // child sorts first
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, lineageLinks); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph lineage {
+	rankdir=LR;
+	node [shape=box, fontname=monospace];
+	"06bqer9xnm79tfnl" [label="06bqer9xnm79tfnl\n2025-03-08 17:50:27.757"];
+	"06bqer9xnr020xdv" [label="06bqer9xnr020xdv\n2025-03-08 17:50:27.758"];
+	"06bqer9xnr02v3dn" [label="06bqer9xnr02v3dn\n2025-03-08 17:50:27.758"];
+	"06bqer9xnr03qmzq" [label="06bqer9xnr03qmzq\n2025-03-08 17:50:27.758"];
+	"06bqer9xnm79tfnl" -> "06bqer9xnr020xdv";
+	"06bqer9xnm79tfnl" -> "06bqer9xnr02v3dn";
+	"06bqer9xnr03qmzq" -> "06bqer9xnr02v3dn" [style=dashed, color=red];
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteDOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMermaid(&buf, lineageLinks); err != nil {
+		t.Fatal(err)
+	}
+	want := `flowchart LR
+	k06bqer9xnm79tfnl["06bqer9xnm79tfnl<br/>2025-03-08 17:50:27.757"]
+	k06bqer9xnr020xdv["06bqer9xnr020xdv<br/>2025-03-08 17:50:27.758"]
+	k06bqer9xnr02v3dn["06bqer9xnr02v3dn<br/>2025-03-08 17:50:27.758"]
+	k06bqer9xnr03qmzq["06bqer9xnr03qmzq<br/>2025-03-08 17:50:27.758"]
+	k06bqer9xnm79tfnl --> k06bqer9xnr020xdv
+	k06bqer9xnm79tfnl --> k06bqer9xnr02v3dn
+	k06bqer9xnr03qmzq -.-> k06bqer9xnr02v3dn
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteMermaid() =\n%s\nwant\n%s", got, want)
+	}
+}
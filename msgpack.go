@@ -0,0 +1,45 @@
+package kid
+
+// MessagePack format bytes used by MarshalMsgpack and UnmarshalMsgpack.
+const (
+	msgpackBin8   = 0xc4              // bin 8: length byte, then data
+	msgpackFixStr = 0xa0 | encodedLen // fixstr of 16 bytes
+	msgpackStr8   = 0xd9              // str 8: length byte, then data
+	msgpackNil    = 0xc0
+)
+
+// MarshalMsgpack encodes id as a MessagePack bin 8 holding the 10 raw bytes,
+// 12 bytes in all against 17 for the string form, or as nil for the nil ID.
+// It satisfies the msgpack.Marshaler interface of
+// github.com/vmihailenco/msgpack, which needs no import to implement.
+func (id ID) MarshalMsgpack() ([]byte, error) {
+	if id == Nil {
+		return []byte{msgpackNil}, nil
+	}
+	b := make([]byte, 2+rawLen)
+	b[0], b[1] = msgpackBin8, rawLen
+	copy(b[2:], id[:])
+	return b, nil
+}
+
+// UnmarshalMsgpack decodes a single MessagePack value, satisfying the
+// msgpack.Unmarshaler interface of github.com/vmihailenco/msgpack. It accepts
+// 10 bytes of bin 8, as written by MarshalMsgpack, the 16-character encoded
+// ID as a fixstr or str 8, or nil, which yields the nil ID; on error, id is
+// set to the nil ID and ErrInvalidID is returned.
+func (id *ID) UnmarshalMsgpack(data []byte) error {
+	switch {
+	case len(data) == 1 && data[0] == msgpackNil:
+		*id = Nil
+		return nil
+	case len(data) == 2+rawLen && data[0] == msgpackBin8 && data[1] == rawLen:
+		copy(id[:], data[2:])
+		return nil
+	case len(data) == 1+encodedLen && data[0] == msgpackFixStr:
+		return id.UnmarshalText(data[1:])
+	case len(data) == 2+encodedLen && data[0] == msgpackStr8 && data[1] == encodedLen:
+		return id.UnmarshalText(data[2:])
+	}
+	*id = Nil
+	return ErrInvalidID
+}
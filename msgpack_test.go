@@ -0,0 +1,44 @@
+package kid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestMsgpack(t *testing.T) {
+	id := tests[6].id
+	b, err := id.MarshalMsgpack()
+	if want, _ := hex.DecodeString("c40a019576e13dad0e9d3ab3"); err != nil || !bytes.Equal(b, want) {
+		t.Errorf("MarshalMsgpack() = %x, %v, want %x", b, err, want)
+	}
+	for _, data := range [][]byte{
+		b,
+		append([]byte{0xb0}, "06bqer9xnm79tfnl"...),
+		append([]byte{0xd9, 0x10}, "06bqer9xnm79tfnl"...),
+	} {
+		var got ID
+		if err := got.UnmarshalMsgpack(data); err != nil || got != id {
+			t.Errorf("UnmarshalMsgpack(%x) = %v, %v, want %v", data, got, err, id)
+		}
+	}
+	if b, _ := Nil.MarshalMsgpack(); !bytes.Equal(b, []byte{0xc0}) {
+		t.Errorf("Nil.MarshalMsgpack() = %x, want c0 (nil)", b)
+	}
+	got := id
+	if err := got.UnmarshalMsgpack([]byte{0xc0}); err != nil || got != Nil {
+		t.Errorf("UnmarshalMsgpack(nil) = %v, %v, want Nil", got, err)
+	}
+	for _, data := range [][]byte{
+		nil,
+		{0xc4, 0x09, 1, 2, 3, 4, 5, 6, 7, 8, 9}, // 9 bytes of bin 8
+		append([]byte{0xb0}, "06BQER9XNM79TFNL"...), // not the kid alphabet
+		{0xcf, 0, 0, 0, 0, 0, 0, 0, 1},              // uint 64
+	} {
+		got := id
+		if err := got.UnmarshalMsgpack(data); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("UnmarshalMsgpack(%x) = %v, %v, want ErrInvalidID", data, got, err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+//go:build !tinygo
+
+package kid
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+)
+
+// NodeFromInterfaces derives a node value for WithNode by hashing the
+// hardware address of the host's first non-loopback network interface. It
+// needs no configuration, but 16 bits cannot hold a hardware address: among
+// a few hundred hosts a collision becomes likely, and processes sharing a
+// host share a value. Prefer explicit assignment where uniqueness matters.
+func NodeFromInterfaces() (uint16, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return 0, fmt.Errorf("kid: node: %w", err)
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) == 0 {
+			continue
+		}
+		h := fnv.New32a()
+		h.Write(iface.HardwareAddr)
+		sum := h.Sum32()
+		return uint16(sum>>16 ^ sum), nil //nolint:gosec
+	}
+	return 0, errors.New("kid: node: no network interface with a hardware address")
+}
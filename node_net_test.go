@@ -0,0 +1,15 @@
+//go:build !tinygo
+
+package kid
+
+import "testing"
+
+func TestNodeFromInterfaces(t *testing.T) {
+	n1, err := NodeFromInterfaces()
+	if err != nil {
+		t.Skip(err) // e.g. a sandbox with only loopback
+	}
+	if n2, _ := NodeFromInterfaces(); n1 != n2 {
+		t.Errorf("NodeFromInterfaces() = %d, then %d; want a stable value", n1, n2)
+	}
+}
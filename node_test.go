@@ -0,0 +1,46 @@
+package kid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithNode(t *testing.T) {
+	fixed := time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return fixed }
+	a := NewGenerator(WithNode(0x1234), WithClock(clock))
+	b := NewGenerator(WithNode(0x1235), WithClock(clock))
+	for range 100 {
+		ida, idb := a.New(), b.New()
+		if ida.Node() != 0x1234 || idb.Node() != 0x1235 {
+			t.Fatalf("Node() = %#x, %#x, want 0x1234, 0x1235", ida.Node(), idb.Node())
+		}
+		// identical timestamp+sequence, separated by node
+		if ida == idb {
+			t.Fatalf("generators with distinct nodes produced %v", ida)
+		}
+	}
+	if id := a.NewWithTime(fixed); id.Node() != 0x1234 {
+		t.Errorf("NewWithTime() node = %#x, want 0x1234", id.Node())
+	}
+	// the last option wins
+	if id := NewGenerator(WithNode(7), WithNoRandom()).New(); id.Node() != 0 {
+		t.Errorf("WithNoRandom after WithNode: Node() = %d, want 0", id.Node())
+	}
+}
+
+func TestNodeFromEnv(t *testing.T) {
+	t.Setenv("KID_TEST_NODE", "4242")
+	if n, err := NodeFromEnv("KID_TEST_NODE"); n != 4242 || err != nil {
+		t.Errorf("NodeFromEnv() = %d, %v, want 4242", n, err)
+	}
+	for _, v := range []string{"65536", "-1", "x"} {
+		t.Setenv("KID_TEST_NODE", v)
+		if _, err := NodeFromEnv("KID_TEST_NODE"); err == nil {
+			t.Errorf("NodeFromEnv(%q) succeeded, want error", v)
+		}
+	}
+	if _, err := NodeFromEnv("KID_TEST_NODE_UNSET"); err == nil {
+		t.Error("NodeFromEnv(unset) succeeded, want error")
+	}
+}
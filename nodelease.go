@@ -0,0 +1,176 @@
+package kid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNodeLost is returned by a NodeLeaser renewing a node value it no
+	// longer holds: the lease expired, and the value may be held by another.
+	ErrNodeLost = errors.New("kid: node lease lost")
+
+	// ErrNoFreeNode is returned by a NodeLeaser when all 65536 node values
+	// are leased.
+	ErrNoFreeNode = errors.New("kid: no free node value")
+)
+
+// NodeLeaser coordinates the node values of WithNode across a cluster, so
+// that concurrently running Generators are guaranteed distinct values
+// rather than trusting NodeFromInterfaces or manual assignment. A value is
+// held for a time to live, and must be renewed before it expires; a holder
+// that dies stops renewing, and its value becomes free again.
+//
+// The kidredis and kidetcd modules provide implementations. LeaseNode
+// manages the renewal.
+type NodeLeaser interface {
+	// Lease claims a node value no other holder has, for ttl.
+	Lease(ctx context.Context, ttl time.Duration) (uint16, error)
+
+	// Renew extends the lease on node, a value from Lease, by ttl from now.
+	// It returns ErrNodeLost if the lease has expired.
+	Renew(ctx context.Context, node uint16, ttl time.Duration) error
+
+	// Release gives up node, making it free for others at once.
+	Release(ctx context.Context, node uint16) error
+}
+
+// NodeLease is a node value leased from a NodeLeaser, renewed in the
+// background until Close:
+//
+//	l, err := kid.LeaseNode(ctx, leaser, 30*time.Second)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer l.Close()
+//	g := kid.NewGenerator(kid.WithNode(l.Node()))
+//
+// If renewal fails for two thirds of a time to live, the lease is lost:
+// Lost is closed and the Generator must stop issuing IDs. The last third is
+// a safety margin: the leaser frees the value a time to live after it
+// processed the last renewal, later than it was sent, and only then may
+// lease it to another holder.
+type NodeLease struct {
+	leaser NodeLeaser
+	node   uint16
+	ttl    time.Duration
+
+	stop context.CancelFunc
+	done chan struct{} // closed when renewal has stopped
+	lost chan struct{}
+	err  error // why the lease was lost; set before lost is closed
+
+	once sync.Once
+}
+
+// LeaseNode leases a node value from c for ttl and renews it every sixth of
+// ttl until Close. ttl must be at least 6ns, and in practice long enough
+// for several round trips to the leaser.
+func LeaseNode(ctx context.Context, c NodeLeaser, ttl time.Duration) (*NodeLease, error) {
+	if ttl/6 <= 0 {
+		return nil, fmt.Errorf("kid: leasing node: time to live %v too short to renew", ttl)
+	}
+	sent := time.Now()
+	node, err := c.Lease(ctx, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("kid: leasing node: %w", err)
+	}
+	rctx, stop := context.WithCancel(context.Background())
+	l := &NodeLease{
+		leaser: c,
+		node:   node,
+		ttl:    ttl,
+		stop:   stop,
+		done:   make(chan struct{}),
+		lost:   make(chan struct{}),
+	}
+	go l.renew(rctx, sent)
+	return l, nil
+}
+
+// renew renews the lease every sixth of its time to live until ctx is
+// done. The lease is trusted for two thirds of a time to live from when the
+// last successful request was sent, the time from which the leaser's expiry
+// is at least a time to live away. Failed renewals are retried at the same
+// interval, each bounded by that deadline; once it passes, or the leaser
+// reports ErrNodeLost, the lease is lost.
+func (l *NodeLease) renew(ctx context.Context, sent time.Time) {
+	defer close(l.done)
+	t := time.NewTicker(l.ttl / 6)
+	defer t.Stop()
+	trust := l.ttl - l.ttl/3
+	expire := time.NewTimer(time.Until(sent.Add(trust)))
+	defer expire.Stop()
+	err := context.DeadlineExceeded // until a renewal has failed
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-expire.C:
+			l.lose(err)
+			return
+		case <-t.C:
+		}
+		at := time.Now()
+		rctx, cancel := context.WithDeadline(ctx, sent.Add(trust))
+		rerr := l.leaser.Renew(rctx, l.node, l.ttl)
+		cancel()
+		switch {
+		case rerr == nil:
+			sent = at
+			expire.Reset(time.Until(sent.Add(trust)))
+		case ctx.Err() != nil:
+			return
+		case errors.Is(rerr, ErrNodeLost) || time.Since(sent) >= trust:
+			l.lose(rerr)
+			return
+		default:
+			err = rerr
+		}
+	}
+}
+
+// lose records err as the reason the lease was lost, and closes Lost.
+func (l *NodeLease) lose(err error) {
+	l.err = fmt.Errorf("kid: renewing node %d: %w", l.node, err)
+	close(l.lost)
+}
+
+// Node returns the leased node value, for WithNode.
+func (l *NodeLease) Node() uint16 {
+	return l.node
+}
+
+// Lost returns a channel closed if the lease is lost.
+func (l *NodeLease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Err returns why the lease was lost, or nil while it is held.
+func (l *NodeLease) Err() error {
+	select {
+	case <-l.lost:
+		return l.err
+	default:
+		return nil
+	}
+}
+
+// Close stops renewal and releases the node value. Stop issuing IDs with it
+// first.
+func (l *NodeLease) Close() error {
+	var err error
+	l.once.Do(func() {
+		l.stop()
+		<-l.done
+		ctx, cancel := context.WithTimeout(context.Background(), l.ttl)
+		defer cancel()
+		if rerr := l.leaser.Release(ctx, l.node); rerr != nil {
+			err = fmt.Errorf("kid: releasing node %d: %w", l.node, rerr)
+		}
+	})
+	return err
+}
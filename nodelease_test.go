@@ -0,0 +1,149 @@
+package kid
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memLeaser is an in-memory NodeLeaser whose renewals can be made to fail.
+type memLeaser struct {
+	mu       sync.Mutex
+	held     map[uint16]time.Time // expiry
+	renewals int
+	fail     error // returned by Renew if set
+}
+
+func (m *memLeaser) Lease(_ context.Context, ttl time.Duration) (uint16, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for n := range 1 << 16 {
+		if exp, ok := m.held[uint16(n)]; !ok || time.Now().After(exp) {
+			m.held[uint16(n)] = time.Now().Add(ttl)
+			return uint16(n), nil
+		}
+	}
+	return 0, ErrNoFreeNode
+}
+
+func (m *memLeaser) Renew(_ context.Context, node uint16, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.renewals++
+	if m.fail != nil {
+		return m.fail
+	}
+	if exp, ok := m.held[node]; !ok || time.Now().After(exp) {
+		return ErrNodeLost
+	}
+	m.held[node] = time.Now().Add(ttl)
+	return nil
+}
+
+func (m *memLeaser) Release(_ context.Context, node uint16) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.held, node)
+	return nil
+}
+
+func (m *memLeaser) set(f func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f()
+}
+
+func TestLeaseNode(t *testing.T) {
+	m := &memLeaser{held: map[uint16]time.Time{}}
+	const ttl = 30 * time.Millisecond
+	a, err := LeaseNode(context.Background(), m, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := LeaseNode(context.Background(), m, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Node() == b.Node() {
+		t.Fatalf("two leases hold node %d", a.Node())
+	}
+
+	// renewal outlives the time to live
+	time.Sleep(5 * ttl)
+	select {
+	case <-a.Lost():
+		t.Fatalf("lease lost: %v", a.Err())
+	default:
+	}
+	if a.Err() != nil {
+		t.Errorf("Err() = %v while held", a.Err())
+	}
+	m.set(func() {
+		if m.renewals < 8 {
+			t.Errorf("%d renewals in 5 TTLs, want about 15 for two leases", m.renewals)
+		}
+	})
+
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Close(); err != nil {
+		t.Errorf("second Close() = %v", err)
+	}
+	c, err := LeaseNode(context.Background(), m, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Node() != a.Node() {
+		t.Errorf("node after release = %d, want the released %d", c.Node(), a.Node())
+	}
+	b.Close()
+	c.Close()
+}
+
+func TestLeaseNodeLost(t *testing.T) {
+	const ttl = 120 * time.Millisecond
+	transient := errors.New("unreachable")
+	for _, fail := range []error{ErrNodeLost, transient} {
+		m := &memLeaser{held: map[uint16]time.Time{}}
+		l, err := LeaseNode(context.Background(), m, ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.set(func() { m.fail = fail })
+		select {
+		case <-l.Lost():
+		case <-time.After(10 * ttl):
+			t.Fatalf("%v: lease not lost", fail)
+		}
+		// lost while the leaser still holds the node for us
+		m.set(func() {
+			if exp := m.held[l.Node()]; !time.Now().Before(exp) {
+				t.Errorf("%v: lease lost %v after the leaser freed it", fail, time.Since(exp))
+			}
+		})
+		if !errors.Is(l.Err(), fail) {
+			t.Errorf("Err() = %v, want %v", l.Err(), fail)
+		}
+		// a transient failure is retried until the time to live runs out
+		m.set(func() {
+			if fail == transient && m.renewals < 3 {
+				t.Errorf("lost after %d renewals, want retries for a TTL", m.renewals)
+			}
+		})
+		l.Close()
+	}
+
+	m := &memLeaser{held: map[uint16]time.Time{}}
+	if _, err := LeaseNode(context.Background(), m, 5); err == nil {
+		t.Errorf("LeaseNode() with a 5ns TTL succeeded, want an error")
+	}
+	for n := range 1 << 16 {
+		m.held[uint16(n)] = time.Now().Add(time.Hour)
+	}
+	if _, err := LeaseNode(context.Background(), m, ttl); !errors.Is(err, ErrNoFreeNode) {
+		t.Errorf("LeaseNode() with every node held = %v, want ErrNoFreeNode", err)
+	}
+}
@@ -0,0 +1,38 @@
+package kid
+
+// NullID is an ID that may be null, in the manner of sql.NullString, for
+// nullable columns and optional JSON fields without resorting to *ID:
+//
+//	var parent kid.NullID
+//	err := row.Scan(&parent)
+//	if parent.Valid {
+//		// use parent.ID
+//	}
+//
+// NullID is null exactly when Valid is false. ID maps the nil ID to NULL
+// and JSON null, so a valid NullID holding Nil reads back as not valid.
+type NullID struct {
+	ID    ID
+	Valid bool // Valid is true if ID is not NULL
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding n as null if
+// it is not valid, or else as n.ID.
+func (n NullID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.ID.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting null,
+// which sets Valid to false, or whatever ID.UnmarshalJSON accepts.
+func (n *NullID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*n = NullID{}
+		return nil
+	}
+	err := n.ID.UnmarshalJSON(b)
+	n.Valid = err == nil
+	return err
+}
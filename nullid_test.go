@@ -0,0 +1,33 @@
+package kid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullIDJSON(t *testing.T) {
+	type record struct {
+		Parent NullID `json:"parent"`
+	}
+	id := tests[6].id
+	for _, tt := range []struct {
+		in   record
+		json string
+	}{
+		{record{NullID{ID: id, Valid: true}}, `{"parent":"06bqer9xnm79tfnl"}`},
+		{record{}, `{"parent":null}`},
+	} {
+		b, err := json.Marshal(tt.in)
+		if err != nil || string(b) != tt.json {
+			t.Errorf("Marshal(%+v) = %s, %v, want %s", tt.in, b, err, tt.json)
+		}
+		got := record{NullID{ID: New(), Valid: true}}
+		if err := json.Unmarshal(b, &got); err != nil || got != tt.in {
+			t.Errorf("Unmarshal(%s) = %+v, %v, want %+v", b, got, err, tt.in)
+		}
+	}
+	var n NullID
+	if err := json.Unmarshal([]byte(`"06bqer9xnm79tfn"`), &n); err == nil || n.Valid {
+		t.Errorf("Unmarshal(short) = %+v, %v, want error", n, err)
+	}
+}
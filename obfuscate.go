@@ -0,0 +1,92 @@
+package kid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+)
+
+// feistelRounds is the number of rounds of the obfuscation cipher; four
+// suffice for a strong pseudorandom permutation with a pseudorandom round
+// function (Luby-Rackoff), the rest are margin.
+const feistelRounds = 8
+
+// Obfuscate returns id encrypted under key with a reversible 80-bit block
+// cipher, encoded like an ID: 16 characters of the kid alphabet that hide
+// the creation time and sequence from anyone without key, so exposed IDs
+// leak neither signup times nor order volumes. Deobfuscate reverses it.
+//
+// Obfuscated strings do not sort like their IDs; keep the plain ID, which
+// does, for storage and indexing and obfuscate only at the API boundary.
+// The cipher is deterministic, so an ID always obfuscates to the same
+// string under one key. key may be of any length; use at least 16 random
+// bytes.
+//
+// The cipher is a balanced Feistel network over the two 40-bit halves of
+// the ID, with AES-128, keyed by SHA-256 of key, as the round function.
+func (id ID) Obfuscate(key []byte) string {
+	f := newFeistel(key)
+	l, r := halves(id)
+	for i := range feistelRounds {
+		l, r = r, l^f.round(i, r)
+	}
+	return join(l, r).String()
+}
+
+// Deobfuscate returns the ID that ID.Obfuscate encrypted to s under key. It
+// returns ErrInvalidID if s is not 16 characters of the kid alphabet; a
+// wrong key yields a wrong ID, not an error. Pair it with Sign where
+// tampering must be detected.
+func Deobfuscate(key []byte, s string) (ID, error) {
+	c, err := FromString(s)
+	if err != nil {
+		return Nil, err
+	}
+	f := newFeistel(key)
+	l, r := halves(c)
+	for i := feistelRounds - 1; i >= 0; i-- {
+		l, r = r^f.round(i, l), l
+	}
+	return join(l, r), nil
+}
+
+type feistel struct {
+	block cipher.Block
+}
+
+func newFeistel(key []byte) feistel {
+	k := sha256.Sum256(key)
+	block, err := aes.NewCipher(k[:16])
+	if err != nil {
+		panic(err) // unreachable: the key length is fixed
+	}
+	return feistel{block}
+}
+
+// round is the round function: the first 40 bits of AES of the round number
+// and the 40-bit half x.
+func (f feistel) round(i int, x uint64) uint64 {
+	var b [aes.BlockSize]byte
+	b[0] = byte(i)
+	b[1], b[2], b[3], b[4], b[5] = byte(x>>32), byte(x>>24), byte(x>>16), byte(x>>8), byte(x)
+	f.block.Encrypt(b[:], b[:])
+	return uint64(b[0])<<32 | uint64(b[1])<<24 | uint64(b[2])<<16 | uint64(b[3])<<8 | uint64(b[4])
+}
+
+// halves splits id into two 40-bit values, big endian.
+func halves(id ID) (l, r uint64) {
+	for i := range 5 {
+		l = l<<8 | uint64(id[i])
+		r = r<<8 | uint64(id[i+5])
+	}
+	return l, r
+}
+
+// join is the inverse of halves.
+func join(l, r uint64) (id ID) {
+	for i := 4; i >= 0; i-- {
+		id[i], id[i+5] = byte(l), byte(r)
+		l, r = l>>8, r>>8
+	}
+	return id
+}
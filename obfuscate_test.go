@@ -0,0 +1,48 @@
+package kid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestObfuscate(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	seen := make(map[string]bool)
+	shared := 0
+	prev := New()
+	for range 1000 {
+		id := New()
+		s := id.Obfuscate(key)
+		if !IsValid(s) || seen[s] {
+			t.Fatalf("Obfuscate(%v) = %q, not a fresh valid encoding", id, s)
+		}
+		seen[s] = true
+		if got, err := Deobfuscate(key, s); err != nil || got != id {
+			t.Fatalf("Deobfuscate(Obfuscate(%v)) = %v, %v", id, got, err)
+		}
+		// consecutive IDs share a timestamp prefix; obfuscated, they do not
+		if prev.Obfuscate(key)[:4] == s[:4] {
+			shared++
+		}
+		prev = id
+	}
+	if shared > 2 {
+		t.Errorf("%d of 1000 consecutive obfuscated IDs share a 4-character prefix, want about none", shared)
+	}
+
+	id := tests[6].id
+	if a, b := id.Obfuscate(key), id.Obfuscate([]byte("another key")); a == b {
+		t.Errorf("Obfuscate() under two keys = %s, want different strings", a)
+	}
+	if got, _ := Deobfuscate([]byte("another key"), id.Obfuscate(key)); got == id {
+		t.Error("Deobfuscate() with the wrong key recovered the ID")
+	}
+	if _, err := Deobfuscate(key, "not an id"); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("Deobfuscate(invalid) err = %v, want ErrInvalidID", err)
+	}
+	for _, id := range []ID{Nil, Max} {
+		if got, err := Deobfuscate(key, id.Obfuscate(key)); err != nil || got != id {
+			t.Errorf("round trip of %v = %v, %v", id, got, err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package kid
+
+import "time"
+
+// Observer receives instrumentation events from a Generator, for exporting
+// as metrics; see WithObserver and the kidprom module for a Prometheus
+// collector. Methods are called synchronously by the goroutine generating
+// an ID, often concurrently, so they must be goroutine-safe and cheap: an
+// atomic counter increment, not I/O.
+type Observer interface {
+	// Issued is called for each timestamp+sequence claimed from the clock:
+	// every ID, ID64 and ID128 from New, Generate, NewWithDeadline and
+	// their variants. NewWithTime, which claims from the given time, is not
+	// counted.
+	Issued()
+
+	// Rollover is called when the sequence of a millisecond is exhausted
+	// and generation carries into the next, borrowing ahead of the clock
+	// if it has not yet caught up; see MaxPerMillisecond.
+	Rollover()
+
+	// Regression is called once per clock regression, as the hook of
+	// WithRegressionHook is, with the size of the step.
+	Regression(behind time.Duration)
+
+	// Waited is called when generation blocked, under the WaitForClock
+	// policy or in NewWithDeadline, with the time slept.
+	Waited(d time.Duration)
+}
+
+// BatchObserver is an Observer that can be told of many values issued at
+// once, as by ReserveSequence, in a single call: one counter addition where
+// an Observer takes one Issued call per value.
+type BatchObserver interface {
+	Observer
+
+	// IssuedN is called in place of n calls of Issued.
+	IssuedN(n int)
+}
+
+// WithObserver sets an Observer notified of the Generator's activity: IDs
+// issued, sequence rollovers, clock regressions and time spent waiting.
+// Observing regressions routes New through the checks of Generate, as
+// WithRegressionHook does.
+func WithObserver(o Observer) Option[Generator] {
+	return func(g *Generator) {
+		g.obs = o
+		g.obsN, _ = o.(BatchObserver)
+	}
+}
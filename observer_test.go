@@ -0,0 +1,72 @@
+package kid
+
+import (
+	"testing"
+	"time"
+)
+
+// countingObserver records a Generator's events. It is not goroutine-safe.
+type countingObserver struct {
+	issued, rollovers int
+	regressions       []time.Duration
+	waited            time.Duration
+}
+
+func (o *countingObserver) Issued()   { o.issued++ }
+func (o *countingObserver) Rollover() { o.rollovers++ }
+func (o *countingObserver) Regression(behind time.Duration) {
+	o.regressions = append(o.regressions, behind)
+}
+func (o *countingObserver) Waited(d time.Duration) { o.waited += d }
+
+func TestObserver(t *testing.T) {
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	o := &countingObserver{}
+	g := NewGenerator(WithClock(c.now), WithObserver(o))
+
+	// the clock stands still: one millisecond holds MaxPerMillisecond IDs
+	for range MaxPerMillisecond {
+		g.New()
+	}
+	if o.issued != MaxPerMillisecond || o.rollovers != 0 {
+		t.Errorf("after %d IDs: issued %d, rollovers %d; want %d, 0", MaxPerMillisecond, o.issued, o.rollovers, MaxPerMillisecond)
+	}
+	g.New()
+	g.New128()
+	g.New64()
+	if o.issued != MaxPerMillisecond+3 || o.rollovers != 1 {
+		t.Errorf("after rolling over: issued %d, rollovers %d; want %d, 1", o.issued, o.rollovers, MaxPerMillisecond+3)
+	}
+	g.NewWithTime(c.t)
+	if o.issued != MaxPerMillisecond+3 {
+		t.Errorf("NewWithTime counted as issued: %d", o.issued)
+	}
+
+	c.t = c.t.Add(-time.Minute)
+	g.New()
+	g.New()
+	if len(o.regressions) != 1 || o.regressions[0] != time.Minute {
+		t.Errorf("regressions = %v, want [1m0s]", o.regressions)
+	}
+	if o.waited != 0 {
+		t.Errorf("waited %v under AdvanceSequence", o.waited)
+	}
+}
+
+func TestObserverWaited(t *testing.T) {
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	// each reading advances the clock a millisecond
+	tick := func() time.Time {
+		t := c.t
+		c.t = c.t.Add(time.Millisecond)
+		return t
+	}
+	o := &countingObserver{}
+	g := NewGenerator(WithClock(tick), WithRegressionPolicy(WaitForClock), WithObserver(o))
+	g.New()
+	c.t = c.t.Add(-5 * time.Millisecond)
+	g.New()
+	if o.waited <= 0 || len(o.regressions) != 1 {
+		t.Errorf("waited %v, regressions %v; want a wait for one regression", o.waited, o.regressions)
+	}
+}
@@ -0,0 +1,18 @@
+package kid
+
+// Option is a functional option configuring a value of type T, such as a
+// Generator. Each option constructor returns the Option type of the target
+// it applies to, so passing an option to the wrong constructor is a compile
+// time error rather than a silently ignored setting:
+//
+//	g := kid.NewGenerator(kid.WithClock(clock), kid.WithNoRandom())
+//
+// New configurable types take their options as ...Option[T] in the same way.
+type Option[T any] func(*T)
+
+// apply configures t with opts, in order; later options override earlier.
+func apply[T any](t *T, opts []Option[T]) {
+	for _, opt := range opts {
+		opt(t)
+	}
+}
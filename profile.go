@@ -0,0 +1,255 @@
+package kid
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// EncodingProfile is a textual presentation of IDs, bundling an alphabet,
+// letter case, optional grouping separators and an optional check character,
+// so presentation options compose through one type rather than a pair of
+// functions each. Profiles are created by NewEncodingProfile and are safe for
+// concurrent use.
+//
+// Every profile encodes the same 80 bits as String, five per character; only
+// the characters and their layout differ. With an alphabet in ascending byte
+// order, as all predefined profiles use, encoded IDs sort like binary IDs.
+type EncodingProfile struct {
+	alphabet string
+	upper    bool
+	group    int  // characters between separators; 0 for none
+	sep      byte // separator inserted every group characters
+	checksum Checksum
+
+	enc   [32]byte  // 5-bit value to character
+	dec   [256]byte // character to 5-bit value, or maxByte
+	check [37]byte  // check value to character, for Mod37
+}
+
+// Checksum selects the check character, if any, an EncodingProfile appends
+// to encoded IDs and verifies when decoding.
+type Checksum int
+
+const (
+	// NoChecksum appends no check character.
+	NoChecksum Checksum = iota
+
+	// Mod37 appends one check character, the ID's 80-bit value modulo 37,
+	// after Crockford's base32 check symbols: values 0-31 are written with
+	// the profile's alphabet, 32-36 as '*', '~', '$', '=' and 'u'. It
+	// detects every single-character substitution and every transposition
+	// of adjacent characters.
+	Mod37
+)
+
+// checkSymbols are the Mod37 check characters beyond the alphabet.
+const checkSymbols = "*~$=u"
+
+// ErrChecksum is returned when decoding an ID whose check character does not
+// match; it wraps ErrInvalidID.
+var ErrChecksum = fmt.Errorf("%w: check character mismatch", ErrInvalidID)
+
+// Predefined profiles.
+var (
+	// DefaultProfile is the package encoding used by String and FromString:
+	// "06bqer9xnm79tfnl".
+	DefaultProfile = NewEncodingProfile()
+
+	// UpperProfile is DefaultProfile in upper case: "06BQER9XNM79TFNL".
+	UpperProfile = NewEncodingProfile(WithUpperCase())
+
+	// PrettyProfile groups DefaultProfile in fours for reading aloud and
+	// transcription: "06bq-er9x-nm79-tfnl".
+	PrettyProfile = NewEncodingProfile(WithGroups(4, '-'))
+
+	// CheckedProfile is DefaultProfile with a Mod37 check character, used by
+	// EncodeChecked and FromCheckedString: "06bqer9xnm79tfnl$".
+	CheckedProfile = NewEncodingProfile(WithChecksum(Mod37))
+)
+
+// WithAlphabet sets the 32 distinct ASCII characters, from lowest to highest
+// value, an EncodingProfile encodes with. The default is the package
+// alphabet, "0123456789bcdefghjklmnpqrstvwxyz".
+func WithAlphabet(alphabet string) Option[EncodingProfile] {
+	return func(p *EncodingProfile) {
+		p.alphabet = alphabet
+	}
+}
+
+// WithUpperCase encodes the letters of an EncodingProfile's alphabet in upper
+// case. Decoding then expects upper case.
+func WithUpperCase() Option[EncodingProfile] {
+	return func(p *EncodingProfile) {
+		p.upper = true
+	}
+}
+
+// WithGroups inserts sep between each run of size characters, e.g.
+// WithGroups(4, '-') gives "06bq-er9x-nm79-tfnl". Decoding requires the
+// separators in the same places.
+func WithGroups(size int, sep byte) Option[EncodingProfile] {
+	return func(p *EncodingProfile) {
+		p.group, p.sep = size, sep
+	}
+}
+
+// WithChecksum appends the check character selected by c.
+func WithChecksum(c Checksum) Option[EncodingProfile] {
+	return func(p *EncodingProfile) {
+		p.checksum = c
+	}
+}
+
+// NewEncodingProfile returns an EncodingProfile configured by opts. Like
+// encoding/base32.NewEncoding, it panics if the configuration is invalid: an
+// alphabet that is not 32 distinct ASCII characters, a group size outside
+// 1-15, or a separator that is also in the alphabet.
+func NewEncodingProfile(opts ...Option[EncodingProfile]) *EncodingProfile {
+	p := &EncodingProfile{alphabet: encoding}
+	apply(p, opts)
+
+	if len(p.alphabet) != len(p.enc) {
+		panic(fmt.Sprintf("kid: encoding alphabet %q is not 32 characters", p.alphabet))
+	}
+	if p.group != 0 && (p.group < 1 || p.group >= encodedLen) {
+		panic(fmt.Sprintf("kid: encoding group size %d is outside 1-%d", p.group, encodedLen-1))
+	}
+	for i := range p.dec {
+		p.dec[i] = maxByte
+	}
+	for i := range len(p.alphabet) {
+		c := p.alphabet[i]
+		if p.upper && 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c >= 0x80 || p.dec[c] != maxByte {
+			panic(fmt.Sprintf("kid: encoding alphabet %q has a non-ASCII or repeated character", p.alphabet))
+		}
+		p.enc[i], p.dec[c] = c, byte(i)
+	}
+	if p.group != 0 && p.dec[p.sep] != maxByte {
+		panic(fmt.Sprintf("kid: encoding separator %q is in the alphabet", p.sep))
+	}
+	if p.checksum == Mod37 {
+		copy(p.check[:], p.enc[:])
+		for i := range len(checkSymbols) {
+			c := checkSymbols[i]
+			if p.upper && 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			if p.dec[c] != maxByte || p.group != 0 && c == p.sep {
+				panic(fmt.Sprintf("kid: encoding check character %q is in the alphabet or the separator", c))
+			}
+			p.check[len(p.enc)+i] = c
+		}
+	}
+	return p
+}
+
+// NewEncoding returns an EncodingProfile encoding with alphabet, 32 distinct
+// ASCII characters from lowest to highest value, in place of the package
+// alphabet: for example, to avoid characters a legacy system cannot handle.
+// Its Encode, String and FromString methods are the encoder/decoder pair.
+// NewEncoding is shorthand for NewEncodingProfile(WithAlphabet(alphabet)),
+// and likewise panics if alphabet is invalid.
+func NewEncoding(alphabet string) *EncodingProfile {
+	return NewEncodingProfile(WithAlphabet(alphabet))
+}
+
+// EncodedLen returns the length in bytes of an ID encoded with p.
+func (p *EncodingProfile) EncodedLen() int {
+	n := encodedLen
+	if p.group != 0 {
+		n += (encodedLen - 1) / p.group
+	}
+	if p.checksum != NoChecksum {
+		n++
+	}
+	return n
+}
+
+// Encode encodes id with p, writing EncodedLen bytes to dst and returning
+// them. Encode panics if len(dst) < EncodedLen.
+func (p *EncodingProfile) Encode(dst []byte, id ID) []byte {
+	var text [encodedLen]byte
+	encode(text[:], id[:])
+	n := 0
+	for i, c := range text {
+		if p.group != 0 && i != 0 && i%p.group == 0 {
+			dst[n] = p.sep
+			n++
+		}
+		dst[n] = p.enc[dec[c]]
+		n++
+	}
+	if p.checksum == Mod37 {
+		dst[n] = p.check[mod37(id)]
+		n++
+	}
+	return dst[:n]
+}
+
+// String returns id encoded with p.
+func (p *EncodingProfile) String(id ID) string {
+	buf := make([]byte, p.EncodedLen())
+	return string(p.Encode(buf, id))
+}
+
+// FromString decodes s, an ID encoded with p. On error it returns the nil ID
+// and an *InvalidLengthError or *InvalidCharError, wrapping ErrInvalidID, or
+// ErrChecksum, which wraps it too, if s is well formed but its check
+// character does not match.
+func (p *EncodingProfile) FromString(s string) (ID, error) {
+	if len(s) != p.EncodedLen() {
+		return Nil, &InvalidLengthError{Got: len(s), Want: p.EncodedLen()}
+	}
+	var text [encodedLen]byte
+	n := 0
+	for i := range text {
+		if p.group != 0 && i != 0 && i%p.group == 0 {
+			if s[n] != p.sep {
+				return Nil, &InvalidCharError{Pos: n, Char: s[n]}
+			}
+			n++
+		}
+		v := p.dec[s[n]]
+		if v == maxByte {
+			return Nil, &InvalidCharError{Pos: n, Char: s[n]}
+		}
+		text[i] = encoding[v]
+		n++
+	}
+	var id ID
+	decode(&id, text[:])
+	if p.checksum == Mod37 {
+		if i := bytes.IndexByte(p.check[:], s[n]); i < 0 {
+			return Nil, &InvalidCharError{Pos: n, Char: s[n]}
+		} else if i != mod37(id) {
+			return Nil, ErrChecksum
+		}
+	}
+	return id, nil
+}
+
+// mod37 returns id, as an 80-bit big-endian integer, modulo 37.
+func mod37(id ID) int {
+	r := 0
+	for _, b := range id {
+		r = (r<<8 | int(b)) % 37
+	}
+	return r
+}
+
+// EncodeChecked returns id encoded with a trailing Mod37 check character,
+// 17 characters, so transcription errors from logs, emails and support
+// tickets are caught by FromCheckedString. The default 16-character format
+// is unchanged.
+func (id ID) EncodeChecked() string {
+	return CheckedProfile.String(id)
+}
+
+// FromCheckedString decodes s, an ID encoded by EncodeChecked, returning
+// ErrChecksum if the check character does not match.
+func FromCheckedString(s string) (ID, error) {
+	return CheckedProfile.FromString(s)
+}
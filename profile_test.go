@@ -0,0 +1,160 @@
+package kid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEncodingProfiles(t *testing.T) {
+	id := tests[6].id // 06bqer9xnm79tfnl
+	for _, tt := range []struct {
+		name    string
+		profile *EncodingProfile
+		want    string
+	}{
+		{"default", DefaultProfile, "06bqer9xnm79tfnl"},
+		{"upper", UpperProfile, "06BQER9XNM79TFNL"},
+		{"pretty", PrettyProfile, "06bq-er9x-nm79-tfnl"},
+		{"upper groups of 5", NewEncodingProfile(WithUpperCase(), WithGroups(5, '.')), "06BQE.R9XNM.79TFN.L"},
+		{"crockford alphabet", NewEncodingProfile(WithAlphabet("0123456789abcdefghjkmnpqrstvwxyz")), "06aqdr9xnm79tenk"},
+	} {
+		got := tt.profile.String(id)
+		if got != tt.want {
+			t.Errorf("%s: String() = %s, want %s", tt.name, got, tt.want)
+		}
+		if n := tt.profile.EncodedLen(); n != len(got) {
+			t.Errorf("%s: EncodedLen() = %d, want %d", tt.name, n, len(got))
+		}
+		if back, err := tt.profile.FromString(got); err != nil || back != id {
+			t.Errorf("%s: FromString(%s) = %v, %v, want %v", tt.name, got, back, err, id)
+		}
+	}
+}
+
+func TestEncodingProfileInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		profile *EncodingProfile
+		s       string
+	}{
+		{DefaultProfile, "06BQER9XNM79TFNL"},
+		{UpperProfile, "06bqer9xnm79tfnl"},
+		{PrettyProfile, "06bqer9xnm79tfnl"},
+		{PrettyProfile, "06bq-er9x-nm79tfnl-"},
+		{PrettyProfile, "06bq_er9x_nm79_tfnl"},
+		{PrettyProfile, "06bq-er9x-nm79-tfna"},
+	} {
+		if id, err := tt.profile.FromString(tt.s); !errors.Is(err, ErrInvalidID) || err == ErrChecksum || id != Nil {
+			t.Errorf("FromString(%q) = %v, %v, want Nil, ErrInvalidID", tt.s, id, err)
+		}
+	}
+}
+
+func TestEncodingProfilePreservesOrder(t *testing.T) {
+	ids := randomIDs(1000)
+	Sort(ids)
+	for _, p := range []*EncodingProfile{UpperProfile, PrettyProfile} {
+		strs := make([]string, len(ids))
+		for i, id := range ids {
+			strs[i] = p.String(id)
+		}
+		if !slices.IsSorted(strs) {
+			t.Errorf("%s...: encoded IDs do not sort like binary IDs", strs[0])
+		}
+	}
+}
+
+func TestNewEncodingProfilePanics(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts []Option[EncodingProfile]
+		want string
+	}{
+		{"short alphabet", []Option[EncodingProfile]{WithAlphabet("0123456789")}, "not 32 characters"},
+		{"repeated character", []Option[EncodingProfile]{WithAlphabet(strings.Repeat("ab", 16))}, "repeated"},
+		{"case collision", []Option[EncodingProfile]{WithAlphabet("0123456789bcdefghjklmnpqrstvwxyB"), WithUpperCase()}, "repeated"},
+		{"group size", []Option[EncodingProfile]{WithGroups(16, '-')}, "group size"},
+		{"separator in alphabet", []Option[EncodingProfile]{WithGroups(4, 'b')}, "separator"},
+		{"check symbol in alphabet", []Option[EncodingProfile]{WithAlphabet("0123456789bcdefghjklmnpqrstvwxy*"), WithChecksum(Mod37)}, "check character"},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), tt.want) {
+					t.Errorf("%s: recover() = %v, want panic containing %q", tt.name, r, tt.want)
+				}
+			}()
+			NewEncodingProfile(tt.opts...)
+		}()
+	}
+}
+
+func TestChecked(t *testing.T) {
+	id := tests[6].id
+	if got, want := id.EncodeChecked(), "06bqer9xnm79tfnl$"; got != want {
+		t.Errorf("EncodeChecked() = %s, want %s", got, want)
+	}
+	for range 1000 {
+		id := New()
+		s := id.EncodeChecked()
+		if got, err := FromCheckedString(s); err != nil || got != id {
+			t.Fatalf("FromCheckedString(%s) = %v, %v, want %v", s, got, err, id)
+		}
+		// every single substitution and adjacent transposition is caught
+		for i := range encodedLen {
+			b := []byte(s)
+			b[i] = encoding[(dec[b[i]]+1+byte(i)%31)%32]
+			if _, err := FromCheckedString(string(b)); !errors.Is(err, ErrChecksum) {
+				t.Fatalf("FromCheckedString(%s), substituted from %s: err = %v, want ErrChecksum", b, s, err)
+			}
+			if b := []byte(s); i+1 < encodedLen && b[i] != b[i+1] {
+				b[i], b[i+1] = b[i+1], b[i]
+				if _, err := FromCheckedString(string(b)); !errors.Is(err, ErrChecksum) {
+					t.Fatalf("FromCheckedString(%s), transposed from %s: err = %v, want ErrChecksum", b, s, err)
+				}
+			}
+		}
+	}
+	for _, s := range []string{"06bqer9xnm79tfnl", "06bqer9xnm79tfnl!", "06bqer9xnm79tfnl$0"} {
+		if _, err := FromCheckedString(s); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("FromCheckedString(%q) err = %v, want ErrInvalidID", s, err)
+		}
+	}
+	p := NewEncodingProfile(WithUpperCase(), WithGroups(4, '-'), WithChecksum(Mod37))
+	if got, want := p.String(id), "06BQ-ER9X-NM79-TFNL$"; got != want {
+		t.Errorf("grouped upper case String() = %s, want %s", got, want)
+	}
+}
+
+func BenchmarkEncodingProfileString(b *testing.B) {
+	id := New()
+	var r string
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r = PrettyProfile.String(id)
+		}
+		benchResultString = r
+	})
+}
+
+func ExampleEncodingProfile() {
+	id := MustFromString("06bqer9xnm79tfnl")
+	fmt.Println(PrettyProfile.String(id))
+	fmt.Println(UpperProfile.String(id))
+	id, err := PrettyProfile.FromString("06bq-er9x-nm79-tfnl")
+	fmt.Println(id, err)
+	// Output:
+	// 06bq-er9x-nm79-tfnl
+	// 06BQER9XNM79TFNL
+	// 06bqer9xnm79tfnl <nil>
+}
+
+func ExampleNewEncoding() {
+	// digits and upper case letters, without the easily confused I and O
+	enc := NewEncoding("0123456789ABCDEFGHJKLMNPQRSTUVWX")
+	s := enc.String(MustFromString("06bqer9xnm79tfnl"))
+	id, err := enc.FromString(s)
+	fmt.Println(s, id, err)
+	// Output: 06APDQ9VML79SEMK 06bqer9xnm79tfnl <nil>
+}
@@ -0,0 +1,95 @@
+package kid
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+// codec is a text form of IDs, for properties that must hold across all of
+// them. ordered codecs sort like the binary IDs they encode.
+type codec struct {
+	name    string
+	encode  func(ID) string
+	decode  func(string) (ID, error)
+	ordered bool
+}
+
+var codecs = []codec{
+	{"String", ID.String, FromString, true},
+	{"Hex", ID.Hex, FromHex, true},
+	{"Base62", ID.Base62, FromBase62, true},
+	{"Base64", ID.Base64, FromBase64, false},
+	{"Crockford", ID.EncodeCrockford, FromCrockford, true},
+	{"Checked", ID.EncodeChecked, FromCheckedString, true},
+	{"ULID", ID.ULID, FromULID, true},
+	{"UpperProfile", UpperProfile.String, UpperProfile.FromString, true},
+	{"PrettyProfile", PrettyProfile.String, PrettyProfile.FromString, true},
+	{"Loose", func(id ID) string { return strings.ToUpper(id.String()) }, FromStringLoose, true},
+	{"JSON", func(id ID) string { return string(id.AppendJSON(nil)) }, func(s string) (id ID, err error) {
+		err = id.UnmarshalJSON([]byte(s))
+		return id, err
+	}, false}, // the nil ID is null
+}
+
+var quickConfig = &quick.Config{MaxCount: 5000}
+
+// TestPropertyRoundTrip checks that every codec round-trips arbitrary 10-byte
+// values, and that ordered codecs order any two of them as Compare does.
+func TestPropertyRoundTrip(t *testing.T) {
+	for _, c := range codecs {
+		f := func(a, b [rawLen]byte) bool {
+			x, y := ID(a), ID(b)
+			ex, ey := c.encode(x), c.encode(y)
+			if back, err := c.decode(ex); err != nil || back != x {
+				t.Logf("%s: %v encodes as %q, decoding to %v, %v", c.name, x, ex, back, err)
+				return false
+			}
+			if c.ordered && x.Compare(y) != strings.Compare(ex, ey) {
+				t.Logf("%s: %v, %v compare %d, encoded %q, %q compare %d", c.name, x, y, x.Compare(y), ex, ey, strings.Compare(ex, ey))
+				return false
+			}
+			return true
+		}
+		if err := quick.Check(f, quickConfig); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+		}
+	}
+}
+
+// TestPropertyGeneratedOrder checks that each ID a Generator issues sorts
+// after the one before it, in binary, in every ordered codec and as a UUID,
+// whatever the clock does between calls: steps are arbitrary, negative ones
+// stepping it backwards.
+func TestPropertyGeneratedOrder(t *testing.T) {
+	f := func(steps []int16) bool {
+		c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+		g := NewGenerator(WithClock(c.now))
+		prev := g.New()
+		for _, step := range steps {
+			c.t = c.t.Add(time.Duration(step) * time.Microsecond)
+			id := g.New()
+			if id.Compare(prev) <= 0 || id.SequenceSpare() != 0 {
+				t.Logf("%v after %v", id, prev)
+				return false
+			}
+			for _, cd := range codecs {
+				if cd.ordered && strings.Compare(cd.encode(prev), cd.encode(id)) >= 0 {
+					t.Logf("%s: %q after %q", cd.name, cd.encode(id), cd.encode(prev))
+					return false
+				}
+			}
+			if u, pu := id.UUID(), prev.UUID(); bytes.Compare(pu[:], u[:]) >= 0 {
+				t.Logf("UUID: %x after %x", u, pu)
+				return false
+			}
+			prev = id
+		}
+		return true
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,42 @@
+package kid
+
+import "fmt"
+
+// Redis clients store an ID as its 10 raw bytes, 6 fewer than the encoded
+// string, and read it back exactly. For go-redis (github.com/redis/go-redis)
+// that needs nothing further: it writes arguments implementing
+// encoding.BinaryMarshaler with MarshalBinary, and Scan decodes replies into
+// an encoding.BinaryUnmarshaler with UnmarshalBinary. For redigo
+// (github.com/gomodule/redigo), ID implements redis.Argument and
+// redis.Scanner below, which need no import to implement.
+//
+// Keys are better readable: build them from the encoded form, as in
+// "order:" + id.String(), which keeps IDs' sort order in SCAN output and
+// sorted sets.
+
+// RedisArg implements redigo's redis.Argument, sending id as its 10 raw
+// bytes.
+func (id ID) RedisArg() any {
+	return id[:]
+}
+
+// RedisScan implements redigo's redis.Scanner, accepting a bulk string of
+// the 10 raw bytes, as written by RedisArg or go-redis, or of the
+// 16-character encoded form; a nil reply yields the nil ID.
+func (id *ID) RedisScan(src any) error {
+	switch val := src.(type) {
+	case []byte:
+		if len(val) == rawLen {
+			copy(id[:], val)
+			return nil
+		}
+		return id.UnmarshalText(val)
+	case string:
+		return id.UnmarshalText([]byte(val))
+	case nil:
+		*id = Nil
+		return nil
+	default:
+		return fmt.Errorf("kid: cannot scan Redis reply of type %T", src)
+	}
+}
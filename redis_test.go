@@ -0,0 +1,28 @@
+package kid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRedis(t *testing.T) {
+	id := tests[6].id
+	if arg, ok := id.RedisArg().([]byte); !ok || !bytes.Equal(arg, id[:]) {
+		t.Errorf("RedisArg() = %#v, want the 10 bytes %x", id.RedisArg(), id[:])
+	}
+	for _, src := range []any{id[:], []byte("06bqer9xnm79tfnl"), "06bqer9xnm79tfnl"} {
+		var got ID
+		if err := got.RedisScan(src); err != nil || got != id {
+			t.Errorf("RedisScan(%q) = %v, %v, want %v", src, got, err, id)
+		}
+	}
+	got := id
+	if err := got.RedisScan(nil); err != nil || got != Nil {
+		t.Errorf("RedisScan(nil) = %v, %v, want Nil", got, err)
+	}
+	for _, src := range []any{[]byte{1, 2, 3}, "06bqer9xnm79tfn", int64(42)} {
+		if err := got.RedisScan(src); err == nil {
+			t.Errorf("RedisScan(%#v) succeeded, want error", src)
+		}
+	}
+}
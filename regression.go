@@ -0,0 +1,136 @@
+package kid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrClockRegression is returned by Generate, under the ErrorOnRegression
+// policy, while the clock reads earlier than it has before.
+var ErrClockRegression = errors.New("kid: clock moved backwards")
+
+// RegressionPolicy selects how a Generator responds when its clock steps
+// backwards, as after an NTP correction.
+//
+// A regression is a clock reading more than a millisecond behind the latest
+// reading the Generator has seen. Smaller steps, and the apparent ones
+// between goroutines reading the clock concurrently, are absorbed by the
+// sequence under every policy.
+type RegressionPolicy int
+
+const (
+	// AdvanceSequence, the default, keeps issuing IDs from the sequence
+	// after the last issued timestamp until the clock catches up. Output
+	// never stalls, but embedded timestamps lead real time by up to the
+	// size of the step.
+	AdvanceSequence RegressionPolicy = iota
+
+	// WaitForClock blocks generation until the clock is back to its latest
+	// reading. Timestamps stay true to the clock, at the cost of stalling
+	// for as long as the clock stepped back.
+	WaitForClock
+
+	// ErrorOnRegression makes Generate return ErrClockRegression until the
+	// clock is back to its latest reading, leaving the response to the
+	// caller; New panics instead.
+	ErrorOnRegression
+)
+
+// WithRegressionPolicy sets how the Generator responds to clock
+// regressions, AdvanceSequence by default.
+func WithRegressionPolicy(p RegressionPolicy) Option[Generator] {
+	return func(g *Generator) {
+		g.policy = p
+	}
+}
+
+// WithRegressionHook sets a function called, with the size of the step,
+// when the Generator's clock is first seen to have moved backwards, so
+// operators can log or count regressions. It is called once per regression,
+// not for every ID generated before the clock catches up, and must be safe
+// for concurrent use.
+func WithRegressionHook(f func(behind time.Duration)) Option[Generator] {
+	return func(g *Generator) {
+		g.onRegress = f
+	}
+}
+
+// Generate generates a new unique ID, like New, reporting failure rather
+// than panicking: it returns ErrClockRegression under the ErrorOnRegression
+// policy, ErrTimeRange if the clock reads before the Generator's epoch (see
+// WithEpoch), and with a failing random source (WithRandom) the error it
+// returned.
+func (g *Generator) Generate() (ID, error) {
+	milli, seq, err := g.stamp()
+	if err != nil {
+		return Nil, err
+	}
+	id, err := g.assemble(milli, seq)
+	if err != nil {
+		return Nil, err
+	}
+	return id, nil
+}
+
+// stamp claims a timestamp and sequence as getTS does, first applying the
+// Generator's RegressionPolicy.
+func (g *Generator) stamp() (milli, seq int64, err error) {
+	nano, err := g.reading()
+	if err != nil {
+		return 0, 0, err
+	}
+	milli, seq = g.claim(nano)
+	return milli, seq, nil
+}
+
+// reading returns a clock reading in nanoseconds since the epoch, applying
+// the Generator's RegressionPolicy.
+func (g *Generator) reading() (int64, error) {
+	nano := g.now().UnixNano() - g.epoch
+	if g.watch {
+		for {
+			behind := g.checkClock(nano)
+			if behind == 0 || g.policy == AdvanceSequence {
+				break
+			}
+			if g.policy == ErrorOnRegression {
+				return 0, fmt.Errorf("%w by %v", ErrClockRegression, behind)
+			}
+			time.Sleep(behind) // WaitForClock
+			if g.obs != nil {
+				g.obs.Waited(behind)
+			}
+			nano = g.now().UnixNano() - g.epoch
+		}
+	}
+	if nano < 0 {
+		return 0, ErrTimeRange // the clock reads before the epoch
+	}
+	return nano, nil
+}
+
+// checkClock records nano, a clock reading, returning how far it is behind
+// the latest reading if that is a regression, or 0.
+func (g *Generator) checkClock(nano int64) time.Duration {
+	for {
+		latest := g.maxClock.Load()
+		if behind := latest - nano; behind > nanoPerMilli {
+			if g.regressing.CompareAndSwap(false, true) {
+				if g.onRegress != nil {
+					g.onRegress(time.Duration(behind))
+				}
+				if g.obs != nil {
+					g.obs.Regression(time.Duration(behind))
+				}
+			}
+			return time.Duration(behind)
+		}
+		if nano <= latest || g.maxClock.CompareAndSwap(latest, nano) {
+			if g.regressing.Load() {
+				g.regressing.Store(false)
+			}
+			return 0
+		}
+	}
+}
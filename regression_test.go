@@ -0,0 +1,99 @@
+package kid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegressionAdvanceSequenceHook(t *testing.T) {
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	var hooks []time.Duration
+	g := NewGenerator(WithClock(c.now), WithRegressionHook(func(behind time.Duration) {
+		hooks = append(hooks, behind)
+	}))
+	prev := g.New()
+	c.t = c.t.Add(-time.Minute)
+	for range 100 { // a regression is reported once, not per ID
+		id := g.New()
+		if id.Compare(prev) <= 0 {
+			t.Fatalf("%v does not sort after %v", id, prev)
+		}
+		prev = id
+		c.t = c.t.Add(time.Millisecond)
+	}
+	if len(hooks) != 1 || hooks[0] != time.Minute {
+		t.Errorf("hook calls = %v, want [1m0s]", hooks)
+	}
+	// sub-millisecond steps are not regressions
+	c.t = c.t.Add(time.Minute - 100*time.Millisecond)
+	g.New()
+	c.t = c.t.Add(-500 * time.Microsecond)
+	g.New()
+	if len(hooks) != 1 {
+		t.Errorf("hook calls = %v after a 500µs step, want no new call", hooks)
+	}
+	// a second regression, after recovering, is reported again
+	c.t = c.t.Add(time.Second)
+	g.New()
+	c.t = c.t.Add(-2 * time.Second)
+	g.New()
+	if len(hooks) != 2 || hooks[1] != 2*time.Second {
+		t.Errorf("hook calls = %v, want a second call of 2s", hooks)
+	}
+}
+
+func TestRegressionErrorPolicy(t *testing.T) {
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	g := NewGenerator(WithClock(c.now), WithRegressionPolicy(ErrorOnRegression))
+	if _, err := g.Generate(); err != nil {
+		t.Fatal(err)
+	}
+	c.t = c.t.Add(-5 * time.Second)
+	if id, err := g.Generate(); !errors.Is(err, ErrClockRegression) || id != Nil {
+		t.Errorf("Generate() after a step back = %v, %v, want Nil, ErrClockRegression", id, err)
+	}
+	func() {
+		defer func() {
+			if r, _ := recover().(error); !errors.Is(r, ErrClockRegression) {
+				t.Errorf("New() after a step back: recover() = %v, want ErrClockRegression", r)
+			}
+		}()
+		g.New()
+	}()
+	c.t = c.t.Add(5 * time.Second)
+	if _, err := g.Generate(); err != nil {
+		t.Errorf("Generate() after the clock caught up: %v", err)
+	}
+}
+
+func TestRegressionWaitPolicy(t *testing.T) {
+	start := time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
+	c := &fakeClock{t: start}
+	// each reading advances the clock a millisecond
+	tick := func() time.Time {
+		t := c.t
+		c.t = c.t.Add(time.Millisecond)
+		return t
+	}
+	g := NewGenerator(WithClock(tick), WithRegressionPolicy(WaitForClock))
+	g.New()
+	c.t = c.t.Add(-5 * time.Millisecond)
+	id := g.New()
+	if id.Time().Before(start) {
+		t.Errorf("Time() = %v, before the latest reading %v", id.Time(), start)
+	}
+	// waiting keeps timestamps true to the clock, within the millisecond
+	// absorbed by the sequence
+	if lead := id.Time().Sub(c.t.Add(-time.Millisecond)); lead > time.Millisecond {
+		t.Errorf("timestamp leads the clock by %v", lead)
+	}
+}
+
+func TestGenerateRandomError(t *testing.T) {
+	g := NewGenerator(WithRandom(bytes.NewReader(nil)))
+	if id, err := g.Generate(); err == nil || id != Nil {
+		t.Errorf("Generate() with a failing random source = %v, %v, want error", id, err)
+	}
+}
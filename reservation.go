@@ -0,0 +1,82 @@
+package kid
+
+import "fmt"
+
+// ReserveSequence atomically reserves n consecutive timestamp+sequence
+// slots, for pipelines that stamp IDs onto records in parallel workers after
+// a single point of coordination: one call claims a range of the
+// Generator's state, and NewReserved then builds the ID of each slot without
+// touching it again.
+//
+//	milli, seq := g.ReserveSequence(len(batch))
+//	for w := range workers {
+//		go func() {
+//			for i := w; i < len(batch); i += workers {
+//				batch[i].ID = g.NewReserved(milli, seq, i)
+//			}
+//		}()
+//	}
+//
+// The slots are those New would have issued for n consecutive calls: they
+// start at firstMilli and firstSeq and count up, the sequence carrying into
+// the timestamp past 4095, so the IDs are unique and sort in slot order,
+// after every ID issued before the call and before every ID issued after.
+// A reservation of more than a millisecond's worth of slots (4096) borrows
+// ahead of the clock, as a burst of New calls does.
+//
+// The RegressionPolicy, WithState and WithObserver apply as for New: an
+// Observer sees the Rollover calls n calls of New would make, and n Issued
+// calls, or one IssuedN call if it is a BatchObserver. Like New,
+// ReserveSequence panics on errors Generate would return; it also panics if
+// n < 1, or on a sharded Generator (WithShards), whose slots are not
+// consecutive.
+func (g *Generator) ReserveSequence(n int) (firstMilli, firstSeq int64) {
+	if n < 1 {
+		panic(fmt.Sprintf("kid: cannot reserve %d sequence slots", n))
+	}
+	if g.shards != nil {
+		panic("kid: ReserveSequence on a sharded Generator")
+	}
+	nano, err := g.reading()
+	if err != nil {
+		panic(err)
+	}
+	// as claim, claiming n slots rather than one
+	milli := nano / nanoPerMilli
+	start := milli<<12 + (nano-milli*nanoPerMilli)>>8
+	end := start + int64(n) - 1
+	// Every slot that opens a millisecond is a rollover, as for New, but
+	// for a first slot claimed from the clock.
+	from := start
+	if last := g.lastTime.Load(); !(start > last && g.lastTime.CompareAndSwap(last, end)) {
+		end = g.lastTime.Add(int64(n))
+		start = end - int64(n) + 1
+		from = start - 1
+	}
+	if g.obs != nil {
+		for range end>>12 - from>>12 {
+			g.obs.Rollover()
+		}
+		if g.obsN != nil {
+			g.obsN.IssuedN(n)
+		} else {
+			for range n {
+				g.obs.Issued()
+			}
+		}
+	}
+	if g.store != nil && end > g.ceiling.Load() {
+		g.reserve(end)
+	}
+	return start >> 12, start & 0xfff
+}
+
+// NewReserved returns the ID of slot i, counting from 0, of the reservation
+// ReserveSequence returned as firstMilli and firstSeq, with random bytes as
+// for New. i must be less than the number of slots reserved; each slot
+// should be used once. NewReserved is goroutine-safe, and panics only if the
+// random source (WithRandom) fails.
+func (g *Generator) NewReserved(firstMilli, firstSeq int64, i int) ID {
+	slot := firstMilli<<12 + firstSeq + int64(i)
+	return g.newID(slot>>12, slot&0xfff)
+}
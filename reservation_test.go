@@ -0,0 +1,155 @@
+package kid
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReserveSequence(t *testing.T) {
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	o := &countingObserver{}
+	g := NewGenerator(WithClock(c.now), WithObserver(o))
+	before := g.New()
+
+	// the clock stands still: 10000 slots carry through two milliseconds
+	const n = 10000
+	milli, seq := g.ReserveSequence(n)
+	after := g.New()
+	if milli != c.t.UnixMilli() || seq != 1 {
+		t.Errorf("ReserveSequence(%d) = %d, %d, want %d, 1", n, milli, seq, c.t.UnixMilli())
+	}
+	if o.issued != n+2 || o.rollovers != 2 {
+		t.Errorf("issued %d, rollovers %d; want %d, 2", o.issued, o.rollovers, n+2)
+	}
+
+	ids := make([]ID, n)
+	var wg sync.WaitGroup
+	const workers = 4
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w; i < n; i += workers {
+				ids[i] = g.NewReserved(milli, seq, i)
+			}
+		}()
+	}
+	wg.Wait()
+	prev := before
+	for i, id := range ids {
+		if id.Compare(prev) <= 0 {
+			t.Fatalf("slot %d: %v does not sort after %v", i, id, prev)
+		}
+		prev = id
+	}
+	if after.Compare(prev) <= 0 {
+		t.Errorf("New() after the reservation = %v, not after its last slot %v", after, prev)
+	}
+	if got, want := ids[n-1].Timestamp()-milli, int64(2); got != want {
+		t.Errorf("last slot %d ms after the first, want %d", got, want)
+	}
+
+	// with the clock ahead, the reservation starts from it
+	c.t = c.t.Add(time.Second)
+	if m, s := g.ReserveSequence(1); m != c.t.UnixMilli() || s != 0 {
+		t.Errorf("ReserveSequence(1) = %d, %d, want %d, 0", m, s, c.t.UnixMilli())
+	}
+}
+
+// batchObserver is a countingObserver taking IssuedN calls.
+type batchObserver struct {
+	countingObserver
+	batches int
+}
+
+func (o *batchObserver) IssuedN(n int) { o.issued += n; o.batches++ }
+
+// TestReserveSequenceObserved checks that a reservation is observed as the
+// New calls it stands for would be.
+func TestReserveSequenceObserved(t *testing.T) {
+	at := time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		prior     int           // IDs issued first, the clock standing still
+		ahead     time.Duration // then the clock moves on
+		n         int
+		rollovers int
+	}{
+		{"within a millisecond", 1, 0, 4095, 0},
+		{"onto the next millisecond", 1, 0, 4096, 1},
+		{"from a millisecond's first slot", MaxPerMillisecond, 0, 1, 1},
+		{"from a first slot, across a millisecond", MaxPerMillisecond, 0, 4097, 2},
+		{"from a first slot, across two", MaxPerMillisecond, 0, 3 * 4096, 3},
+		{"from the clock", 1, time.Millisecond, 4096, 0},
+		{"from the clock, across a millisecond", 1, time.Millisecond, 4097, 1},
+	}
+	for _, tt := range tests {
+		var want countingObserver
+		c := &fakeClock{t: at}
+		g := NewGenerator(WithClock(c.now), WithObserver(&want))
+		for range tt.prior {
+			g.New()
+		}
+		c.t = c.t.Add(tt.ahead)
+		for range tt.n {
+			g.New()
+		}
+
+		o, b := &countingObserver{}, &batchObserver{}
+		for _, obs := range []Observer{o, b} {
+			c := &fakeClock{t: at}
+			g := NewGenerator(WithClock(c.now), WithObserver(obs))
+			for range tt.prior {
+				g.New()
+			}
+			c.t = c.t.Add(tt.ahead)
+			g.ReserveSequence(tt.n)
+		}
+		if want.rollovers != tt.rollovers {
+			t.Fatalf("%s: New calls rolled over %d times, test wants %d", tt.name, want.rollovers, tt.rollovers)
+		}
+		if o.issued != want.issued || o.rollovers != want.rollovers {
+			t.Errorf("%s: issued %d, rollovers %d; want %d, %d", tt.name, o.issued, o.rollovers, want.issued, want.rollovers)
+		}
+		if b.issued != want.issued || b.rollovers != want.rollovers || b.batches != 1 {
+			t.Errorf("%s: BatchObserver issued %d in %d batches, rollovers %d; want %d in 1, %d",
+				tt.name, b.issued, b.batches, b.rollovers, want.issued, want.rollovers)
+		}
+	}
+}
+
+func TestReserveSequencePanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"cannot reserve 0":  func() { NewGenerator().ReserveSequence(0) },
+		"sharded Generator": func() { NewGenerator(WithShards(2)).ReserveSequence(1) },
+		"clock moved backwards": func() {
+			c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+			g := NewGenerator(WithClock(c.now), WithRegressionPolicy(ErrorOnRegression))
+			g.New()
+			c.t = c.t.Add(-time.Second)
+			g.ReserveSequence(1)
+		},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); !strings.Contains(fmtPanic(r), name) {
+					t.Errorf("recover() = %v, want panic containing %q", r, name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// fmtPanic returns the message of a recovered panic value.
+func fmtPanic(r any) string {
+	switch v := r.(type) {
+	case error:
+		return v.Error()
+	case string:
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package kid
+
+import (
+	"errors"
+	mrand "math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSet(t *testing.T) {
+	var s Set // the zero value is ready to use
+	if s.Contains(Nil) || s.Contains(tests[6].id) || s.Remove(tests[6].id) || s.Len() != 0 {
+		t.Fatalf("zero Set is not empty")
+	}
+
+	// check against a map under random adds and removes, including Nil
+	r := mrand.New(mrand.NewPCG(1, 2))
+	want := map[ID]bool{}
+	ids := make([]ID, 2000)
+	for i := range ids {
+		ids[i] = NewWithTime(time.UnixMilli(1741456227757 + int64(r.IntN(50))))
+	}
+	ids[0] = Nil
+	for range 20000 {
+		id := ids[r.IntN(len(ids))]
+		if r.IntN(3) == 0 {
+			if got := s.Remove(id); got != want[id] {
+				t.Fatalf("Remove(%v) = %v, want %v", id, got, want[id])
+			}
+			delete(want, id)
+		} else {
+			if got := s.Add(id); got == want[id] {
+				t.Fatalf("Add(%v) = %v, want %v", id, got, !want[id])
+			}
+			want[id] = true
+		}
+	}
+	if s.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", s.Len(), len(want))
+	}
+	for _, id := range ids {
+		if s.Contains(id) != want[id] {
+			t.Errorf("Contains(%v) = %v, want %v", id, !want[id], want[id])
+		}
+	}
+	sorted := s.Sorted()
+	if len(sorted) != len(want) || !slices.IsSortedFunc(sorted, ID.Compare) {
+		t.Errorf("Sorted() = %d IDs, sorted %v; want %d", len(sorted), slices.IsSortedFunc(sorted, ID.Compare), len(want))
+	}
+	for _, id := range sorted {
+		if !want[id] {
+			t.Errorf("Sorted() holds %v, not in the set", id)
+		}
+	}
+}
+
+func TestSetUnionIntersect(t *testing.T) {
+	a, b, c := New(), New(), New()
+	x, y := SetOf(a, b, Nil), SetOf(b, c, Nil)
+	if got, want := x.Union(y).Sorted(), []ID{Nil, a, b, c}; !slices.Equal(got, want) {
+		t.Errorf("Union() = %v, want %v", got, want)
+	}
+	if got, want := x.Intersect(y).Sorted(), []ID{Nil, b}; !slices.Equal(got, want) {
+		t.Errorf("Intersect() = %v, want %v", got, want)
+	}
+	if x.Len() != 3 || y.Len() != 3 {
+		t.Errorf("operands modified: Len() = %d, %d", x.Len(), y.Len())
+	}
+}
+
+func TestSetBinary(t *testing.T) {
+	g := NewGenerator()
+	s := NewSet(10000)
+	for range 10000 {
+		s.Add(g.New())
+	}
+	s.Add(Nil)
+	s.Add(Max)
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if per := float64(len(b)) / float64(s.Len()); per > 6 {
+		t.Errorf("MarshalBinary() = %.1f bytes per ID, want compact", per)
+	}
+	var got Set
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Sorted(), s.Sorted()) {
+		t.Errorf("UnmarshalBinary(MarshalBinary()) differs")
+	}
+
+	for _, bad := range [][]byte{nil, {2, 0}, {setVersion}, {setVersion, 5, 0, 1}, b[:len(b)-1], append(b[:len(b):len(b)], 0)} {
+		if err := got.UnmarshalBinary(bad); !errors.Is(err, errSetData) {
+			t.Errorf("UnmarshalBinary(%v...) = %v, want errSetData", bad[:min(len(bad), 4)], err)
+		}
+	}
+}
+
+func BenchmarkSetAdd(b *testing.B) {
+	ids := make([]ID, 1<<20)
+	for i := range ids {
+		ids[i] = New()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i += len(ids) {
+		s := NewSet(0)
+		for _, id := range ids[:min(len(ids), b.N-i)] {
+			s.Add(id)
+		}
+	}
+}
+
+func BenchmarkMapAdd(b *testing.B) {
+	ids := make([]ID, 1<<20)
+	for i := range ids {
+		ids[i] = New()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i += len(ids) {
+		m := map[ID]struct{}{}
+		for _, id := range ids[:min(len(ids), b.N-i)] {
+			m[id] = struct{}{}
+		}
+	}
+}
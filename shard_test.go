@@ -0,0 +1,114 @@
+package kid
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithShards(t *testing.T) {
+	// a stopped clock: every ID comes from the sequence
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	g := NewGenerator(WithClock(c.now), WithShards(8), WithNoRandom())
+	const goroutines, per = 8, 2000
+	results := make([][]ID, goroutines)
+	var wg sync.WaitGroup
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range per {
+				results[i] = append(results[i], g.New())
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[ID]bool)
+	last := make([]ID, 8) // per shard
+	for _, r := range results {
+		for _, id := range r {
+			if seen[id] {
+				t.Fatalf("duplicate ID %v", id)
+			}
+			seen[id] = true
+			if id.Sequence() > 0xfff {
+				t.Fatalf("%v: sequence %d beyond 12 bits", id, id.Sequence())
+			}
+		}
+	}
+	// within a shard, IDs ascend in the order issued
+	for _, r := range results {
+		for i := range last {
+			last[i] = Nil
+		}
+		for _, id := range r {
+			s := id.Sequence() & 7
+			if id.Compare(last[s]) <= 0 {
+				t.Fatalf("shard %d: %v does not sort after %v", s, id, last[s])
+			}
+			last[s] = id
+		}
+	}
+	// 16000 IDs at 4096 per millisecond borrow 3 milliseconds ahead
+	var maxTS int64
+	for id := range seen {
+		maxTS = max(maxTS, id.Timestamp())
+	}
+	if got, want := maxTS-c.t.UnixMilli(), int64(3); got > want+1 {
+		t.Errorf("borrowed %d ms ahead for %d IDs, want about %d", got, len(seen), want)
+	}
+}
+
+func TestWithShardsState(t *testing.T) {
+	store := &memState{}
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	g := NewGenerator(WithClock(c.now), WithShards(4), WithState(store))
+	var last ID
+	for range 1000 {
+		if id := g.New(); id.Compare(last) > 0 {
+			last = id
+		}
+	}
+	c.t = c.t.Add(-time.Minute)
+	g = NewGenerator(WithClock(c.now), WithShards(4), WithState(store))
+	for range 100 {
+		if id := g.New(); id.Compare(last) <= 0 {
+			t.Fatalf("after restart, %v does not sort after %v", id, last)
+		}
+	}
+}
+
+func TestWithShardsInvalid(t *testing.T) {
+	for _, n := range []int{0, 3, 128, -2} {
+		func() {
+			defer func() {
+				if r, _ := recover().(string); !strings.Contains(r, "shard count") {
+					t.Errorf("WithShards(%d): recover() = %q, want panic", n, r)
+				}
+			}()
+			NewGenerator(WithShards(n))
+		}()
+	}
+	// one shard is the unsharded default
+	if g := NewGenerator(WithShards(1)); g.shards != nil {
+		t.Errorf("WithShards(1) allocated %d shards", len(g.shards))
+	}
+}
+
+func BenchmarkNewSharded(b *testing.B) {
+	for _, n := range []int{1, 16} {
+		b.Run(fmt.Sprintf("shards=%d", n), func(b *testing.B) {
+			g := NewGenerator(WithShards(n))
+			var r ID
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					r = g.New()
+				}
+				benchResultID = r
+			})
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package kid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// ErrBadSignature is returned by VerifySigned when a signed ID's tag does
+// not match its ID under the key.
+var ErrBadSignature = errors.New("kid: invalid signature")
+
+// signedLen is the length of a signed ID: the encoded ID, then its encoded
+// 10-byte tag.
+const signedLen = 2 * encodedLen
+
+// NewSigned generates a new ID and returns it signed with key; see Sign.
+func NewSigned(key []byte) string {
+	return Sign(key, New())
+}
+
+// Sign returns id followed by a tag, HMAC-SHA256 of the ID's bytes under
+// key truncated to 80 bits, both in the kid encoding: 32 URL-safe characters
+// whose first 16 are the encoded ID, so signed IDs sort like their IDs.
+//
+// A server handing signed IDs to clients can reject forged or enumerated
+// IDs it receives back with VerifySigned, without a database lookup. key
+// should be at least 32 random bytes, kept secret, and rotated by verifying
+// against both keys for a while.
+func Sign(key []byte, id ID) string {
+	var b [signedLen]byte
+	encode(b[:encodedLen], id[:])
+	t := tag(key, id)
+	encode(b[encodedLen:], t[:])
+	return string(b[:])
+}
+
+// VerifySigned returns the ID of s, a signed ID from Sign, if its tag is
+// valid under key. It returns an error wrapping ErrInvalidID if s is not a
+// signed ID, and ErrBadSignature if the tag does not match.
+func VerifySigned(key []byte, s string) (ID, error) {
+	if len(s) != signedLen {
+		return Nil, fmt.Errorf("%w: not a signed ID: %q", ErrInvalidID, s)
+	}
+	id, err := FromString(s[:encodedLen])
+	if err != nil {
+		return Nil, fmt.Errorf("%w: not a signed ID: %q", err, s)
+	}
+	var got ID
+	if err := got.UnmarshalText([]byte(s[encodedLen:])); err != nil {
+		return Nil, fmt.Errorf("%w: not a signed ID: %q", err, s)
+	}
+	want := tag(key, id)
+	if !hmac.Equal(got[:], want[:]) {
+		return Nil, ErrBadSignature
+	}
+	return id, nil
+}
+
+// tag returns the truncated HMAC of id under key, held in an ID for
+// encoding.
+func tag(key []byte, id ID) (t ID) {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(id[:])
+	copy(t[:], mac.Sum(nil))
+	return t
+}
@@ -0,0 +1,32 @@
+package kid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSigned(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	s := NewSigned(key)
+	id, err := VerifySigned(key, s)
+	if err != nil || id.String() != s[:encodedLen] {
+		t.Fatalf("VerifySigned(%s) = %v, %v, want the ID it starts with", s, id, err)
+	}
+	if got := Sign(key, id); got != s {
+		t.Errorf("Sign() = %s, want %s: signing is deterministic", got, s)
+	}
+
+	if _, err := VerifySigned([]byte("another key"), s); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("VerifySigned(wrong key) err = %v, want ErrBadSignature", err)
+	}
+	// an enumerated ID, keeping the original tag
+	forged := Sign(key, id.Next())[:encodedLen] + s[encodedLen:]
+	if _, err := VerifySigned(key, forged); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("VerifySigned(forged) err = %v, want ErrBadSignature", err)
+	}
+	for _, bad := range []string{"", s[:encodedLen], s + "0", s[:signedLen-1] + "a"} {
+		if got, err := VerifySigned(key, bad); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("VerifySigned(%q) = %v, %v, want ErrInvalidID", bad, got, err)
+		}
+	}
+}
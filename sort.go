@@ -0,0 +1,85 @@
+package kid
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelSortThreshold is the slice length below which SortParallel sorts
+// serially: under it, goroutine and merge overhead outweigh the speedup.
+const parallelSortThreshold = 1 << 16
+
+// SortParallel sorts a slice of IDs in place, in ascending order, like Sort
+// but using up to GOMAXPROCS goroutines: the slice is cut into runs that are
+// sorted concurrently and then merged pairwise, each merge pass also running
+// concurrently. It allocates a scratch slice the size of ids, so it suits
+// very large slices (millions of IDs) on multi-core machines; below
+// parallelSortThreshold IDs, or with GOMAXPROCS of 1, it calls Sort.
+//
+// As with Sort, ids must not be accessed by other goroutines during the call.
+func SortParallel(ids []ID) {
+	procs := runtime.GOMAXPROCS(0)
+	if len(ids) < parallelSortThreshold || procs < 2 {
+		Sort(ids)
+		return
+	}
+	runs := min(procs, len(ids)/(parallelSortThreshold/4))
+	bounds := make([]int, runs+1)
+	for i := range bounds {
+		bounds[i] = i * len(ids) / runs
+	}
+
+	var wg sync.WaitGroup
+	for i := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Sort(ids[bounds[i]:bounds[i+1]])
+		}()
+	}
+	wg.Wait()
+
+	src, dst := ids, make([]ID, len(ids))
+	for len(bounds) > 2 {
+		next := []int{0}
+		for i := 0; i < len(bounds)-1; i += 2 {
+			lo := bounds[i]
+			if i+2 >= len(bounds) {
+				// odd run out: carry it over to the next pass unmerged
+				copy(dst[lo:], src[lo:])
+				next = append(next, len(ids))
+				break
+			}
+			mid, hi := bounds[i+1], bounds[i+2]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				merge(dst[lo:hi], src[lo:mid], src[mid:hi])
+			}()
+			next = append(next, hi)
+		}
+		wg.Wait()
+		src, dst, bounds = dst, src, next
+	}
+	if &src[0] != &ids[0] {
+		copy(ids, src)
+	}
+}
+
+// merge merges the sorted slices a and b into dst, which must have room for
+// both.
+func merge(dst, a, b []ID) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if b[j].Compare(a[i]) < 0 {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
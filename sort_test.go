@@ -0,0 +1,54 @@
+package kid
+
+import (
+	"crypto/rand"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func randomIDs(n int) []ID {
+	ids := make([]ID, n)
+	for i := range ids {
+		rand.Read(ids[i][:])
+	}
+	return ids
+}
+
+func TestSortParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	// odd run counts exercise the carried-over run; sizes straddle the
+	// serial threshold
+	for _, procs := range []int{1, 2, 3, 5, 8} {
+		runtime.GOMAXPROCS(procs)
+		for _, n := range []int{0, 1, 1000, parallelSortThreshold - 1, parallelSortThreshold, 3*parallelSortThreshold + 7} {
+			ids := randomIDs(n)
+			want := slices.Clone(ids)
+			Sort(want)
+			SortParallel(ids)
+			if !slices.Equal(ids, want) {
+				t.Fatalf("GOMAXPROCS=%d n=%d: SortParallel result differs from Sort", procs, n)
+			}
+		}
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	ids := randomIDs(1 << 20)
+	work := make([]ID, len(ids))
+	b.ResetTimer()
+	for range b.N {
+		copy(work, ids)
+		Sort(work)
+	}
+}
+
+func BenchmarkSortParallel(b *testing.B) {
+	ids := randomIDs(1 << 20)
+	work := make([]ID, len(ids))
+	b.ResetTimer()
+	for range b.N {
+		copy(work, ids)
+		SortParallel(work)
+	}
+}
@@ -0,0 +1,240 @@
+//go:build !kid_nosql
+
+package kid
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+// The database/sql interfaces live here, so that building with the
+// kid_nosql tag leaves package database/sql/driver out of small binaries
+// that have no use for it.
+
+// ScanTypeError is returned by the Scan methods for a value of a type they
+// do not accept.
+type ScanTypeError struct {
+	Value any // the value passed to Scan
+}
+
+func (e *ScanTypeError) Error() string {
+	return fmt.Sprintf("kid: scanning unsupported type: %T", e.Value)
+}
+
+// Value implements package sql's driver.Valuer, returning the ID in its
+// 16-byte encoded string form, or nil for the nil ID.
+// https://pkg.go.dev/database/sql/driver#Valuer
+func (id ID) Value() (driver.Value, error) {
+	if id.IsNil() {
+		return nil, nil
+	}
+	return id.String(), nil
+}
+
+// Scan implements the sql.Scanner interface, accepting the 16-byte encoded
+// form as a string or []byte, the 10-byte binary form as a []byte, or nil,
+// which yields the nil ID. IDs kept in uuid columns in their UUIDv7 form
+// (see ID.UUID) are accepted as a 16-byte []byte or a 36-character
+// canonical UUID string, and converted back as by FromUUIDv7.
+// https://pkg.go.dev/database/sql#Scanner
+func (id *ID) Scan(value any) error {
+	switch val := value.(type) {
+	case string:
+		if len(val) == uuidStrLen {
+			return id.scanUUID([]byte(val))
+		}
+		return id.UnmarshalText([]byte(val))
+	case []byte:
+		switch {
+		case len(val) == rawLen:
+			copy(id[:], val)
+			return nil
+		case len(val) == uuidStrLen,
+			// 16 bytes are also the length of the encoded form, but a
+			// binary UUID's variant bits give it a byte above ASCII
+			len(val) == uuidLen && val[8] >= 0x80:
+			return id.scanUUID(val)
+		}
+		return id.UnmarshalText(val)
+	case nil:
+		*id = Nil
+		return nil
+	default:
+		return &ScanTypeError{value}
+	}
+}
+
+// scanUUID sets id from u, a UUID in binary or canonical string form as read
+// from a uuid column, converting it as FromUUIDv7 does.
+func (id *ID) scanUUID(u []byte) error {
+	var b [uuidLen]byte
+	if len(u) == uuidStrLen {
+		if !parseUUID(&b, u) {
+			*id = Nil
+			return fmt.Errorf("%w: malformed UUID %q", ErrInvalidID, u)
+		}
+	} else {
+		copy(b[:], u)
+	}
+	var err error
+	*id, err = FromUUIDv7(b)
+	return err
+}
+
+// parseUUID decodes s, a canonical UUID string in either case, into u,
+// reporting whether s was well formed.
+func parseUUID(u *[uuidLen]byte, s []byte) bool {
+	if len(s) != uuidStrLen || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return false
+	}
+	dst := u[:]
+	for _, group := range [][2]int{{0, 8}, {9, 13}, {14, 18}, {19, 23}, {24, 36}} {
+		n, err := hex.Decode(dst, s[group[0]:group[1]])
+		if err != nil {
+			return false
+		}
+		dst = dst[n:]
+	}
+	return true
+}
+
+// Value implements package sql's driver.Valuer, returning the 10 bytes of b,
+// or nil for the nil ID.
+func (b Binary) Value() (driver.Value, error) {
+	if ID(b).IsNil() {
+		return nil, nil
+	}
+	return b[:], nil
+}
+
+// Scan implements the sql.Scanner interface, accepting only a []byte of
+// exactly 10 bytes, or nil, which yields the nil ID. Scan into an ID to also
+// accept the encoded string form.
+func (b *Binary) Scan(value any) error {
+	switch val := value.(type) {
+	case []byte:
+		if len(val) != rawLen {
+			*b = Binary{}
+			return fmt.Errorf("%w: scanning %d bytes, want %d", ErrInvalidID, len(val), rawLen)
+		}
+		copy(b[:], val)
+		return nil
+	case nil:
+		*b = Binary{}
+		return nil
+	default:
+		return &ScanTypeError{value}
+	}
+}
+
+// Value implements package sql's driver.Valuer, returning id as an int64
+// for BIGINT columns, or nil for the zero value.
+func (id ID64) Value() (driver.Value, error) {
+	if id.IsNil() {
+		return nil, nil
+	}
+	return id.Int64(), nil
+}
+
+// Scan implements the sql.Scanner interface, accepting an int64, the
+// 13-character encoded form as a string or []byte, the 8-byte binary form
+// as a []byte, or nil, which yields the zero value.
+func (id *ID64) Scan(value any) error {
+	switch val := value.(type) {
+	case int64:
+		*id = FromInt64(val)
+		return nil
+	case string:
+		return id.UnmarshalText([]byte(val))
+	case []byte:
+		if len(val) == len(id) {
+			copy(id[:], val)
+			return nil
+		}
+		return id.UnmarshalText(val)
+	case nil:
+		*id = ID64{}
+		return nil
+	default:
+		return &ScanTypeError{value}
+	}
+}
+
+// Value implements package sql's driver.Valuer, returning id in its
+// 26-character encoded form, or nil for the zero value. Store the binary
+// form, id.Bytes(), in 16-byte columns such as Postgres uuid or bytea.
+func (id ID128) Value() (driver.Value, error) {
+	if id.IsNil() {
+		return nil, nil
+	}
+	return id.String(), nil
+}
+
+// Scan implements the sql.Scanner interface, accepting the 26-character
+// encoded form as a string or []byte, the 16-byte binary form as a []byte,
+// or nil, which yields the zero value.
+func (id *ID128) Scan(value any) error {
+	switch val := value.(type) {
+	case string:
+		return id.UnmarshalText([]byte(val))
+	case []byte:
+		if len(val) == rawLen128 {
+			copy(id[:], val)
+			return nil
+		}
+		return id.UnmarshalText(val)
+	case nil:
+		*id = ID128{}
+		return nil
+	default:
+		return &ScanTypeError{value}
+	}
+}
+
+// Scan implements the sql.Scanner interface, accepting whatever ID.Scan
+// accepts; nil sets Valid to false.
+func (n *NullID) Scan(value any) error {
+	if value == nil {
+		*n = NullID{}
+		return nil
+	}
+	err := n.ID.Scan(value)
+	n.Valid = err == nil
+	return err
+}
+
+// Value implements package sql's driver.Valuer, returning nil if n is not
+// valid, or else the value of n.ID.
+func (n NullID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.ID.Value()
+}
+
+// Value implements package sql's driver.Valuer, returning t in its
+// prefix_id form, or nil for the zero value.
+func (t Typed) Value() (driver.Value, error) {
+	if t.IsNil() {
+		return nil, nil
+	}
+	text, err := t.MarshalText()
+	return string(text), err
+}
+
+// Scan implements the sql.Scanner interface, accepting the prefix_id form
+// as a string or []byte, or nil, which yields the zero value.
+func (t *Typed) Scan(value any) error {
+	switch val := value.(type) {
+	case string:
+		return t.UnmarshalText([]byte(val))
+	case []byte:
+		return t.UnmarshalText(val)
+	case nil:
+		*t = Typed{}
+		return nil
+	default:
+		return &ScanTypeError{value}
+	}
+}
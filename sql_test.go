@@ -0,0 +1,253 @@
+//go:build !kid_nosql
+
+package kid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIDDriverValue(t *testing.T) {
+	// 06bprg666xzm7hpg ts:1741277677111 seq:32579 rnd:49871 2025-03-06 16:14:37.111 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf }
+	id := ID{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf}
+	got, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "06bprg666xzm7hpg"; got != want {
+		t.Errorf("Value() = %v, want %v", got, want)
+	}
+	got, err = Nil.Value()
+	if got != nil && err != nil {
+		t.Errorf("Nil.Value() should return nil, nil, got: %v, %v", got, err)
+	}
+}
+
+func TestIDDriverScan(t *testing.T) {
+	// 06bprg666xzm7hpg ts:1741277677111 seq:32579 rnd:49871 2025-03-06 16:14:37.111 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf }
+	id := ID{}
+	err := id.Scan("06bprg666xzm7hpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ID{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf}
+	if !bytes.Equal(id[:], want[:]) {
+		t.Errorf("Scan() = %v, want %v", id, want)
+	}
+	id = ID{}
+	err = id.Scan(nil)
+	if err != nil || id != Nil {
+		t.Errorf("Nil.Scan(\"\") should return nil err, Nil. got: %v %v", err, id)
+	}
+}
+
+func TestIDDriverScanBinary(t *testing.T) {
+	// Scan must also accept the 10-byte binary form, e.g. from a BLOB column
+	want := ID{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf}
+	got := ID{}
+	if err := got.Scan(want.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("Scan(binary) = %v, want %v", got, want)
+	}
+	// a []byte of any other invalid length must fail
+	if err := got.Scan([]byte{0x1, 0x2, 0x3}); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("Scan(3 bytes) err=%v, want %v", err, ErrInvalidID)
+	}
+}
+
+func TestIDDriverScanByteFromDatabase(t *testing.T) {
+	// 06bprg666xzm7hpg ts:1741277677111 seq:32579 rnd:49871 2025-03-06 16:14:37.111 +0000 UTC ID{  0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf }
+	got := ID{}
+	bs := []byte("06bprg666xzm7hpg")
+	err := got.Scan(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ID{0x1, 0x95, 0x6c, 0x3c, 0xc6, 0x37, 0x7f, 0x43, 0xc2, 0xcf}
+	if !bytes.Equal(got[:], want[:]) {
+		t.Errorf("Scan() = %v, want %v", got, want)
+	}
+}
+
+func TestIDDriverScanError(t *testing.T) {
+	id := ID{}
+
+	if got, want := id.Scan(0), errors.New("kid: scanning unsupported type: int"); got.Error() != want.Error() {
+		t.Errorf("Scan() err=%v, want %v", got, want)
+	}
+	var typeErr *ScanTypeError
+	for _, s := range []interface{ Scan(any) error }{&id, new(Binary), new(ID64), new(ID128), new(Typed), new(NullID)} {
+		if err := s.Scan(1.5); !errors.As(err, &typeErr) || typeErr.Value != 1.5 {
+			t.Errorf("%T.Scan(1.5) = %v, want a *ScanTypeError", s, err)
+		}
+	}
+	if got, want := id.Scan("0"), ErrInvalidID; !errors.Is(got, want) {
+		t.Errorf("Scan() err=%v, want %v", got, want)
+	}
+	if id != Nil {
+		t.Errorf("Scan() id=%v, want %v", id, Nil)
+	}
+}
+
+func TestBinary(t *testing.T) {
+	id := tests[6].id
+	v, err := Binary(id).Value()
+	if b, ok := v.([]byte); err != nil || !ok || !bytes.Equal(b, id[:]) {
+		t.Errorf("Value() = %#v, %v, want %x", v, err, id[:])
+	}
+	if v, err := Binary(Nil).Value(); v != nil || err != nil {
+		t.Errorf("Binary(Nil).Value() = %#v, %v, want nil", v, err)
+	}
+
+	var got Binary
+	if err := got.Scan(id[:]); err != nil || ID(got) != id {
+		t.Errorf("Scan(%x) = %v, %v, want %v", id[:], got, err, id)
+	}
+	if err := got.Scan(nil); err != nil || ID(got) != Nil {
+		t.Errorf("Scan(nil) = %v, %v, want Nil", got, err)
+	}
+	for _, v := range []driver.Value{[]byte("06bqer9xnm79tfnl"), []byte{1, 2, 3}, []byte{}} {
+		got := Binary(id)
+		if err := got.Scan(v); !errors.Is(err, ErrInvalidID) || ID(got) != Nil {
+			t.Errorf("Scan(%q) = %v, %v, want ErrInvalidID", v, got, err)
+		}
+	}
+	if err := got.Scan("06bqer9xnm79tfnl"); err == nil {
+		t.Error("Scan(string) succeeded, want error")
+	}
+
+	// ID.Scan reads what Binary writes
+	var back ID
+	if v, _ := Binary(id).Value(); back.Scan(v) != nil || back != id {
+		t.Errorf("ID.Scan(Binary.Value()) = %v, want %v", back, id)
+	}
+}
+
+func TestNullIDSQL(t *testing.T) {
+	id := tests[6].id
+	var n NullID
+	if err := n.Scan("06bqer9xnm79tfnl"); err != nil || !n.Valid || n.ID != id {
+		t.Errorf("Scan(string) = %+v, %v, want valid %v", n, err, id)
+	}
+	if v, err := n.Value(); v != "06bqer9xnm79tfnl" || err != nil {
+		t.Errorf("Value() = %v, %v, want 06bqer9xnm79tfnl", v, err)
+	}
+	if err := n.Scan(nil); err != nil || n.Valid || n.ID != Nil {
+		t.Errorf("Scan(nil) = %+v, %v, want not valid", n, err)
+	}
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Errorf("Value() of null = %v, %v, want nil", v, err)
+	}
+	n = NullID{ID: id, Valid: true}
+	if err := n.Scan("bad"); err == nil || n.Valid {
+		t.Errorf("Scan(bad) = %+v, %v, want error and not valid", n, err)
+	}
+}
+
+func TestScanUUID(t *testing.T) {
+	id := tests[6].id
+	u := id.UUID()
+	for _, v := range []any{
+		u[:],
+		"019576e1-3dad-7e9d-8eac-c00000000000",
+		[]byte("019576E1-3DAD-7E9D-8EAC-C00000000000"),
+	} {
+		var got ID
+		if err := got.Scan(v); err != nil || got != id {
+			t.Errorf("Scan(%q) = %v, %v, want %v", v, got, err, id)
+		}
+	}
+	for _, v := range []any{
+		"9c5b94b1-35ad-49bb-b118-8e8fc24abf80", // version 4
+		"019576e1-3dad-7e9d-8eac-c00000000001", // extra random bits
+		"019576e13dad-7e9d-8eac-c00000000000-", // misplaced hyphens
+		"019576e1-3dad-7e9d-8eac-c0000000000g",
+	} {
+		got := id
+		if err := got.Scan(v); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("Scan(%q) = %v, %v, want ErrInvalidID", v, got, err)
+		}
+	}
+}
+
+func TestID64SQL(t *testing.T) {
+	id := New64()
+	val, err := id.Value()
+	if n, ok := val.(int64); err != nil || !ok || n != id.Int64() {
+		t.Errorf("Value() = %v (%T), %v, want int64 %d", val, val, err, id.Int64())
+	}
+	if val, _ := (ID64{}).Value(); val != nil {
+		t.Errorf("zero Value() = %v, want nil", val)
+	}
+	for _, src := range []any{id.Int64(), id.String(), []byte(id.String()), id.Bytes()} {
+		var got ID64
+		if err := got.Scan(src); err != nil || got != id {
+			t.Errorf("Scan(%T) = %v, %v, want %v", src, got, err, id)
+		}
+	}
+	var got ID64
+	if err := got.Scan(3.14); err == nil {
+		t.Error("Scan(float64) succeeded, want error")
+	}
+}
+
+func TestID128SQL(t *testing.T) {
+	id := New128()
+	val, err := id.Value()
+	if s, ok := val.(string); err != nil || !ok || s != id.String() {
+		t.Errorf("Value() = %v (%T), %v, want string %s", val, val, err, id)
+	}
+	if val, _ := (ID128{}).Value(); val != nil {
+		t.Errorf("zero Value() = %v, want nil", val)
+	}
+	for _, src := range []any{id.String(), []byte(id.String()), id.Bytes()} {
+		var got ID128
+		if err := got.Scan(src); err != nil || got != id {
+			t.Errorf("Scan(%T) = %v, %v, want %v", src, got, err, id)
+		}
+	}
+	var got ID128
+	if err := got.Scan(3.14); err == nil {
+		t.Error("Scan(float64) succeeded, want error")
+	}
+}
+
+func TestTypedSQL(t *testing.T) {
+	in := struct{ ID Typed }{NewTyped("acct")}
+	val, err := in.ID.Value()
+	if val != in.ID.String() || err != nil {
+		t.Errorf("Value() = %v, %v, want %s", val, err, in.ID)
+	}
+	for _, src := range []any{in.ID.String(), []byte(in.ID.String())} {
+		var got Typed
+		if err := got.Scan(src); err != nil || got != in.ID {
+			t.Errorf("Scan(%T) = %v, %v, want %v", src, got, err, in.ID)
+		}
+	}
+	var got Typed
+	if err := got.Scan(nil); err != nil || !got.IsNil() {
+		t.Errorf("Scan(nil) = %v, %v, want the zero value", got, err)
+	}
+}
+
+func TestScanCanonical(t *testing.T) {
+	const base = "06bqer9xnm79tfnl"
+	for _, pos := range []int{0, encodedLen - 1} {
+		for c := range 256 {
+			s := base[:pos] + string(byte(c)) + base[pos+1:]
+			valid := strings.IndexByte(encoding, byte(c)) >= 0
+			for _, v := range []any{s, []byte(s)} {
+				var id ID
+				err := id.Scan(v)
+				if valid && (err != nil || id.String() != s) || !valid && (!errors.Is(err, ErrInvalidID) || id != Nil) {
+					t.Errorf("Scan(%q) = %v, %v", v, id, err)
+				}
+			}
+		}
+	}
+}
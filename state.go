@@ -0,0 +1,91 @@
+package kid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// StateStore persists a Generator's high-water mark, so that IDs from a
+// restarted process sort after every ID issued before the restart — even if
+// it restarts within the same millisecond, or the clock reads earlier at
+// boot. Values are opaque int64s, meaningful only to Generators with the
+// same epoch.
+type StateStore interface {
+	// Load returns the last value stored, or 0 if there is none.
+	Load() (int64, error)
+	// Store durably records v, replacing any previous value.
+	Store(v int64) error
+}
+
+// stateLease is how far ahead of issued IDs a Generator reserves with its
+// StateStore: one second, in ts+seq units.
+const stateLease = 1000 << 12
+
+// WithState makes a Generator resume from, and record its progress in, s.
+// Rather than writing every ID, the Generator stores a ceiling one second
+// ahead of the IDs it issues, and stores a new ceiling before issuing past
+// it, so s is written at most about once per second. After a restart, IDs
+// resume above the stored ceiling: they may lead the clock by up to a
+// second, which the sequence absorbs as for any clock regression (see New).
+//
+// A store must be used by one Generator at a time; it does not coordinate
+// concurrent processes. Only New is covered; NewWithTime is independent of
+// the stored state. If s fails to load or store, the Generator panics, as
+// continuing could silently break ordering.
+func WithState(s StateStore) Option[Generator] {
+	return func(g *Generator) {
+		g.store = s
+	}
+}
+
+// FileState is a StateStore backed by a small text file, written atomically
+// by replacing it with a synced temporary file.
+type FileState struct {
+	path string
+}
+
+// NewFileState returns a FileState persisting to path. The file is created
+// on first store; until then Load returns 0.
+func NewFileState(path string) *FileState {
+	return &FileState{path: path}
+}
+
+// Load implements StateStore.
+func (f *FileState) Load() (int64, error) {
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", f.path, err)
+	}
+	return v, nil
+}
+
+// Store implements StateStore.
+func (f *FileState) Store(v int64) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	_, err = tmp.WriteString(strconv.FormatInt(v, 10) + "\n")
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
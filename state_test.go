@@ -0,0 +1,100 @@
+package kid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memState is an in-memory StateStore counting its writes.
+type memState struct {
+	v      int64
+	stores int
+	err    error
+}
+
+func (m *memState) Load() (int64, error) { return m.v, m.err }
+
+func (m *memState) Store(v int64) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.v = v
+	m.stores++
+	return nil
+}
+
+func TestWithStateRestart(t *testing.T) {
+	store := &memState{}
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	g := NewGenerator(WithClock(c.now), WithState(store))
+	var last ID
+	for range 5000 { // 5s of IDs, one per millisecond
+		last = g.New()
+		c.t = c.t.Add(time.Millisecond)
+	}
+	if store.stores < 5 || store.stores > 6 {
+		t.Errorf("%d stores for 5s of IDs, want one per second", store.stores)
+	}
+
+	// restart with the clock behind, as after a backwards step at boot
+	c.t = c.t.Add(-time.Minute)
+	g = NewGenerator(WithClock(c.now), WithState(store))
+	if id := g.New(); id.Compare(last) <= 0 {
+		t.Errorf("after restart, %v does not sort after %v", id, last)
+	}
+	// without state, the restarted generator would sort before
+	if id := NewGenerator(WithClock(c.now)).New(); id.Compare(last) >= 0 {
+		t.Errorf("stateless restart unexpectedly sorts after: %v", id)
+	}
+}
+
+func TestWithStateErrors(t *testing.T) {
+	fail := errors.New("disk on fire")
+	expectPanic := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if r, _ := recover().(error); !errors.Is(r, fail) {
+				t.Errorf("%s: recover() = %v, want panic wrapping %v", name, r, fail)
+			}
+		}()
+		f()
+	}
+	expectPanic("load", func() { NewGenerator(WithState(&memState{err: fail})) })
+
+	store := &memState{}
+	g := NewGenerator(WithState(store))
+	store.err = fail
+	expectPanic("store", func() {
+		c := &fakeClock{t: time.Now().Add(time.Hour)} // past the ceiling
+		g.now = c.now
+		g.New()
+	})
+}
+
+func TestFileState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kid.state")
+	f := NewFileState(path)
+	if v, err := f.Load(); v != 0 || err != nil {
+		t.Errorf("Load() before any Store = %d, %v, want 0, nil", v, err)
+	}
+	for _, v := range []int64{1, 7133078334173478912} {
+		if err := f.Store(v); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := NewFileState(path).Load(); got != v || err != nil {
+			t.Errorf("Load() = %d, %v, want %d", got, err, v)
+		}
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("state directory holds %d files, want 1 (temporary files left behind?)", len(entries))
+	}
+	os.WriteFile(path, []byte("garbage"), 0o600)
+	if _, err := f.Load(); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Load(corrupt) err = %v, want error naming the file", err)
+	}
+}
@@ -0,0 +1,155 @@
+package kid
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrQuotaExceeded is returned by Tenants.New when a tenant has used up its
+// quota (see WithTenantQuota).
+var ErrQuotaExceeded = errors.New("kid: tenant quota exceeded")
+
+// Tenants is a registry of per-tenant Generators, for multi-tenant services
+// that isolate ID issuance per customer within a process: each tenant gets a
+// dedicated Generator, configured per tenant (for example with its own
+// WithNode value, making tenants' IDs attributable), an optional rate quota,
+// and issuance counters.
+//
+// Generators are created on first use of a tenant key and kept for the
+// life of the registry. Tenants is goroutine-safe.
+type Tenants struct {
+	opts  func(tenant string) []Option[Generator]
+	rate  float64 // quota in IDs per second; 0 for none
+	burst float64
+
+	mu sync.RWMutex
+	m  map[string]*tenant
+}
+
+// tenant is the state of one tenant key.
+type tenant struct {
+	g                *Generator
+	issued, rejected atomic.Uint64
+
+	mu     sync.Mutex // guards tokens and filled
+	tokens float64
+	filled time.Time
+}
+
+// TenantStats counts the IDs issued and refused for one tenant.
+type TenantStats struct {
+	Issued   uint64
+	Rejected uint64 // refused with ErrQuotaExceeded
+}
+
+// WithTenantOptions sets the function returning the Generator options for
+// each new tenant, for example assigning each a node:
+//
+//	kid.NewTenants(kid.WithTenantOptions(func(tenant string) []kid.Option[kid.Generator] {
+//		return []kid.Option[kid.Generator]{kid.WithNode(nodes[tenant])}
+//	}))
+func WithTenantOptions(f func(tenant string) []Option[Generator]) Option[Tenants] {
+	return func(t *Tenants) {
+		t.opts = f
+	}
+}
+
+// WithTenantQuota limits each tenant to perSecond IDs per second on
+// average, allowing bursts of up to burst IDs; beyond that, Tenants.New
+// returns ErrQuotaExceeded. Quotas are measured against each tenant
+// Generator's clock. burst must be at least 1, as a bucket holding less
+// than one ID refuses every request; WithTenantQuota panics otherwise.
+func WithTenantQuota(perSecond float64, burst int) Option[Tenants] {
+	if burst < 1 {
+		panic(fmt.Sprintf("kid: tenant quota burst %d is less than 1", burst))
+	}
+	return func(t *Tenants) {
+		t.rate, t.burst = perSecond, float64(burst)
+	}
+}
+
+// NewTenants returns an empty registry configured by opts.
+func NewTenants(opts ...Option[Tenants]) *Tenants {
+	t := &Tenants{m: make(map[string]*tenant)}
+	apply(t, opts)
+	return t
+}
+
+// New generates an ID from the tenant's Generator, or returns
+// ErrQuotaExceeded if the tenant is over its quota. Errors of the
+// Generator, such as those its options (WithRegressionPolicy) make Generate
+// return, are returned too; the ID is then counted neither as issued nor
+// as rejected, though it used up quota.
+func (t *Tenants) New(key string) (ID, error) {
+	tn := t.get(key)
+	if t.rate > 0 && !tn.take(t.rate, t.burst) {
+		tn.rejected.Add(1)
+		return Nil, ErrQuotaExceeded
+	}
+	id, err := tn.g.Generate()
+	if err != nil {
+		return Nil, err
+	}
+	tn.issued.Add(1)
+	return id, nil
+}
+
+// Generator returns the tenant's Generator, creating it if needed. IDs
+// generated from it directly bypass the quota and counters.
+func (t *Tenants) Generator(key string) *Generator {
+	return t.get(key).g
+}
+
+// Stats returns the counters of every tenant seen so far.
+func (t *Tenants) Stats() map[string]TenantStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	stats := make(map[string]TenantStats, len(t.m))
+	for key, tn := range t.m {
+		stats[key] = TenantStats{Issued: tn.issued.Load(), Rejected: tn.rejected.Load()}
+	}
+	return stats
+}
+
+// get returns the state for key, creating it on first use.
+func (t *Tenants) get(key string) *tenant {
+	t.mu.RLock()
+	tn := t.m[key]
+	t.mu.RUnlock()
+	if tn != nil {
+		return tn
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tn = t.m[key]; tn == nil {
+		var opts []Option[Generator]
+		if t.opts != nil {
+			opts = t.opts(key)
+		}
+		tn = &tenant{g: NewGenerator(opts...), tokens: t.burst}
+		tn.filled = tn.g.now()
+		t.m[key] = tn
+	}
+	return tn
+}
+
+// take removes a token from the tenant's bucket, refilled at rate per
+// second up to burst, reporting whether one was available.
+func (tn *tenant) take(rate, burst float64) bool {
+	tn.mu.Lock()
+	defer tn.mu.Unlock()
+	now := tn.g.now()
+	if elapsed := now.Sub(tn.filled); elapsed > 0 {
+		tn.tokens = min(burst, tn.tokens+elapsed.Seconds()*rate)
+		tn.filled = now
+	}
+	if tn.tokens < 1 {
+		return false
+	}
+	tn.tokens--
+	return true
+}
@@ -0,0 +1,110 @@
+package kid
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTenants(t *testing.T) {
+	nodes := map[string]uint16{"acme": 1, "globex": 2}
+	ts := NewTenants(WithTenantOptions(func(tenant string) []Option[Generator] {
+		return []Option[Generator]{WithNode(nodes[tenant])}
+	}))
+	for tenant, node := range nodes {
+		id, err := ts.New(tenant)
+		if err != nil || id.Node() != node {
+			t.Errorf("New(%s) = %v (node %d), %v; want node %d", tenant, id, id.Node(), err, node)
+		}
+	}
+	if ts.Generator("acme") != ts.Generator("acme") || ts.Generator("acme") == ts.Generator("globex") {
+		t.Error("Generator() does not return one dedicated Generator per tenant")
+	}
+	want := map[string]TenantStats{"acme": {Issued: 1}, "globex": {Issued: 1}}
+	if got := ts.Stats(); len(got) != len(want) || got["acme"] != want["acme"] || got["globex"] != want["globex"] {
+		t.Errorf("Stats() = %v, want %v", got, want)
+	}
+}
+
+func TestTenantsQuota(t *testing.T) {
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	ts := NewTenants(
+		WithTenantOptions(func(string) []Option[Generator] { return []Option[Generator]{WithClock(c.now)} }),
+		WithTenantQuota(100, 10),
+	)
+	for i := range 10 {
+		if _, err := ts.New("acme"); err != nil {
+			t.Fatalf("burst ID %d: %v", i, err)
+		}
+	}
+	if _, err := ts.New("acme"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("New() past burst: err = %v, want ErrQuotaExceeded", err)
+	}
+	// other tenants are unaffected
+	if _, err := ts.New("globex"); err != nil {
+		t.Errorf("New(globex): %v", err)
+	}
+	// 100/s refills one token per 10ms
+	c.t = c.t.Add(25 * time.Millisecond)
+	for i := range 2 {
+		if _, err := ts.New("acme"); err != nil {
+			t.Errorf("refilled ID %d: %v", i, err)
+		}
+	}
+	if _, err := ts.New("acme"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("New() past refill: err = %v, want ErrQuotaExceeded", err)
+	}
+	if got, want := ts.Stats()["acme"], (TenantStats{Issued: 12, Rejected: 2}); got != want {
+		t.Errorf("Stats()[acme] = %+v, want %+v", got, want)
+	}
+}
+
+func TestTenantsGeneratorError(t *testing.T) {
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	ts := NewTenants(WithTenantOptions(func(string) []Option[Generator] {
+		return []Option[Generator]{WithClock(c.now), WithRegressionPolicy(ErrorOnRegression)}
+	}))
+	if _, err := ts.New("acme"); err != nil {
+		t.Fatal(err)
+	}
+	c.t = c.t.Add(-time.Second)
+	if id, err := ts.New("acme"); !errors.Is(err, ErrClockRegression) || !id.IsNil() {
+		t.Errorf("New() after a regression = %v, %v, want Nil, ErrClockRegression", id, err)
+	}
+	if got, want := ts.Stats()["acme"], (TenantStats{Issued: 1}); got != want {
+		t.Errorf("Stats()[acme] = %+v, want %+v", got, want)
+	}
+}
+
+func TestTenantsQuotaBurst(t *testing.T) {
+	for _, burst := range []int{0, -1} {
+		func() {
+			defer func() {
+				if r := recover(); !strings.Contains(fmtPanic(r), "burst") {
+					t.Errorf("WithTenantQuota(1, %d) panicked with %v, want a burst error", burst, r)
+				}
+			}()
+			WithTenantQuota(1, burst)
+		}()
+	}
+}
+
+func TestTenantsParallel(t *testing.T) {
+	ts := NewTenants()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				ts.New("acme")
+			}
+		}()
+	}
+	wg.Wait()
+	if got := ts.Stats()["acme"].Issued; got != 8000 {
+		t.Errorf("Issued = %d, want 8000", got)
+	}
+}
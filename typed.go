@@ -0,0 +1,119 @@
+package kid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Typed is an ID qualified by a resource type prefix, written Stripe-style
+// as the prefix, an underscore and the encoded ID:
+//
+//	usr_06bqer9xnm79tfnl
+//
+// A prefix is 1 to 32 characters of lower case ASCII letters, digits and
+// underscores, starting with a letter; the last underscore separates it
+// from the ID, which contains none. Typed values marshal to and from this
+// form as text, JSON and SQL values, the zero value as null.
+type Typed struct {
+	Prefix string
+	ID     ID
+}
+
+// maxPrefixLen is the longest prefix a Typed may carry.
+const maxPrefixLen = 32
+
+// NewTyped returns a new ID, from New, qualified by prefix. It panics if
+// prefix is not a valid prefix; see Typed.
+func NewTyped(prefix string) Typed {
+	if err := checkPrefix(prefix); err != nil {
+		panic(err)
+	}
+	return Typed{Prefix: prefix, ID: New()}
+}
+
+// ParseTyped splits a typed ID, such as "usr_06bqer9xnm79tfnl", into its
+// prefix and ID. Errors wrap ErrInvalidID.
+func ParseTyped(s string) (prefix string, id ID, err error) {
+	i := strings.LastIndexByte(s, '_')
+	if i < 0 {
+		return "", Nil, fmt.Errorf("%w: no type prefix: %q", ErrInvalidID, s)
+	}
+	if err := checkPrefix(s[:i]); err != nil {
+		return "", Nil, err
+	}
+	if id, err = FromString(s[i+1:]); err != nil {
+		return "", Nil, fmt.Errorf("%w: %q", err, s)
+	}
+	return s[:i], id, nil
+}
+
+// checkPrefix returns an error wrapping ErrInvalidID if p is not a valid
+// Typed prefix.
+func checkPrefix(p string) error {
+	if len(p) == 0 || len(p) > maxPrefixLen || p[0] < 'a' || p[0] > 'z' {
+		return fmt.Errorf("%w: bad type prefix %q", ErrInvalidID, p)
+	}
+	for i := range len(p) {
+		if c := p[i]; !('a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_') {
+			return fmt.Errorf("%w: bad type prefix %q", ErrInvalidID, p)
+		}
+	}
+	return nil
+}
+
+// String returns t as prefix_id.
+func (t Typed) String() string {
+	b := make([]byte, len(t.Prefix)+1+encodedLen)
+	copy(b, t.Prefix)
+	b[len(t.Prefix)] = '_'
+	encode(b[len(t.Prefix)+1:], t.ID[:])
+	return string(b)
+}
+
+// IsNil returns true if t is the zero value.
+func (t Typed) IsNil() bool {
+	return t.Prefix == "" && t.ID.IsNil()
+}
+
+// MarshalText implements encoding.TextMarshaler. It fails if t.Prefix is
+// not a valid prefix.
+func (t Typed) MarshalText() ([]byte, error) {
+	if err := checkPrefix(t.Prefix); err != nil {
+		return nil, err
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, as ParseTyped.
+func (t *Typed) UnmarshalText(text []byte) error {
+	prefix, id, err := ParseTyped(string(text))
+	*t = Typed{Prefix: prefix, ID: id}
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding t as a
+// quoted prefix_id string, or null for the zero value.
+func (t Typed) MarshalJSON() ([]byte, error) {
+	if t.IsNil() {
+		return []byte("null"), nil
+	}
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`"` + string(text) + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting only
+// null or a quoted prefix_id string.
+func (t *Typed) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*t = Typed{}
+		return nil
+	}
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		*t = Typed{}
+		return fmt.Errorf("%w: Typed JSON is not a string: %.32s", ErrInvalidID, b)
+	}
+	return t.UnmarshalText(b[1 : len(b)-1])
+}
@@ -0,0 +1,74 @@
+package kid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTyped(t *testing.T) {
+	typed := NewTyped("usr")
+	s := typed.String()
+	if !strings.HasPrefix(s, "usr_") || len(s) != 4+encodedLen {
+		t.Fatalf("String() = %s, want usr_ and an encoded ID", s)
+	}
+	if prefix, id, err := ParseTyped(s); err != nil || prefix != "usr" || id != typed.ID {
+		t.Errorf("ParseTyped(%s) = %s, %v, %v, want usr, %v", s, prefix, id, err, typed.ID)
+	}
+	if prefix, id, err := ParseTyped("sk_live_06bqer9xnm79tfnl"); err != nil || prefix != "sk_live" || id != tests[6].id {
+		t.Errorf("ParseTyped(sk_live_...) = %s, %v, %v", prefix, id, err)
+	}
+	for _, s := range []string{
+		"",
+		"06bqer9xnm79tfnl",
+		"_06bqer9xnm79tfnl",
+		"Usr_06bqer9xnm79tfnl",
+		"1usr_06bqer9xnm79tfnl",
+		"us-r_06bqer9xnm79tfnl",
+		"usr_06bqer9xnm79tfn",
+		"usr_06bqer9xnm79tfnL",
+		strings.Repeat("x", maxPrefixLen+1) + "_06bqer9xnm79tfnl",
+	} {
+		if prefix, id, err := ParseTyped(s); !errors.Is(err, ErrInvalidID) || prefix != "" || id != Nil {
+			t.Errorf("ParseTyped(%q) = %q, %v, %v, want ErrInvalidID", s, prefix, id, err)
+		}
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewTyped(bad prefix) did not panic")
+			}
+		}()
+		NewTyped("User")
+	}()
+}
+
+func TestTypedMarshaling(t *testing.T) {
+	type account struct {
+		ID    Typed
+		Owner Typed
+	}
+	in := account{ID: NewTyped("acct")}
+	b, err := json.Marshal(in)
+	if want := `{"ID":"` + in.ID.String() + `","Owner":null}`; err != nil || string(b) != want {
+		t.Errorf("json.Marshal() = %s, %v, want %s", b, err, want)
+	}
+	var out account
+	if err := json.Unmarshal(b, &out); err != nil || out != in {
+		t.Errorf("json.Unmarshal() = %+v, %v, want %+v", out, err, in)
+	}
+	if err := json.Unmarshal([]byte(`{"ID":"acct-06bqer9xnm79tfnl"}`), &out); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("json.Unmarshal(bad) err = %v, want ErrInvalidID", err)
+	}
+	if _, err := json.Marshal(Typed{Prefix: "Bad", ID: New()}); err == nil {
+		t.Error("json.Marshal() of a bad prefix succeeded")
+	}
+}
+
+func ExampleParseTyped() {
+	prefix, id, err := ParseTyped("usr_06bqer9xnm79tfnl")
+	fmt.Println(prefix, id, err)
+	// Output: usr 06bqer9xnm79tfnl <nil>
+}
@@ -0,0 +1,141 @@
+package kid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// ULID returns id as a ULID: the timestamp as the 48-bit ULID timestamp,
+// then the sequence and random bytes as the leading 32 bits of the ULID's
+// randomness, the rest zero. ULIDs sort like the IDs they came from, and
+// convert back with FromULID.
+func (id ID) ULID() string {
+	var u ULID
+	copy(u[:], id[:])
+	return u.String()
+}
+
+// FromULID returns the ID that ID.ULID converts to s. Decoding follows the
+// ULID specification (case-insensitive, Crockford aliases). It fails,
+// returning ErrInvalidID, if s is not a ULID or carries randomness beyond
+// the 32 bits an ID holds: such ULIDs, from other generators, cannot convert
+// losslessly.
+func FromULID(s string) (ID, error) {
+	u, err := ParseULID(s)
+	if err != nil {
+		return Nil, err
+	}
+	if binary.BigEndian.Uint64(u[8:])&0xffffffffffff != 0 {
+		return Nil, fmt.Errorf("%w: ULID has more randomness than an ID: %q", ErrInvalidID, s)
+	}
+	var id ID
+	copy(id[:], u[:rawLen])
+	return id, nil
+}
+
+// ULID is a 16-byte identifier in the ULID layout, issued by a Generator for
+// consumers that parse ULIDs, such as code written against oklog/ulid, so
+// generation can move to kid before they do. Its bytes are those of an
+// ID128:
+//
+//   - 6 bytes, timestamp, milliseconds since the Unix epoch
+//   - 2 bytes, sequence, as for ID
+//   - 8 bytes, random value
+//
+// and its String form is the 26-character ULID encoding, Crockford's base32
+// in upper case. The sequence leads the ULID's 80 bits of randomness, so
+// ULIDs from one Generator are strictly increasing, like its IDs; the
+// remaining 64 bits are random, wider than the two random bytes of an ID as
+// ULIDs are expected to be unique without coordination.
+type ULID [16]byte
+
+// NewULID generates a new ULID from the default Generator, which it shares
+// with New.
+func NewULID() ULID {
+	return std.NewULID()
+}
+
+// NewULID generates a new ULID from g's timestamp+sequence state, shared
+// with g.New and g.New128, and with random bytes as for New128. A ULID's
+// timestamp counts from the Unix epoch whatever g's epoch (WithEpoch), so
+// ULID consumers read the right time.
+//
+// Like New, NewULID panics on errors Generate would return.
+func (g *Generator) NewULID() ULID {
+	u := ULID(g.New128())
+	if off := g.epoch / nanoPerMilli; off != 0 {
+		ts := binary.BigEndian.Uint64(u[:8])
+		binary.BigEndian.PutUint64(u[:8], ts+uint64(off)<<16) //nolint:gosec
+	}
+	return u
+}
+
+// ParseULID decodes s, any ULID, following the ULID specification
+// (case-insensitive, Crockford aliases). On error it returns the zero ULID
+// and an *InvalidLengthError or *InvalidCharError, wrapping ErrInvalidID.
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if err := u.UnmarshalText([]byte(s)); err != nil {
+		return ULID{}, err
+	}
+	return u, nil
+}
+
+// String implements fmt.Stringer, returning u as a 26-character ULID.
+func (u ULID) String() string {
+	hi, lo := binary.BigEndian.Uint64(u[:8]), binary.BigEndian.Uint64(u[8:])
+	var text [26]byte
+	for i := len(text) - 1; i >= 0; i-- {
+		text[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(text[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any ULID as
+// ParseULID does; on error, u is set to the zero value and an
+// *InvalidLengthError or *InvalidCharError, wrapping ErrInvalidID, is
+// returned.
+func (u *ULID) UnmarshalText(text []byte) error {
+	if len(text) != 26 {
+		*u = ULID{}
+		return &InvalidLengthError{Got: len(text), Want: 26}
+	}
+	if crockfordDec[text[0]] > 7 {
+		*u = ULID{}
+		return &InvalidCharError{Pos: 0, Char: text[0]}
+	}
+	var hi, lo uint64
+	for i, c := range text {
+		d := crockfordDec[c]
+		if d == maxByte {
+			*u = ULID{}
+			return &InvalidCharError{Pos: i, Char: c}
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(d)
+	}
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return nil
+}
+
+// Time returns the timestamp of u as a Time with millisecond resolution.
+// Location is set to UTC.
+func (u ULID) Time() time.Time {
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(u[:8]) >> 16)).UTC() //nolint:gosec
+}
+
+// Compare returns an integer comparing two ULIDs with bytes.Compare
+// semantics, which orders them as their strings sort.
+func (u ULID) Compare(other ULID) int {
+	return bytes.Compare(u[:], other[:])
+}
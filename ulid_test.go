@@ -0,0 +1,95 @@
+package kid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestULID(t *testing.T) {
+	id := tests[6].id // 06bqer9xnm79tfnl
+	want := "01JNVE2FDD1TEKNCR000000000"
+	if got := id.ULID(); got != want {
+		t.Errorf("ULID() = %s, want %s", got, want)
+	}
+	for _, s := range []string{want, strings.ToLower(want), "0IJNVE2FDD1TEKNCROOOOOOOOO"} {
+		if got, err := FromULID(s); err != nil || got != id {
+			t.Errorf("FromULID(%s) = %v, %v, want %v", s, got, err, id)
+		}
+	}
+	for _, id := range []ID{Nil, Max} {
+		if got, err := FromULID(id.ULID()); err != nil || got != id {
+			t.Errorf("FromULID(%v.ULID()) = %v, %v", id, got, err)
+		}
+	}
+
+	prev := New().ULID()
+	for range 1000 {
+		u := New().ULID()
+		if u <= prev {
+			t.Fatalf("ULID %s does not sort after %s", u, prev)
+		}
+		prev = u
+	}
+
+	for _, s := range []string{
+		"",
+		"01JNVE2FDD1TEKNCR00000000",  // short
+		"81JNVE2FDD1TEKNCR000000000", // overflows 128 bits
+		"01JNVE2FDD1TEKNCR00000000U", // U is not in the alphabet
+		"01JNVE2FDD1TEKNCR000000001", // randomness an ID cannot hold
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV", // a ULID from another generator
+	} {
+		if got, err := FromULID(s); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("FromULID(%q) = %v, %v, want ErrInvalidID", s, got, err)
+		}
+	}
+}
+
+func TestNewULID(t *testing.T) {
+	// a ULID from another generator round-trips
+	const other = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	u, err := ParseULID(strings.ToLower(other))
+	if err != nil || u.String() != other {
+		t.Fatalf("ParseULID(%s) = %v, %v", other, u, err)
+	}
+	if got, want := u.Time(), time.UnixMilli(1469922850259).UTC(); !got.Equal(want) {
+		t.Errorf("Time() = %v, want %v", got, want)
+	}
+	for _, s := range []string{"", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU"} {
+		if got, err := ParseULID(s); !errors.Is(err, ErrInvalidID) || got != (ULID{}) {
+			t.Errorf("ParseULID(%q) = %v, %v, want ErrInvalidID", s, got, err)
+		}
+	}
+
+	now := time.UnixMilli(1741456227757)
+	g := NewGenerator(WithClock(func() time.Time { return now }))
+	prev := g.NewULID()
+	if got := prev.Time(); !got.Equal(now) {
+		t.Errorf("Time() = %v, want %v", got, now)
+	}
+	for range 1000 {
+		u := g.NewULID()
+		if u.Compare(prev) <= 0 || u.String() <= prev.String() {
+			t.Fatalf("ULID %s does not sort after %s", u, prev)
+		}
+		if len(u.String()) != 26 {
+			t.Fatalf("len(%s) = %d, want 26", u, len(u.String()))
+		}
+		prev = u
+	}
+
+	// timestamps count from the Unix epoch whatever the Generator's epoch
+	g = NewGenerator(WithClock(func() time.Time { return now }),
+		WithEpoch(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+	if got := g.NewULID().Time(); !got.Equal(now) {
+		t.Errorf("Time() with epoch = %v, want %v", got, now)
+	}
+
+	text, _ := prev.MarshalText()
+	var back ULID
+	if err := back.UnmarshalText(text); err != nil || back != prev {
+		t.Errorf("UnmarshalText(%s) = %v, %v, want %v", text, back, err, prev)
+	}
+}
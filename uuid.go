@@ -0,0 +1,53 @@
+package kid
+
+import "fmt"
+
+const (
+	uuidLen    = 16 // binary UUID
+	uuidStrLen = 36 // canonical UUID string, 8-4-4-4-12 hex digits
+)
+
+// UUID returns id as an RFC 9562 version 7 UUID, for databases and services
+// that only accept UUIDs. The fields map in order, preserving sort order:
+//
+//   - unix_ts_ms, 48 bits: the timestamp
+//   - ver, 4 bits: 7
+//   - rand_a, 12 bits: the sequence
+//   - var, 2 bits: 0b10
+//   - rand_b, 62 bits: the two random bytes, then zeros
+//
+// The timestamp must count from the Unix epoch (the default; see WithEpoch)
+// for the UUID's timestamp to be meaningful. Only the low 12 bits of the
+// sequence fit, which covers every sequence New produces; IDs with larger
+// sequences (as from Max or Next arithmetic) do not round-trip through
+// FromUUIDv7.
+func (id ID) UUID() [16]byte {
+	var u [16]byte
+	copy(u[:6], id[:6])
+	u[6] = 0x70 | id[6]&0x0f // version 7
+	u[7] = id[7]
+	u[8] = 0x80 | id[8]>>2 // variant 0b10
+	u[9] = id[8]<<6 | id[9]>>2
+	u[10] = id[9] << 6
+	return u
+}
+
+// FromUUIDv7 returns the ID that UUID converts to u. It fails, returning
+// ErrInvalidID, unless u is a version 7, variant 0b10 UUID whose random bits
+// beyond the first 16 of rand_b are zero: other UUIDv7s carry more entropy
+// than an ID can hold, and cannot convert losslessly.
+func FromUUIDv7(u [16]byte) (ID, error) {
+	if u[6]>>4 != 7 || u[8]>>6 != 0b10 {
+		return Nil, fmt.Errorf("%w: not a version 7 UUID", ErrInvalidID)
+	}
+	if u[10]&0x3f != 0 || u[11]|u[12]|u[13]|u[14]|u[15] != 0 {
+		return Nil, fmt.Errorf("%w: UUIDv7 has more random bits than an ID", ErrInvalidID)
+	}
+	var id ID
+	copy(id[:6], u[:6])
+	id[6] = u[6] & 0x0f
+	id[7] = u[7]
+	id[8] = u[8]<<2 | u[9]>>6
+	id[9] = u[9]<<2 | u[10]>>6
+	return id, nil
+}
@@ -0,0 +1,44 @@
+package kid
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestUUID(t *testing.T) {
+	id := tests[6].id // 06bqer9xnm79tfnl, seq 3741, rnd 15027
+	u := id.UUID()
+	if got, want := hex.EncodeToString(u[:]), "019576e13dad7e9d8eacc00000000000"; got != want {
+		t.Errorf("UUID() = %s, want %s", got, want)
+	}
+	if got, err := FromUUIDv7(u); err != nil || got != id {
+		t.Errorf("FromUUIDv7(UUID()) = %v, %v, want %v", got, err, id)
+	}
+
+	// sort order is preserved
+	prev := New().UUID()
+	for range 1000 {
+		u := New().UUID()
+		if string(u[:]) <= string(prev[:]) {
+			t.Fatalf("UUID %x does not sort after %x", u, prev)
+		}
+		prev = u
+	}
+
+	bad := func(f func(u *[16]byte)) [16]byte {
+		u := id.UUID()
+		f(&u)
+		return u
+	}
+	for name, u := range map[string][16]byte{
+		"version 4":     bad(func(u *[16]byte) { u[6] = 0x40 | u[6]&0x0f }),
+		"variant 0b11":  bad(func(u *[16]byte) { u[8] |= 0x40 }),
+		"extra rand_b":  bad(func(u *[16]byte) { u[15] = 1 }),
+		"extra rand_b2": bad(func(u *[16]byte) { u[10] |= 0x01 }),
+	} {
+		if got, err := FromUUIDv7(u); !errors.Is(err, ErrInvalidID) || got != Nil {
+			t.Errorf("FromUUIDv7(%s) = %v, %v, want ErrInvalidID", name, got, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package kid
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Warmup's clock calibration watches for calibrateSteps clock steps, for at
+// most calibrateFor, which a clock that stands still would otherwise never
+// finish.
+const (
+	calibrateSteps = 8
+	calibrateFor   = 10 * time.Millisecond
+)
+
+// WarmupStats reports the work done by Generator.Warmup.
+type WarmupStats struct {
+	// Random is the time taken by the first read from the random source
+	// (WithRandom), filling the read-ahead pool. It is zero for
+	// math/rand/v2's generator, which the runtime seeds, with
+	// WithUnbufferedRandom, which has no pool to fill, or if the pool
+	// already held bytes.
+	Random time.Duration
+
+	// Clock is the time taken by one reading of the Generator's clock.
+	Clock time.Duration
+
+	// Resolution is the smallest step between successive distinct clock
+	// readings seen, or zero if the clock did not advance within 10ms, as
+	// for a clock that stands still (WithClock).
+	Resolution time.Duration
+}
+
+// Warmup performs ahead of time the work the first IDs from g would
+// otherwise pay for, so a latency-sensitive service can do it before
+// taking traffic: the first read from the random source, which fills the
+// read-ahead pool and may be a system call or a hardware RNG round trip,
+// and a first reading of the clock. With WithUnbufferedRandom it does not
+// read the source: bytes read ahead would have to be held for the next ID,
+// or discarded, shifting a deterministic source's output. It also calibrates the clock, reading
+// it for up to 10ms to find its resolution: New takes its sequence from
+// the sub-millisecond part of a reading, so with a coarse clock IDs within
+// a tick are numbered by the sequence alone.
+//
+// Warmup returns the random source's error, if any, or ctx's error if ctx
+// is done first, with the stats gathered so far. It claims no
+// timestamp+sequence and may be called at any time.
+func (g *Generator) Warmup(ctx context.Context) (WarmupStats, error) {
+	var st WarmupStats
+	if err := ctx.Err(); err != nil {
+		return st, err
+	}
+	if g.custom.Load() {
+		var err error
+		if st.Random, err = g.warmRandom(); err != nil {
+			return st, err
+		}
+	}
+
+	start := time.Now()
+	prev := g.now()
+	st.Clock = time.Since(start)
+	for steps := 0; steps < calibrateSteps && time.Since(start) < calibrateFor; {
+		if err := ctx.Err(); err != nil {
+			return st, err
+		}
+		now := g.now()
+		if step := now.Sub(prev); step > 0 {
+			if st.Resolution == 0 || step < st.Resolution {
+				st.Resolution = step
+			}
+			steps++
+		}
+		prev = now
+	}
+	return st, nil
+}
+
+// warmRandom fills the read-ahead pool from the random source, if it is
+// empty, returning the time it took.
+func (g *Generator) warmRandom() (time.Duration, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.rand == nil || g.unbuffered || g.rpos < g.rend {
+		return 0, nil
+	}
+	start := time.Now()
+	if err := g.refill(1); err != nil {
+		return 0, fmt.Errorf("kid: reading random bytes: %w", err)
+	}
+	return time.Since(start), nil
+}
@@ -0,0 +1,71 @@
+package kid
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestWarmup(t *testing.T) {
+	src := &readCounter{r: bytes.NewReader(make([]byte, randPoolSize))}
+	g := NewGenerator(WithRandom(src))
+	st, err := g.Warmup(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.reads != 1 {
+		t.Errorf("Warmup() read the source %d times, want once", src.reads)
+	}
+	if st.Resolution <= 0 || st.Resolution > calibrateFor {
+		t.Errorf("Warmup() Resolution = %v, want a step of the wall clock", st.Resolution)
+	}
+	g.New()
+	if src.reads != 1 {
+		t.Errorf("New() after Warmup read the source again")
+	}
+	// the pool is warm: nothing more to read
+	if st, err := g.Warmup(context.Background()); err != nil || st.Random != 0 || src.reads != 1 {
+		t.Errorf("second Warmup() = %+v, %v after %d reads, want no read", st, err, src.reads)
+	}
+
+	// unbuffered, the first ID still takes the source's first bytes
+	g = NewGenerator(WithRandom(bytes.NewReader([]byte{0xab, 0xcd})), WithUnbufferedRandom())
+	if _, err := g.Warmup(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.New().Random(); got != 0xabcd {
+		t.Errorf("Random() after unbuffered Warmup = %#x, want %#x", got, 0xabcd)
+	}
+
+	// a clock that stands still has no resolution to find
+	c := &fakeClock{t: time.Date(2026, 7, 6, 12, 0, 0, 0, time.UTC)}
+	st, err = NewGenerator(WithClock(c.now)).Warmup(context.Background())
+	if err != nil || st.Random != 0 || st.Resolution != 0 {
+		t.Errorf("Warmup() with a fixed clock = %+v, %v, want zero stats", st, err)
+	}
+}
+
+func TestWarmupErrors(t *testing.T) {
+	errRNG := errors.New("rng offline")
+	if _, err := NewGenerator(WithRandom(iotest.ErrReader(errRNG))).Warmup(context.Background()); !errors.Is(err, errRNG) {
+		t.Errorf("Warmup() error = %v, want %v", err, errRNG)
+	}
+	// unbuffered, the source is left for the IDs, which see its error
+	src := &readCounter{r: iotest.ErrReader(errRNG)}
+	g := NewGenerator(WithRandom(src), WithUnbufferedRandom())
+	if st, err := g.Warmup(context.Background()); err != nil || st.Random != 0 || src.reads != 0 {
+		t.Errorf("unbuffered Warmup() = %+v, %v after %d reads, want no read", st, err, src.reads)
+	}
+	if _, err := g.Generate(); !errors.Is(err, errRNG) {
+		t.Errorf("Generate() after Warmup() error = %v, want %v", err, errRNG)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewGenerator().Warmup(ctx); err != context.Canceled {
+		t.Errorf("Warmup(canceled) error = %v, want %v", err, context.Canceled)
+	}
+}